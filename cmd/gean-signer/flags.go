@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+var errInvalidConfig = errors.New("invalid gean-signer configuration")
+
+type config struct {
+	ConfigDir            string
+	NodeID               string
+	Listen               string
+	TLSCert              string
+	TLSKey               string
+	TLSClientCA          string
+	HistoryFile          string
+	AllowNoTLS           bool
+	KeystorePasswordFile string
+}
+
+type configPaths struct {
+	validators string
+	keysDir    string
+}
+
+func parseConfig(args []string, stderr io.Writer) (config, error) {
+	var cfg config
+	fs := flag.NewFlagSet("gean-signer", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&cfg.ConfigDir, "custom-network-config-dir", "", "Config directory holding annotated_validators.yaml and hash-sig-keys (required)")
+	fs.StringVar(&cfg.NodeID, "node-id", "", "Node identifier whose keys are served, e.g. gean_0 (required)")
+	fs.StringVar(&cfg.Listen, "listen", "127.0.0.1:9100", "Listen address")
+	fs.StringVar(&cfg.TLSCert, "tls-cert", "", "Server certificate (PEM)")
+	fs.StringVar(&cfg.TLSKey, "tls-key", "", "Server certificate key (PEM)")
+	fs.StringVar(&cfg.TLSClientCA, "tls-client-ca", "", "Require client certificates signed by this CA (PEM)")
+	fs.StringVar(&cfg.HistoryFile, "history-file", "", "Signing history file (required)")
+	fs.StringVar(&cfg.KeystorePasswordFile, "keystore-password-file", "", "Password for encrypted validator keystores; prompts on the terminal when unset")
+	fs.BoolVar(&cfg.AllowNoTLS, "insecure-no-tls", false, "Serve plain HTTP; only for local testing")
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	if cfg.ConfigDir == "" || cfg.NodeID == "" || cfg.HistoryFile == "" {
+		fmt.Fprintln(stderr, "required flags: --custom-network-config-dir, --node-id, --history-file")
+		fs.Usage()
+		return cfg, errInvalidConfig
+	}
+	if (cfg.TLSCert == "") != (cfg.TLSKey == "") {
+		fmt.Fprintln(stderr, "--tls-cert and --tls-key must be set together")
+		return cfg, errInvalidConfig
+	}
+	if cfg.TLSCert == "" && !cfg.AllowNoTLS {
+		fmt.Fprintln(stderr, "--tls-cert/--tls-key are required unless --insecure-no-tls is set")
+		return cfg, errInvalidConfig
+	}
+	if cfg.TLSCert == "" && cfg.TLSClientCA != "" {
+		fmt.Fprintln(stderr, "--tls-client-ca requires --tls-cert")
+		return cfg, errInvalidConfig
+	}
+	return cfg, nil
+}
+
+func (c config) paths() configPaths {
+	return configPaths{
+		validators: filepath.Join(c.ConfigDir, "annotated_validators.yaml"),
+		keysDir:    filepath.Join(c.ConfigDir, "hash-sig-keys"),
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParseConfig_RequiresTLS(t *testing.T) {
+	base := []string{"--custom-network-config-dir", "/c", "--node-id", "n", "--history-file", "/h.json"}
+
+	var stderr bytes.Buffer
+	if _, err := parseConfig(base, &stderr); !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("err=%v, want errInvalidConfig without TLS", err)
+	}
+	if _, err := parseConfig(append(base, "--insecure-no-tls"), &stderr); err != nil {
+		t.Fatalf("insecure-no-tls: %v", err)
+	}
+	if _, err := parseConfig(append(base, "--tls-cert", "c.pem", "--tls-key", "k.pem", "--tls-client-ca", "ca.pem"), &stderr); err != nil {
+		t.Fatalf("mtls: %v", err)
+	}
+	if _, err := parseConfig(append(base, "--tls-cert", "c.pem"), &stderr); !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("err=%v, want errInvalidConfig for cert without key", err)
+	}
+}
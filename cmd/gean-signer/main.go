@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/geanlabs/gean/internal/keystore"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/remotesigner"
+	"github.com/geanlabs/gean/xmss"
+)
+
+func main() {
+	cfg, err := parseConfig(os.Args[1:], os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+	if err := run(cfg); err != nil {
+		logger.Error(logger.Signature, "fatal: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config) error {
+	if xmss.Insecure {
+		logger.Warn(logger.Signature, "built with xmss_insecure: signatures are forgeable, use for local testing only")
+	}
+	paths := cfg.paths()
+	keyManager, err := xmss.LoadValidatorKeysWithPasswords(paths.validators, paths.keysDir, cfg.NodeID,
+		keystore.PasswordFlag(cfg.KeystorePasswordFile, os.Stderr))
+	if err != nil {
+		return err
+	}
+	defer keyManager.Close()
+	if len(keyManager.ValidatorIDs()) == 0 {
+		return errors.New("no validator keys assigned to node " + cfg.NodeID)
+	}
+
+	history, err := xmss.LoadSigningHistory(cfg.HistoryFile)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           remotesigner.NewServer(keyManager, history).Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	if cfg.TLSCert != "" {
+		tlsCfg, err := remotesigner.ServerTLSConfig(cfg.TLSCert, cfg.TLSKey, cfg.TLSClientCA)
+		if err != nil {
+			return err
+		}
+		srv.TLSConfig = tlsCfg
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info(logger.Signature, "gean signer serving %d validators on %s tls=%t mtls=%t",
+			len(keyManager.ValidatorIDs()), cfg.Listen, cfg.TLSCert != "", cfg.TLSClientCA != "")
+		if srv.TLSConfig != nil {
+			errCh <- srv.ListenAndServeTLS("", "")
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	case <-ctx.Done():
+	}
+
+	logger.Info(logger.Signature, "shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+const maxResponseBytes = 10 << 20
+
+// errRejected marks a 4xx response: the request itself is bad, so retrying it
+// against another node would not help.
+var errRejected = errors.New("request rejected by beacon node")
+
+type proposerDuty struct {
+	Slot           uint64 `json:"slot"`
+	ValidatorIndex uint64 `json:"validator_index"`
+}
+
+type proposerDutiesResponse struct {
+	NumValidators uint64         `json:"num_validators"`
+	Duties        []proposerDuty `json:"duties"`
+}
+
+type payloadError struct {
+	DataRoot     string `json:"data_root"`
+	Error        string `json:"error"`
+	ExpectedSkip bool   `json:"expected_skip"`
+}
+
+type produceBlockResponse struct {
+	Slot                  uint64         `json:"slot"`
+	ProposerIndex         uint64         `json:"proposer_index"`
+	BlockRoot             string         `json:"block_root"`
+	Block                 string         `json:"block"`
+	AttestationSignatures []string       `json:"attestation_signatures"`
+	PayloadErrors         []payloadError `json:"payload_errors"`
+}
+
+type sszUnmarshaler interface {
+	UnmarshalSSZ(buf []byte) error
+}
+
+type beaconClient struct {
+	nodes   []string
+	http    *http.Client
+	timeout time.Duration
+
+	mu      sync.Mutex
+	current int
+}
+
+func newBeaconClient(nodes []string, timeout time.Duration) *beaconClient {
+	return &beaconClient{
+		nodes:   nodes,
+		http:    &http.Client{},
+		timeout: timeout,
+	}
+}
+
+func (c *beaconClient) ProposerDuties(ctx context.Context, fromSlot, count uint64) (*proposerDutiesResponse, error) {
+	q := url.Values{}
+	q.Set("from_slot", strconv.FormatUint(fromSlot, 10))
+	q.Set("count", strconv.FormatUint(count, 10))
+	body, err := c.do(ctx, http.MethodGet, "/lean/v0/validator/duties/proposer?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp proposerDutiesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode proposer duties: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *beaconClient) AttestationData(ctx context.Context, slot uint64) (*types.AttestationData, error) {
+	body, err := c.do(ctx, http.MethodGet, "/lean/v0/validator/attestation_data?slot="+strconv.FormatUint(slot, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+	data := new(types.AttestationData)
+	if err := data.UnmarshalSSZ(body); err != nil {
+		return nil, fmt.Errorf("decode attestation data: %w", err)
+	}
+	return data, nil
+}
+
+func (c *beaconClient) SubmitAttestation(ctx context.Context, att *types.SignedAttestation) error {
+	payload, err := att.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("encode attestation: %w", err)
+	}
+	_, err = c.do(ctx, http.MethodPost, "/lean/v0/validator/attestations", payload)
+	return err
+}
+
+func (c *beaconClient) ProduceBlock(ctx context.Context, slot, proposerIndex uint64) (*types.SignedBlock, *produceBlockResponse, error) {
+	path := fmt.Sprintf("/lean/v0/validator/blocks/%d?proposer_index=%d", slot, proposerIndex)
+	body, err := c.doAccept(ctx, http.MethodGet, path, nil, "application/json")
+	if err != nil {
+		return nil, nil, err
+	}
+	var resp produceBlockResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, nil, fmt.Errorf("decode block response: %w", err)
+	}
+
+	block := new(types.Block)
+	if err := unmarshalHexSSZ(resp.Block, block); err != nil {
+		return nil, nil, fmt.Errorf("decode block: %w", err)
+	}
+	proofs := make([]*types.AggregatedSignatureProof, 0, len(resp.AttestationSignatures))
+	for i, raw := range resp.AttestationSignatures {
+		proof := new(types.AggregatedSignatureProof)
+		if err := unmarshalHexSSZ(raw, proof); err != nil {
+			return nil, nil, fmt.Errorf("decode attestation signature %d: %w", i, err)
+		}
+		proofs = append(proofs, proof)
+	}
+	return &types.SignedBlock{
+		Block:     block,
+		Signature: &types.BlockSignatures{AttestationSignatures: proofs},
+	}, &resp, nil
+}
+
+func (c *beaconClient) PublishBlock(ctx context.Context, signedBlock *types.SignedBlock) error {
+	payload, err := signedBlock.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("encode block: %w", err)
+	}
+	_, err = c.do(ctx, http.MethodPost, "/lean/v0/blocks", payload)
+	return err
+}
+
+// do tries each beacon node once, starting from the last one that answered,
+// and sticks to whichever node succeeds.
+func (c *beaconClient) do(ctx context.Context, method, path string, payload []byte) ([]byte, error) {
+	return c.doAccept(ctx, method, path, payload, "")
+}
+
+func (c *beaconClient) doAccept(ctx context.Context, method, path string, payload []byte, accept string) ([]byte, error) {
+	c.mu.Lock()
+	start := c.current
+	c.mu.Unlock()
+
+	var lastErr error
+	for i := range c.nodes {
+		idx := (start + i) % len(c.nodes)
+		body, err := c.doOnce(ctx, c.nodes[idx], method, path, payload, accept)
+		if err == nil {
+			c.mu.Lock()
+			if c.current != idx {
+				logger.Info(logger.Validator, "switched beacon node to %s", c.nodes[idx])
+			}
+			c.current = idx
+			c.mu.Unlock()
+			return body, nil
+		}
+		if errors.Is(err, errRejected) || ctx.Err() != nil {
+			return nil, err
+		}
+		logger.Warn(logger.Validator, "beacon node %s failed %s %s: %v", c.nodes[idx], method, path, err)
+		lastErr = err
+	}
+	return nil, fmt.Errorf("all beacon nodes failed: %w", lastErr)
+}
+
+func (c *beaconClient) doOnce(ctx context.Context, node, method, path string, payload []byte, accept string) ([]byte, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(reqCtx, method, node+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	switch {
+	case resp.StatusCode >= 500:
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("%w: status %d: %s", errRejected, resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+func unmarshalHexSSZ(s string, v sszUnmarshaler) error {
+	if !strings.HasPrefix(s, "0x") {
+		return fmt.Errorf("missing 0x prefix")
+	}
+	raw, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalSSZ(raw)
+}
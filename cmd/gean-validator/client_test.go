@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestBeaconClientFailsOverOnServerError(t *testing.T) {
+	var badHits, goodHits atomic.Int32
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		badHits.Add(1)
+		http.Error(w, "node busy", http.StatusServiceUnavailable)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		goodHits.Add(1)
+		w.Write([]byte(`{"num_validators":3,"duties":[{"slot":4,"validator_index":1}]}`))
+	}))
+	defer good.Close()
+
+	c := newBeaconClient([]string{bad.URL, good.URL}, time.Second)
+	duties, err := c.ProposerDuties(context.Background(), 4, 1)
+	if err != nil {
+		t.Fatalf("ProposerDuties: %v", err)
+	}
+	if len(duties.Duties) != 1 || duties.Duties[0].ValidatorIndex != 1 {
+		t.Fatalf("unexpected duties: %+v", duties)
+	}
+
+	// The working node is remembered, so the failed node is not retried first.
+	if _, err := c.ProposerDuties(context.Background(), 5, 1); err != nil {
+		t.Fatalf("second ProposerDuties: %v", err)
+	}
+	if badHits.Load() != 1 || goodHits.Load() != 2 {
+		t.Fatalf("hits bad=%d good=%d, want 1/2", badHits.Load(), goodHits.Load())
+	}
+}
+
+func TestBeaconClientDoesNotFailOverOnRejection(t *testing.T) {
+	var secondHits atomic.Int32
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad slot", http.StatusBadRequest)
+	}))
+	defer first.Close()
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondHits.Add(1)
+	}))
+	defer second.Close()
+
+	c := newBeaconClient([]string{first.URL, second.URL}, time.Second)
+	_, err := c.AttestationData(context.Background(), 1)
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("err=%v, want errRejected", err)
+	}
+	if secondHits.Load() != 0 {
+		t.Fatal("rejected request was retried on another node")
+	}
+}
+
+func TestBeaconClientAllNodesDown(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	c := newBeaconClient([]string{down.URL, down.URL}, 100*time.Millisecond)
+	if _, err := c.ProposerDuties(context.Background(), 1, 1); err == nil {
+		t.Fatal("expected error when every node is unreachable")
+	}
+}
+
+func TestBeaconClientProduceBlockDecodesJSON(t *testing.T) {
+	block := &types.Block{Slot: 7, ProposerIndex: 1, Body: &types.BlockBody{}}
+	blockSSZ, err := block.MarshalSSZ()
+	if err != nil {
+		t.Fatalf("marshal block: %v", err)
+	}
+	proof := &types.AggregatedSignatureProof{Participants: []byte{0x03}, ProofData: []byte{0xaa}}
+	proofSSZ, err := proof.MarshalSSZ()
+	if err != nil {
+		t.Fatalf("marshal proof: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/json" {
+			http.Error(w, "want json", http.StatusNotAcceptable)
+			return
+		}
+		json.NewEncoder(w).Encode(produceBlockResponse{
+			Slot:                  7,
+			Block:                 "0x" + hex.EncodeToString(blockSSZ),
+			AttestationSignatures: []string{"0x" + hex.EncodeToString(proofSSZ)},
+			PayloadErrors:         []payloadError{{DataRoot: "0x01", Error: "boom"}},
+		})
+	}))
+	defer srv.Close()
+
+	c := newBeaconClient([]string{srv.URL}, time.Second)
+	signedBlock, resp, err := c.ProduceBlock(context.Background(), 7, 1)
+	if err != nil {
+		t.Fatalf("ProduceBlock: %v", err)
+	}
+	if signedBlock.Block.Slot != 7 || len(signedBlock.Signature.AttestationSignatures) != 1 {
+		t.Fatalf("unexpected block: slot=%d proofs=%d", signedBlock.Block.Slot, len(signedBlock.Signature.AttestationSignatures))
+	}
+	if len(resp.PayloadErrors) != 1 {
+		t.Fatalf("payload errors=%d, want 1", len(resp.PayloadErrors))
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/internal/validatorkeys"
+	"github.com/geanlabs/gean/xmss"
+)
+
+type validatorClient struct {
+	beacon      *beaconClient
+	keys        *xmss.KeyManager
+	spec        types.ChainSpec
+	genesisTime uint64
+	warnEpochs  uint64
+
+	// Slots already signed per validator, so a retried tick or a failover to
+	// a node with a different head never double-signs the same slot.
+	proposedSlots map[uint64]uint64
+	attestedSlots map[uint64]uint64
+}
+
+func newValidatorClient(beacon *beaconClient, keys *xmss.KeyManager, spec types.ChainSpec, genesisTime, warnEpochs uint64) *validatorClient {
+	return &validatorClient{
+		beacon:        beacon,
+		keys:          keys,
+		spec:          spec,
+		genesisTime:   genesisTime,
+		warnEpochs:    warnEpochs,
+		proposedSlots: make(map[uint64]uint64),
+		attestedSlots: make(map[uint64]uint64),
+	}
+}
+
+func (v *validatorClient) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(v.spec.MillisecondsPerInterval()) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			v.onTick(ctx, uint64(time.Now().UnixMilli()))
+		}
+	}
+}
+
+func (v *validatorClient) onTick(ctx context.Context, nowMs uint64) {
+	if nowMs < v.genesisTime*1000 {
+		return
+	}
+	slot := v.spec.CurrentSlot(v.genesisTime, nowMs)
+	switch v.spec.CurrentInterval(v.genesisTime, nowMs) {
+	case 0:
+		if slot%validatorkeys.WarnIntervalSlots == 0 {
+			validatorkeys.WarnExpiring(v.keys, slot, v.warnEpochs, logger.Validator)
+		}
+		if slot > 0 {
+			v.propose(ctx, slot)
+		}
+	case 1:
+		v.attest(ctx, slot)
+	}
+}
+
+func (v *validatorClient) propose(ctx context.Context, slot uint64) {
+	duties, err := v.beacon.ProposerDuties(ctx, slot, 1)
+	if err != nil {
+		logger.Error(logger.Validator, "fetch proposer duties slot=%d: %v", slot, err)
+		return
+	}
+	for _, duty := range duties.Duties {
+		if duty.Slot != slot || v.keys.GetProposalKey(duty.ValidatorIndex) == nil {
+			continue
+		}
+		if last, ok := v.proposedSlots[duty.ValidatorIndex]; ok && last >= slot {
+			continue
+		}
+		v.proposeFor(ctx, slot, duty.ValidatorIndex)
+	}
+}
+
+func (v *validatorClient) proposeFor(ctx context.Context, slot, validatorID uint64) {
+	signedBlock, resp, err := v.beacon.ProduceBlock(ctx, slot, validatorID)
+	if err != nil {
+		logger.Error(logger.Validator, "produce block slot=%d validator=%d: %v", slot, validatorID, err)
+		return
+	}
+	for _, payloadErr := range resp.PayloadErrors {
+		if !payloadErr.ExpectedSkip {
+			logger.Warn(logger.Validator, "block payload issue slot=%d root=%s: %s", slot, payloadErr.DataRoot, payloadErr.Error)
+		}
+	}
+	if err := checkProducedBlock(signedBlock, slot, validatorID); err != nil {
+		logger.Error(logger.Validator, "refusing to sign produced block slot=%d validator=%d: %v", slot, validatorID, err)
+		return
+	}
+	blockRoot, err := signedBlock.Block.HashTreeRoot()
+	if err != nil {
+		logger.Error(logger.Validator, "block root slot=%d: %v", slot, err)
+		return
+	}
+	sig, err := v.keys.SignBlock(validatorID, slot, blockRoot)
+	if err != nil {
+		logger.Error(logger.Validator, "sign block slot=%d validator=%d: %v", slot, validatorID, err)
+		return
+	}
+	v.proposedSlots[validatorID] = slot
+	signedBlock.Signature.ProposerSignature = sig
+
+	if err := v.beacon.PublishBlock(ctx, signedBlock); err != nil {
+		logger.Error(logger.Validator, "publish block slot=%d validator=%d: %v", slot, validatorID, err)
+		return
+	}
+	logger.Info(logger.Validator, "proposed block slot=%d validator=%d block_root=0x%x", slot, validatorID, blockRoot)
+}
+
+// checkProducedBlock makes sure a beacon node returned the block that was
+// asked for, since any node in the failover list may answer.
+func checkProducedBlock(signedBlock *types.SignedBlock, slot, validatorID uint64) error {
+	if signedBlock == nil || signedBlock.Block == nil || signedBlock.Signature == nil {
+		return fmt.Errorf("no block in response")
+	}
+	if block := signedBlock.Block; block.Slot != slot || block.ProposerIndex != validatorID {
+		return fmt.Errorf("got block for slot %d proposer %d", block.Slot, block.ProposerIndex)
+	}
+	return nil
+}
+
+func (v *validatorClient) attest(ctx context.Context, slot uint64) {
+	data, err := v.beacon.AttestationData(ctx, slot)
+	if err != nil {
+		logger.Error(logger.Validator, "fetch attestation data slot=%d: %v", slot, err)
+		return
+	}
+	for _, vid := range v.keys.ValidatorIDs() {
+		if last, ok := v.attestedSlots[vid]; ok && last >= slot {
+			continue
+		}
+		sig, err := v.keys.SignAttestation(vid, data)
+		if err != nil {
+			logger.Error(logger.Validator, "sign attestation slot=%d validator=%d: %v", slot, vid, err)
+			continue
+		}
+		v.attestedSlots[vid] = slot
+
+		att := &types.SignedAttestation{ValidatorID: vid, Data: data, Signature: sig}
+		if err := v.beacon.SubmitAttestation(ctx, att); err != nil {
+			logger.Error(logger.Validator, "submit attestation slot=%d validator=%d: %v", slot, vid, err)
+			continue
+		}
+		logger.Info(logger.Validator, "submitted attestation slot=%d validator=%d", slot, vid)
+	}
+}
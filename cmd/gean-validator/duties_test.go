@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestCheckProducedBlock(t *testing.T) {
+	block := func(slot, proposer uint64) *types.SignedBlock {
+		return &types.SignedBlock{
+			Block:     &types.Block{Slot: slot, ProposerIndex: proposer, Body: &types.BlockBody{}},
+			Signature: &types.BlockSignatures{},
+		}
+	}
+	if err := checkProducedBlock(block(7, 1), 7, 1); err != nil {
+		t.Fatalf("matching block: %v", err)
+	}
+	cases := map[string]*types.SignedBlock{
+		"nil":            nil,
+		"no block":       {Signature: &types.BlockSignatures{}},
+		"wrong slot":     block(8, 1),
+		"wrong proposer": block(7, 2),
+	}
+	for name, signedBlock := range cases {
+		if err := checkProducedBlock(signedBlock, 7, 1); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestProposeForSkipsMismatchedBlock(t *testing.T) {
+	blockSSZ, err := (&types.Block{Slot: 8, ProposerIndex: 1, Body: &types.BlockBody{}}).MarshalSSZ()
+	if err != nil {
+		t.Fatalf("marshal block: %v", err)
+	}
+	var published atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			published.Add(1)
+			return
+		}
+		json.NewEncoder(w).Encode(produceBlockResponse{Slot: 8, Block: "0x" + hex.EncodeToString(blockSSZ)})
+	}))
+	defer srv.Close()
+
+	// No keys: reaching the signer would panic.
+	v := newValidatorClient(newBeaconClient([]string{srv.URL}, time.Second), nil, types.DevnetSpec(), 0, 0)
+	v.proposeFor(context.Background(), 7, 1)
+	if published.Load() != 0 {
+		t.Fatal("published a block for the wrong slot")
+	}
+	if _, ok := v.proposedSlots[1]; ok {
+		t.Fatal("recorded a proposal for a block that was not signed")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/geanlabs/gean/internal/validatorkeys"
+)
+
+var errInvalidConfig = errors.New("invalid gean-validator configuration")
+
+type config struct {
+	ConfigDir      string
+	NodeID         string
+	BeaconNodes    []string
+	RequestTimeout time.Duration
+	validatorkeys.Config
+}
+
+type configPaths struct {
+	config     string
+	validators string
+	keysDir    string
+}
+
+func parseConfig(args []string, stderr io.Writer) (config, error) {
+	var cfg config
+	fs := flag.NewFlagSet("gean-validator", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	beaconNodes := ""
+	fs.StringVar(&cfg.ConfigDir, "custom-network-config-dir", "", "Config directory (required)")
+	fs.StringVar(&cfg.NodeID, "node-id", "", "Node identifier used to select validator keys, e.g. gean_0 (required)")
+	fs.StringVar(&beaconNodes, "beacon-nodes", "http://127.0.0.1:5052", "Comma-separated gean API URLs, tried in order on failure")
+	fs.DurationVar(&cfg.RequestTimeout, "request-timeout", 2*time.Second, "Per-request timeout against a beacon node")
+
+	validatorkeys.RegisterFlags(fs, &cfg.Config)
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	if cfg.ConfigDir == "" || cfg.NodeID == "" {
+		fmt.Fprintln(stderr, "required flags: --custom-network-config-dir, --node-id")
+		fs.Usage()
+		return cfg, errInvalidConfig
+	}
+	if cfg.RequestTimeout <= 0 {
+		fmt.Fprintln(stderr, "--request-timeout must be > 0")
+		return cfg, errInvalidConfig
+	}
+
+	if err := cfg.Config.Validate(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return cfg, errInvalidConfig
+	}
+
+	nodes, err := parseBeaconNodes(beaconNodes, stderr)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.BeaconNodes = nodes
+	return cfg, nil
+}
+
+func parseBeaconNodes(raw string, stderr io.Writer) ([]string, error) {
+	var nodes []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		u, err := url.Parse(part)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fmt.Fprintf(stderr, "invalid beacon node URL %q\n", part)
+			return nil, errInvalidConfig
+		}
+		nodes = append(nodes, strings.TrimRight(part, "/"))
+	}
+	if len(nodes) == 0 {
+		fmt.Fprintln(stderr, "--beacon-nodes must list at least one URL")
+		return nil, errInvalidConfig
+	}
+	return nodes, nil
+}
+
+func (c config) paths() configPaths {
+	return configPaths{
+		config:     filepath.Join(c.ConfigDir, "config.yaml"),
+		validators: filepath.Join(c.ConfigDir, "annotated_validators.yaml"),
+		keysDir:    filepath.Join(c.ConfigDir, "hash-sig-keys"),
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseConfig_ValidDefaults(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseConfig([]string{"--custom-network-config-dir", "/config", "--node-id", "gean_0"}, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if !reflect.DeepEqual(cfg.BeaconNodes, []string{"http://127.0.0.1:5052"}) {
+		t.Fatalf("beacon nodes=%v, want default", cfg.BeaconNodes)
+	}
+	if cfg.RequestTimeout != 2*time.Second {
+		t.Fatalf("request timeout=%s, want 2s", cfg.RequestTimeout)
+	}
+}
+
+func TestParseConfig_BeaconNodes(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseConfig([]string{
+		"--custom-network-config-dir", "/config",
+		"--node-id", "gean_0",
+		"--beacon-nodes", "http://a:5052/, https://b:5052,,",
+	}, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if !reflect.DeepEqual(cfg.BeaconNodes, []string{"http://a:5052", "https://b:5052"}) {
+		t.Fatalf("beacon nodes=%v", cfg.BeaconNodes)
+	}
+}
+
+func TestParseConfig_Rejects(t *testing.T) {
+	cases := map[string][]string{
+		"missing required": nil,
+		"bad url":          {"--custom-network-config-dir", "/c", "--node-id", "n", "--beacon-nodes", "ftp://x"},
+		"empty nodes":      {"--custom-network-config-dir", "/c", "--node-id", "n", "--beacon-nodes", ","},
+		"zero timeout":     {"--custom-network-config-dir", "/c", "--node-id", "n", "--request-timeout", "0s"},
+		"signer ca no url": {"--custom-network-config-dir", "/c", "--node-id", "n", "--remote-signer-ca", "ca.pem"},
+		"signer cert only": {"--custom-network-config-dir", "/c", "--node-id", "n", "--remote-signer-url", "https://s:9100", "--remote-signer-cert", "c.pem"},
+	}
+	for name, args := range cases {
+		t.Run(name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if _, err := parseConfig(args, &stderr); !errors.Is(err, errInvalidConfig) {
+				t.Fatalf("err=%v, want errInvalidConfig", err)
+			}
+		})
+	}
+}
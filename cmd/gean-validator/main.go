@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/geanlabs/gean/internal/genesis"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/validatorkeys"
+	"github.com/geanlabs/gean/xmss"
+)
+
+func main() {
+	cfg, err := parseConfig(os.Args[1:], os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+	if err := run(cfg); err != nil {
+		logger.Error(logger.Validator, "fatal: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config) error {
+	logger.Info(logger.Validator, "gean validator client starting: beacon_nodes=%v", cfg.BeaconNodes)
+	if xmss.Insecure {
+		logger.Warn(logger.Validator, "built with xmss_insecure: signatures are forgeable, use for local testing only")
+	}
+	paths := cfg.paths()
+
+	genesisConfig, err := genesis.LoadGenesisConfig(paths.config)
+	if err != nil {
+		return err
+	}
+	spec, err := genesisConfig.Spec()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	keyManager, err := validatorkeys.Load(ctx, cfg.Config, paths.validators, paths.keysDir, cfg.NodeID, logger.Validator)
+	if err != nil {
+		return err
+	}
+	defer keyManager.Close()
+	if len(keyManager.ValidatorIDs()) == 0 {
+		return errors.New("no validator keys assigned to node " + cfg.NodeID)
+	}
+	logger.Info(logger.Validator, "loaded validator keys: %v", keyManager.ValidatorIDs())
+	if err := validatorkeys.CheckLifetimes(keyManager, spec, genesisConfig.GenesisTime, cfg.KeyExpiryWarnEpochs, logger.Validator); err != nil {
+		return err
+	}
+
+	beacon := newBeaconClient(cfg.BeaconNodes, cfg.RequestTimeout)
+	newValidatorClient(beacon, keyManager, spec, genesisConfig.GenesisTime, cfg.KeyExpiryWarnEpochs).run(ctx)
+
+	logger.Info(logger.Validator, "shutting down")
+	return nil
+}
@@ -3,12 +3,11 @@
 package main
 
 import (
+	"net/http"
+
 	"github.com/geanlabs/gean/internal/api"
-	"github.com/geanlabs/gean/internal/forkchoice"
-	"github.com/geanlabs/gean/internal/role"
-	"github.com/geanlabs/gean/internal/store"
 )
 
-func startAPIServer(address string, s *store.ConsensusStore, fc *forkchoice.ForkChoice, aggCtl *role.Controller) error {
-	return api.StartAPIServer(address, s, fc, aggCtl)
+func newAPIServer(address string, svc api.Services) *http.Server {
+	return api.NewAPIServer(address, svc)
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/geanlabs/gean/internal/checkpoint"
 	"github.com/geanlabs/gean/internal/genesis"
@@ -9,17 +11,19 @@ import (
 	"github.com/geanlabs/gean/internal/p2p"
 	"github.com/geanlabs/gean/internal/store"
 	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/internal/validatorkeys"
 	"github.com/geanlabs/gean/xmss"
 	"github.com/multiformats/go-multiaddr"
 )
 
 type startupInputs struct {
 	genesisConfig *genesis.GenesisConfig
+	spec          types.ChainSpec
 	bootnodes     []multiaddr.Multiaddr
 	keyManager    *xmss.KeyManager
 }
 
-func loadStartupInputs(cfg config) (*startupInputs, error) {
+func loadStartupInputs(cfg *config) (*startupInputs, error) {
 	paths := cfg.paths()
 
 	genesisConfig, err := genesis.LoadGenesisConfig(paths.config)
@@ -28,6 +32,14 @@ func loadStartupInputs(cfg config) (*startupInputs, error) {
 		return nil, err
 	}
 	logger.Info(logger.Node, "genesis: time=%d validators=%d", genesisConfig.GenesisTime, len(genesisConfig.GenesisValidators))
+	spec, err := resolveChainSpec(cfg, genesisConfig, os.Stderr)
+	if err != nil {
+		logger.Error(logger.Node, "%v", err)
+		return nil, err
+	}
+	// The state transition has no config of its own and reads the process
+	// default.
+	types.SetSpec(spec)
 
 	bootnodes, err := p2p.LoadBootnodes(paths.bootnodes)
 	if err != nil {
@@ -36,15 +48,21 @@ func loadStartupInputs(cfg config) (*startupInputs, error) {
 	}
 	logger.Info(logger.Node, "bootnodes: %d loaded", len(bootnodes))
 
-	keyManager, err := xmss.LoadValidatorKeys(paths.validators, paths.keysDir, cfg.NodeID)
+	keyManager, err := validatorkeys.Load(context.Background(), cfg.Config, paths.validators, paths.keysDir, cfg.NodeID, logger.Node)
 	if err != nil {
 		logger.Error(logger.Node, "load validator keys: %v", err)
 		return nil, err
 	}
 	logger.Info(logger.Node, "validators: %d keys loaded for %s", len(keyManager.ValidatorIDs()), cfg.NodeID)
+	if err := validatorkeys.CheckLifetimes(keyManager, spec, genesisConfig.GenesisTime, cfg.KeyExpiryWarnEpochs, logger.Node); err != nil {
+		logger.Error(logger.Node, "validator keys: %v", err)
+		keyManager.Close()
+		return nil, err
+	}
 
 	return &startupInputs{
 		genesisConfig: genesisConfig,
+		spec:          spec,
 		bootnodes:     bootnodes,
 		keyManager:    keyManager,
 	}, nil
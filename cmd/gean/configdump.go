@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// runConfig handles "gean config dump", which prints the configuration the
+// node would run with after merging the --config file, GEAN_* variables and
+// the given flags.
+func runConfig(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "dump" {
+		fmt.Fprintln(stderr, "usage: gean config dump [--config FILE] [node flags...]")
+		return errInvalidConfig
+	}
+	var cfg config
+	var raw flagStrings
+	fs := newConfigFlagSet(&cfg, &raw, stderr)
+	fs.Init("gean config dump", fs.ErrorHandling())
+	set, err := parseFlagSources(fs, args[1:], stderr)
+	if err != nil {
+		return err
+	}
+	return dumpFlags(stdout, fs, set)
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileFlag = "config"
+	envPrefix      = "GEAN_"
+)
+
+// envName is the environment variable for a flag: GEAN_ followed by the flag
+// name in upper case with dashes as underscores.
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// parseFlagSources parses args, then fills every flag not given on the
+// command line from its GEAN_* environment variable or, failing that, from
+// the --config file. It returns the names of the flags set by any source.
+func parseFlagSources(fs *flag.FlagSet, args []string, stderr io.Writer) (map[string]bool, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	if !set[configFileFlag] {
+		if path, ok := os.LookupEnv(envName(configFileFlag)); ok {
+			if err := setFlag(fs, configFileFlag, path, envName(configFileFlag), stderr); err != nil {
+				return nil, err
+			}
+			set[configFileFlag] = true
+		}
+	}
+	var fileValues map[string]string
+	if path := fs.Lookup(configFileFlag).Value.String(); path != "" {
+		var err error
+		if fileValues, err = loadConfigFile(path); err != nil {
+			fmt.Fprintf(stderr, "--config: %v\n", err)
+			return nil, errInvalidConfig
+		}
+		for _, name := range sortedKeys(fileValues) {
+			if name == configFileFlag || fs.Lookup(name) == nil {
+				fmt.Fprintf(stderr, "--config: unknown option %q in %s\n", name, path)
+				return nil, errInvalidConfig
+			}
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] || f.Name == configFileFlag {
+			return
+		}
+		if value, ok := os.LookupEnv(envName(f.Name)); ok {
+			err = setFlag(fs, f.Name, value, envName(f.Name), stderr)
+			set[f.Name] = true
+		} else if value, ok := fileValues[f.Name]; ok {
+			err = setFlag(fs, f.Name, value, "config file option "+f.Name, stderr)
+			set[f.Name] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func setFlag(fs *flag.FlagSet, name, value, source string, stderr io.Writer) error {
+	if err := fs.Set(name, value); err != nil {
+		fmt.Fprintf(stderr, "invalid value %q for %s: %v\n", value, source, err)
+		return errInvalidConfig
+	}
+	return nil
+}
+
+// loadConfigFile reads flag values keyed by flag name from a YAML file, or a
+// flat TOML file when the name ends in .toml. Underscores in keys stand for
+// dashes and lists are joined with commas, as the list flags expect.
+func loadConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		raw, err = parseFlatTOML(string(data))
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		name := strings.ReplaceAll(key, "_", "-")
+		if _, dup := values[name]; dup {
+			return nil, fmt.Errorf("%s: option %q given twice", path, name)
+		}
+		s, err := configValueString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: option %q: %w", path, key, err)
+		}
+		values[name] = s
+	}
+	return values, nil
+}
+
+func configValueString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			s, err := configValueString(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ","), nil
+	case map[string]any:
+		return "", fmt.Errorf("nested tables are not supported")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// parseFlatTOML parses the subset of TOML a flag file needs: key = value
+// lines with strings, numbers, booleans and arrays of those, and comments.
+func parseFlatTOML(data string) (map[string]any, error) {
+	out := make(map[string]any)
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			return nil, fmt.Errorf("line %d: tables are not supported", i+1)
+		}
+		key, rest, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: want key = value", i+1)
+		}
+		value, rest, err := parseTOMLValue(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if rest = strings.TrimSpace(rest); rest != "" && rest[0] != '#' {
+			return nil, fmt.Errorf("line %d: unexpected %q after value", i+1, rest)
+		}
+		if _, dup := out[key]; dup {
+			return nil, fmt.Errorf("line %d: key %q given twice", i+1, key)
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+func parseTOMLValue(s string) (any, string, error) {
+	switch {
+	case s == "":
+		return nil, "", fmt.Errorf("missing value")
+	case s[0] == '[':
+		var items []any
+		rest := strings.TrimSpace(s[1:])
+		for {
+			if rest == "" {
+				return nil, "", fmt.Errorf("unterminated array")
+			}
+			if rest[0] == ']' {
+				return items, rest[1:], nil
+			}
+			item, next, err := parseTOMLValue(rest)
+			if err != nil {
+				return nil, "", err
+			}
+			items = append(items, item)
+			rest = strings.TrimSpace(next)
+			if strings.HasPrefix(rest, ",") {
+				rest = strings.TrimSpace(rest[1:])
+			} else if !strings.HasPrefix(rest, "]") {
+				return nil, "", fmt.Errorf("want , or ] in array")
+			}
+		}
+	case s[0] == '"':
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				v, err := strconv.Unquote(s[:i+1])
+				return v, s[i+1:], err
+			}
+		}
+		return nil, "", fmt.Errorf("unterminated string")
+	case s[0] == '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], s[end+2:], nil
+	default:
+		end := strings.IndexAny(s, ",]# \t")
+		if end < 0 {
+			end = len(s)
+		}
+		return s[:end], s[end:], nil
+	}
+}
+
+// dumpFlags writes the effective value of every flag except --config as a
+// YAML document that --config accepts. Flags no source set are written as
+// comments, so loading the dump does not pin their defaults.
+func dumpFlags(w io.Writer, fs *flag.FlagSet, set map[string]bool) error {
+	var out bytes.Buffer
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == configFileFlag {
+			return
+		}
+		var value any = f.Value.String()
+		if getter, ok := f.Value.(flag.Getter); ok {
+			switch v := getter.Get().(type) {
+			case bool, int, uint64, float64:
+				value = v
+			}
+		}
+		var line []byte
+		if line, err = yaml.Marshal(map[string]any{f.Name: value}); err != nil {
+			return
+		}
+		if !set[f.Name] {
+			out.WriteString("# ")
+		}
+		out.Write(line)
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
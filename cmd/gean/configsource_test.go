@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestParseConfig_FileEnvAndFlagPrecedence(t *testing.T) {
+	path := writeConfigFile(t, "gean.yaml", `custom-network-config-dir: /config
+node_key: /config/node0.key
+node-id: node0
+api-port: 6000
+metrics-port: 6001
+is-aggregator: true
+attestation-committee-count: 4
+aggregate-subnet-ids: [1, 3]
+otlp-sample-ratio: 0.25
+`)
+	t.Setenv("GEAN_METRICS_PORT", "7001")
+	t.Setenv("GEAN_NODE_ID", "from-env")
+
+	var stderr bytes.Buffer
+	cfg, err := parseConfig([]string{"--config", path, "--node-id", "node7"}, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig: %v\n%s", err, stderr.String())
+	}
+	if cfg.ConfigDir != "/config" || cfg.NodeKey != "/config/node0.key" || cfg.APIPort != 6000 || !cfg.IsAggregator {
+		t.Fatalf("file values not applied: %+v", cfg)
+	}
+	if cfg.MetricsPort != 7001 || cfg.NodeID != "node7" {
+		t.Fatalf("metrics port %d node id %q, want env 7001 and flag node7", cfg.MetricsPort, cfg.NodeID)
+	}
+	if cfg.CommitteeCount != 4 || !cfg.committeeCountSet || !reflect.DeepEqual(cfg.AggregateSubnetIDs, []uint64{1, 3}) || cfg.OTLPSampleRatio != 0.25 {
+		t.Fatalf("committee settings from file: %+v", cfg)
+	}
+}
+
+func TestParseConfig_TOMLFileAndEnvConfigPath(t *testing.T) {
+	path := writeConfigFile(t, "gean.toml", `# node settings
+custom_network_config_dir = "/config"
+node-key = '/config/node0.key'
+node-id = "node0"   # trailing comment
+gossipsub-port = 9100
+monitor-validators = [2, 5]
+health-min-peers = 0
+`)
+	t.Setenv("GEAN_CONFIG", path)
+
+	var stderr bytes.Buffer
+	cfg, err := parseConfig(nil, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig: %v\n%s", err, stderr.String())
+	}
+	if cfg.ConfigDir != "/config" || cfg.GossipPort != 9100 || cfg.Health.MinPeers != 0 ||
+		!reflect.DeepEqual(cfg.MonitorValidators, []uint64{2, 5}) {
+		t.Fatalf("toml values not applied: %+v", cfg)
+	}
+}
+
+func TestParseConfig_RejectsBadConfigFiles(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown.yaml":  "node-id: a\nno-such-flag: 1\n",
+		"badvalue.yaml": "api-port: lots\n",
+		"nested.yaml":   "health:\n  min-peers: 1\n",
+		"self.yaml":     "config: other.yaml\n",
+		"table.toml":    "[node]\nnode-id = \"a\"\n",
+		"string.toml":   "node-id = \"a\n",
+		"dup.toml":      "node-id = \"a\"\nnode_id = \"b\"\n",
+	} {
+		var stderr bytes.Buffer
+		args := append(validFlagArgs(), "--config", writeConfigFile(t, name, content))
+		if _, err := parseConfig(args, &stderr); !errors.Is(err, errInvalidConfig) {
+			t.Fatalf("%s: err=%v, want errInvalidConfig", name, err)
+		}
+	}
+}
+
+func TestParseConfig_ExplicitPaths(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseConfig([]string{
+		"--node-key", "k", "--node-id", "n",
+		"--genesis-config-file", "/etc/gean/genesis.yaml",
+		"--bootnodes-file", "/etc/gean/bootnodes.yaml",
+		"--validators-file", "/etc/gean/validators.yaml",
+		"--keys-dir", "/var/lib/keys",
+	}, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig: %v\n%s", err, stderr.String())
+	}
+	want := configPaths{
+		config:     "/etc/gean/genesis.yaml",
+		bootnodes:  "/etc/gean/bootnodes.yaml",
+		validators: "/etc/gean/validators.yaml",
+		keysDir:    "/var/lib/keys",
+	}
+	if got := cfg.paths(); got != want {
+		t.Fatalf("paths=%+v, want %+v", got, want)
+	}
+
+	cfg, err = parseConfig(append(validFlagArgs(), "--bootnodes-file", "/tmp/peers.yaml"), &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig: %v", err)
+	}
+	if p := cfg.paths(); p.bootnodes != "/tmp/peers.yaml" || p.config != filepath.Join("/config", "config.yaml") {
+		t.Fatalf("paths=%+v", p)
+	}
+
+	if _, err := parseConfig([]string{"--node-key", "k", "--node-id", "n", "--keys-dir", "/k"}, &stderr); !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("partial paths without config dir: err=%v", err)
+	}
+}
+
+func TestRunConfigDumpRoundTrips(t *testing.T) {
+	args := append(validFlagArgs(), "--api-port", "6100", "--is-aggregator", "--aggregate-subnet-ids", "0", "--remote-signer-timeout", "3s")
+
+	var dumped, stderr bytes.Buffer
+	if err := runConfig(append([]string{"dump"}, args...), &dumped, &stderr); err != nil {
+		t.Fatalf("dump: %v\n%s", err, stderr.String())
+	}
+	want, err := parseConfig(args, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig: %v", err)
+	}
+
+	got, err := parseConfig([]string{"--config", writeConfigFile(t, "dump.yaml", dumped.String())}, &stderr)
+	if err != nil {
+		t.Fatalf("parse dumped config: %v\n%s", err, dumped.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("dumped config differs:\n got %+v\nwant %+v\n%s", got, want, dumped.String())
+	}
+	if !strings.Contains(dumped.String(), "api-port: 6100\n") || !strings.Contains(dumped.String(), "# attestation-committee-count: 1\n") {
+		t.Fatalf("dump output:\n%s", dumped.String())
+	}
+
+	if err := runConfig([]string{"show"}, &dumped, &stderr); !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("unknown config command: err=%v", err)
+	}
+}
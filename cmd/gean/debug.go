@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func runDebug(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "forkchoice" {
+		fmt.Fprintln(stderr, "usage: gean debug forkchoice [--api-url URL] [--format json|dot]")
+		return errInvalidConfig
+	}
+
+	fs := flag.NewFlagSet("gean debug forkchoice", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	apiURL := fs.String("api-url", "http://127.0.0.1:5052", "API server of the node to inspect")
+	format := fs.String("format", "json", "Output format: json or dot (Graphviz)")
+	timeout := fs.Duration("timeout", 5*time.Second, "Request timeout")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "dot" {
+		fmt.Fprintln(stderr, "--format must be json or dot")
+		return errInvalidConfig
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	url := strings.TrimRight(*apiURL, "/") + "/lean/v0/debug/fork_choice?format=" + *format
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch fork choice: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("fetch fork choice: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if _, err := io.Copy(stdout, resp.Body); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
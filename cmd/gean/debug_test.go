@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunDebugForkChoice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lean/v0/debug/fork_choice" || r.URL.Query().Get("format") != "dot" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("digraph forkchoice {}\n"))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	if err := runDebug([]string{"forkchoice", "--api-url", srv.URL + "/", "--format", "dot"}, &stdout, &stderr); err != nil {
+		t.Fatalf("runDebug: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if stdout.String() != "digraph forkchoice {}\n" {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+
+	if err := runDebug([]string{"forkchoice", "--api-url", srv.URL}, &stdout, &stderr); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("err=%v, want 404 from json request", err)
+	}
+	if err := runDebug([]string{"votes"}, &stdout, &stderr); err == nil {
+		t.Fatal("expected unknown debug command to fail")
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/geanlabs/gean/internal/api"
+	"github.com/geanlabs/gean/internal/validatorkeys"
 )
 
 var errInvalidConfig = errors.New("invalid gean configuration")
@@ -26,6 +30,22 @@ type config struct {
 	CommitteeCount     uint64
 	AggregateSubnetIDs []uint64
 	DataDir            string
+	validatorkeys.Config
+	DoppelgangerSlots uint64
+	MonitorValidators []uint64
+	Health            api.HealthConfig
+	OTLPEndpoint      string
+	OTLPSampleRatio   float64
+	ShutdownTimeout   time.Duration
+	APITokenFile      string
+
+	// Explicit file locations; each defaults to its fixed name under ConfigDir.
+	GenesisConfigFile string
+	BootnodesFile     string
+	ValidatorsFile    string
+	KeysDir           string
+
+	committeeCountSet bool
 }
 
 type configPaths struct {
@@ -35,13 +55,22 @@ type configPaths struct {
 	keysDir    string
 }
 
-func parseConfig(args []string, stderr io.Writer) (config, error) {
-	var cfg config
+// flagStrings holds flags that are parsed further after all sources apply.
+type flagStrings struct {
+	aggregateSubnetIDs string
+	monitorValidators  string
+}
+
+func newConfigFlagSet(cfg *config, raw *flagStrings, stderr io.Writer) *flag.FlagSet {
 	fs := flag.NewFlagSet("gean", flag.ContinueOnError)
 	fs.SetOutput(stderr)
 
-	aggregateSubnetIDs := ""
-	fs.StringVar(&cfg.ConfigDir, "custom-network-config-dir", "", "Config directory (required)")
+	fs.String(configFileFlag, "", "YAML or TOML file of flag values keyed by flag name; GEAN_* environment variables and command-line flags override it")
+	fs.StringVar(&cfg.ConfigDir, "custom-network-config-dir", "", "Config directory (required unless every file below is given)")
+	fs.StringVar(&cfg.GenesisConfigFile, "genesis-config-file", "", "Genesis config.yaml (default: config.yaml in the config directory)")
+	fs.StringVar(&cfg.BootnodesFile, "bootnodes-file", "", "Bootnodes list (default: nodes.yaml in the config directory)")
+	fs.StringVar(&cfg.ValidatorsFile, "validators-file", "", "Validator assignments (default: annotated_validators.yaml in the config directory)")
+	fs.StringVar(&cfg.KeysDir, "keys-dir", "", "Validator key directory (default: hash-sig-keys in the config directory)")
 	fs.IntVar(&cfg.GossipPort, "gossipsub-port", 9000, "P2P listen port (QUIC/UDP)")
 	fs.StringVar(&cfg.HTTPAddr, "http-address", "127.0.0.1", "Bind address for API + metrics")
 	fs.IntVar(&cfg.APIPort, "api-port", 5052, "API server port")
@@ -51,15 +80,33 @@ func parseConfig(args []string, stderr io.Writer) (config, error) {
 	fs.StringVar(&cfg.CheckpointURL, "checkpoint-sync-url", "", "URL for checkpoint sync (optional)")
 	fs.BoolVar(&cfg.IsAggregator, "is-aggregator", false, "Enable attestation aggregation")
 	fs.Uint64Var(&cfg.CommitteeCount, "attestation-committee-count", 1, "Number of attestation subnets")
-	fs.StringVar(&aggregateSubnetIDs, "aggregate-subnet-ids", "", "Comma-separated subnet IDs (requires --is-aggregator)")
+	fs.StringVar(&raw.aggregateSubnetIDs, "aggregate-subnet-ids", "", "Comma-separated subnet IDs (requires --is-aggregator)")
 	fs.StringVar(&cfg.DataDir, "data-dir", "./data", "Pebble database directory")
+	fs.StringVar(&raw.monitorValidators, "monitor-validators", "", "Comma-separated validator indices to monitor in addition to local keys")
+	fs.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", "", "Export traces to this OTLP/HTTP collector, e.g. http://127.0.0.1:4318 (optional)")
+	fs.Float64Var(&cfg.OTLPSampleRatio, "otlp-sample-ratio", 1, "Fraction of block and attestation traces to export, from 0 to 1")
+	fs.Uint64Var(&cfg.DoppelgangerSlots, "doppelganger-detection-slots", 0, "Watch gossip this many slots for our validators signing elsewhere before enabling duties (0 disables)")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", 10*time.Second, "How long shutdown waits for in-flight work and HTTP requests")
+	fs.StringVar(&cfg.APITokenFile, "api-token-file", "", "Bearer token required by the key management API; generated if missing (default: api-token.txt in the data directory)")
+
+	validatorkeys.RegisterFlags(fs, &cfg.Config)
+	registerHealthFlags(fs, cfg)
+	return fs
+}
 
-	if err := fs.Parse(args); err != nil {
+func parseConfig(args []string, stderr io.Writer) (config, error) {
+	var cfg config
+	var raw flagStrings
+	fs := newConfigFlagSet(&cfg, &raw, stderr)
+	set, err := parseFlagSources(fs, args, stderr)
+	if err != nil {
 		return cfg, err
 	}
+	cfg.committeeCountSet = set["attestation-committee-count"]
 
-	if cfg.ConfigDir == "" || cfg.NodeKey == "" || cfg.NodeID == "" {
+	if (cfg.ConfigDir == "" && !cfg.hasAllPaths()) || cfg.NodeKey == "" || cfg.NodeID == "" {
 		fmt.Fprintln(stderr, "required flags: --custom-network-config-dir, --node-key, --node-id")
+		fmt.Fprintln(stderr, "(--custom-network-config-dir may be replaced by --genesis-config-file, --bootnodes-file, --validators-file and --keys-dir)")
 		fs.Usage()
 		return cfg, errInvalidConfig
 	}
@@ -76,12 +123,32 @@ func parseConfig(args []string, stderr io.Writer) (config, error) {
 		fmt.Fprintln(stderr, "--attestation-committee-count must be >= 1")
 		return cfg, errInvalidConfig
 	}
-	if !cfg.IsAggregator && aggregateSubnetIDs != "" {
+	if err := cfg.Config.Validate(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return cfg, errInvalidConfig
+	}
+	if cfg.OTLPEndpoint != "" && !strings.HasPrefix(cfg.OTLPEndpoint, "http://") && !strings.HasPrefix(cfg.OTLPEndpoint, "https://") {
+		fmt.Fprintln(stderr, "--otlp-endpoint must be an http:// or https:// URL")
+		return cfg, errInvalidConfig
+	}
+	if cfg.OTLPSampleRatio < 0 || cfg.OTLPSampleRatio > 1 {
+		fmt.Fprintln(stderr, "--otlp-sample-ratio must be between 0 and 1")
+		return cfg, errInvalidConfig
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		fmt.Fprintln(stderr, "--shutdown-timeout must be positive")
+		return cfg, errInvalidConfig
+	}
+	if cfg.Health.SyncingStatus < 100 || cfg.Health.SyncingStatus > 599 {
+		fmt.Fprintln(stderr, "--health-syncing-status must be an HTTP status code")
+		return cfg, errInvalidConfig
+	}
+	if !cfg.IsAggregator && raw.aggregateSubnetIDs != "" {
 		fmt.Fprintln(stderr, "--aggregate-subnet-ids requires --is-aggregator")
 		return cfg, errInvalidConfig
 	}
 
-	subnetIDs, err := parseAggregateSubnetIDs(aggregateSubnetIDs, stderr)
+	subnetIDs, err := parseUintList("aggregate-subnet-id", raw.aggregateSubnetIDs, stderr)
 	if err != nil {
 		return cfg, err
 	}
@@ -89,6 +156,10 @@ func parseConfig(args []string, stderr io.Writer) (config, error) {
 		return cfg, err
 	}
 	cfg.AggregateSubnetIDs = subnetIDs
+
+	if cfg.MonitorValidators, err = parseUintList("monitor-validators index", raw.monitorValidators, stderr); err != nil {
+		return cfg, err
+	}
 	return cfg, nil
 }
 
@@ -100,7 +171,7 @@ func validatePort(name string, port int, stderr io.Writer) error {
 	return nil
 }
 
-func parseAggregateSubnetIDs(raw string, stderr io.Writer) ([]uint64, error) {
+func parseUintList(name, raw string, stderr io.Writer) ([]uint64, error) {
 	if raw == "" {
 		return nil, nil
 	}
@@ -112,7 +183,7 @@ func parseAggregateSubnetIDs(raw string, stderr io.Writer) ([]uint64, error) {
 		}
 		id, err := strconv.ParseUint(part, 10, 64)
 		if err != nil {
-			fmt.Fprintf(stderr, "invalid aggregate-subnet-id %q: %v\n", part, err)
+			fmt.Fprintf(stderr, "invalid %s %q: %v\n", name, part, err)
 			return nil, errInvalidConfig
 		}
 		ids = append(ids, id)
@@ -131,13 +202,38 @@ func validateAggregateSubnetIDs(ids []uint64, committeeCount uint64, stderr io.W
 	return nil
 }
 
+func registerHealthFlags(fs *flag.FlagSet, cfg *config) {
+	def := api.DefaultHealthConfig()
+	fs.IntVar(&cfg.Health.MinPeers, "health-min-peers", def.MinPeers, "Report /lean/v0/node/health as not ready below this many peers")
+	fs.Uint64Var(&cfg.Health.MaxSyncDistance, "health-max-sync-distance", def.MaxSyncDistance, "Report /lean/v0/node/health as syncing when the head trails the wall clock by more slots")
+	fs.IntVar(&cfg.Health.SyncingStatus, "health-syncing-status", def.SyncingStatus, "HTTP status returned by /lean/v0/node/health while syncing")
+}
+
 func (c config) paths() configPaths {
 	return configPaths{
-		config:     filepath.Join(c.ConfigDir, "config.yaml"),
-		bootnodes:  filepath.Join(c.ConfigDir, "nodes.yaml"),
-		validators: filepath.Join(c.ConfigDir, "annotated_validators.yaml"),
-		keysDir:    filepath.Join(c.ConfigDir, "hash-sig-keys"),
+		config:     c.pathOr(c.GenesisConfigFile, "config.yaml"),
+		bootnodes:  c.pathOr(c.BootnodesFile, "nodes.yaml"),
+		validators: c.pathOr(c.ValidatorsFile, "annotated_validators.yaml"),
+		keysDir:    c.pathOr(c.KeysDir, "hash-sig-keys"),
+	}
+}
+
+func (c config) pathOr(override, name string) string {
+	if override != "" {
+		return override
+	}
+	return filepath.Join(c.ConfigDir, name)
+}
+
+func (c config) hasAllPaths() bool {
+	return c.GenesisConfigFile != "" && c.BootnodesFile != "" && c.ValidatorsFile != "" && c.KeysDir != ""
+}
+
+func (c config) apiTokenFile() string {
+	if c.APITokenFile != "" {
+		return c.APITokenFile
 	}
+	return filepath.Join(c.DataDir, "api-token.txt")
 }
 
 func (c config) apiAddress() string {
@@ -5,6 +5,9 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/api"
 )
 
 func validFlagArgs() []string {
@@ -95,6 +98,62 @@ func TestParseConfig_AggregateSubnetIDs(t *testing.T) {
 	}
 }
 
+func TestParseConfig_MonitorValidators(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseConfig(append(validFlagArgs(), "--monitor-validators", "4,9"), &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if !reflect.DeepEqual(cfg.MonitorValidators, []uint64{4, 9}) {
+		t.Fatalf("monitor validators=%v, want [4 9]", cfg.MonitorValidators)
+	}
+
+	stderr.Reset()
+	if _, err := parseConfig(append(validFlagArgs(), "--monitor-validators", "4,x"), &stderr); err == nil {
+		t.Fatal("expected error for invalid index")
+	}
+}
+
+func TestParseConfig_Health(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseConfig(validFlagArgs(), &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if cfg.Health != api.DefaultHealthConfig() {
+		t.Fatalf("health=%+v, want defaults", cfg.Health)
+	}
+
+	args := append(validFlagArgs(), "--health-min-peers", "3", "--health-max-sync-distance", "8", "--health-syncing-status", "503")
+	cfg, err = parseConfig(args, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if cfg.Health != (api.HealthConfig{MinPeers: 3, MaxSyncDistance: 8, SyncingStatus: 503}) {
+		t.Fatalf("health=%+v", cfg.Health)
+	}
+
+	if _, err := parseConfig(append(validFlagArgs(), "--health-syncing-status", "42"), &stderr); err == nil {
+		t.Fatal("expected invalid syncing status to fail")
+	}
+}
+
+func TestParseConfig_OTLPSampleRatio(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseConfig(validFlagArgs(), &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if cfg.OTLPSampleRatio != 1 {
+		t.Fatalf("sample ratio=%v, want 1", cfg.OTLPSampleRatio)
+	}
+	for _, ratio := range []string{"-0.1", "1.5"} {
+		if _, err := parseConfig(append(validFlagArgs(), "--otlp-sample-ratio", ratio), &stderr); err == nil {
+			t.Fatalf("expected sample ratio %s to fail", ratio)
+		}
+	}
+}
+
 func TestParseConfig_InvalidPorts(t *testing.T) {
 	tests := []struct {
 		name string
@@ -132,6 +191,26 @@ func TestParseConfig_AggregateSubnetIDsMustBeInRange(t *testing.T) {
 	}
 }
 
+func TestParseConfig_RemoteSigner(t *testing.T) {
+	args := append(validFlagArgs(),
+		"--remote-signer-url", "https://signer:9100",
+		"--remote-signer-ca", "/config/ca.pem",
+		"--remote-signer-timeout", "500ms")
+	var stderr bytes.Buffer
+	cfg, err := parseConfig(args, &stderr)
+	if err != nil {
+		t.Fatalf("parseConfig returned error: %v\nstderr:\n%s", err, stderr.String())
+	}
+	if cfg.RemoteSigner.URL != "https://signer:9100" || cfg.RemoteSigner.CAFile != "/config/ca.pem" || cfg.RemoteSigner.Timeout != 500*time.Millisecond {
+		t.Fatalf("unexpected remote signer config: %+v", cfg.RemoteSigner)
+	}
+
+	args = append(validFlagArgs(), "--remote-signer-cert", "/config/client.pem")
+	if _, err := parseConfig(args, &stderr); err == nil {
+		t.Fatal("expected TLS files without --remote-signer-url to fail")
+	}
+}
+
 func TestConfigAddressesUseJoinHostPort(t *testing.T) {
 	cfg := config{HTTPAddr: "::1", APIPort: 5052, MetricsPort: 5054}
 	if got := cfg.apiAddress(); got != "[::1]:5052" {
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/geanlabs/gean/internal/genesis"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+const genesisUsage = `usage:
+  gean genesis build   --config config.yaml [--output-dir DIR]
+  gean genesis inspect (--config config.yaml | --state genesis.ssz)
+  gean genesis verify  --config config.yaml --state genesis.ssz`
+
+// maxReportedDifferences caps the field differences verify prints; a wrong
+// validator set otherwise lists every validator.
+const maxReportedDifferences = 10
+
+func runGenesis(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, genesisUsage)
+		return errInvalidConfig
+	}
+	cmd := args[0]
+	if cmd != "build" && cmd != "inspect" && cmd != "verify" {
+		fmt.Fprintln(stderr, genesisUsage)
+		return errInvalidConfig
+	}
+
+	fs := flag.NewFlagSet("gean genesis "+cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "Network config.yaml to build the genesis state from")
+	statePath := fs.String("state", "", "SSZ-encoded genesis state to inspect or verify")
+	outputDir := fs.String("output-dir", ".", "Directory for genesis.ssz and genesis.json (build)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch {
+	case cmd == "build" && *configPath == "",
+		cmd == "inspect" && (*configPath == "") == (*statePath == ""),
+		cmd == "verify" && (*configPath == "" || *statePath == ""):
+		fmt.Fprintln(stderr, genesisUsage)
+		return errInvalidConfig
+	}
+
+	switch cmd {
+	case "build":
+		state, err := buildGenesisState(*configPath)
+		if err != nil {
+			return err
+		}
+		if err := writeGenesisFiles(*outputDir, state); err != nil {
+			return err
+		}
+		return printGenesisSummary(stdout, state)
+	case "inspect":
+		var state *types.State
+		var err error
+		if *configPath != "" {
+			state, err = buildGenesisState(*configPath)
+		} else {
+			state, err = readGenesisState(*statePath)
+		}
+		if err != nil {
+			return err
+		}
+		return printGenesisSummary(stdout, state)
+	default:
+		want, err := buildGenesisState(*configPath)
+		if err != nil {
+			return err
+		}
+		got, err := readGenesisState(*statePath)
+		if err != nil {
+			return err
+		}
+		return verifyGenesisState(stdout, want, got)
+	}
+}
+
+func buildGenesisState(configPath string) (*types.State, error) {
+	gc, err := genesis.LoadGenesisConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return gc.GenesisState()
+}
+
+func readGenesisState(path string) (*types.State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read genesis state: %w", err)
+	}
+	state := &types.State{}
+	if err := state.UnmarshalSSZ(data); err != nil {
+		return nil, fmt.Errorf("decode genesis state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func writeGenesisFiles(dir string, state *types.State) error {
+	sszBytes, err := state.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("encode genesis state: %w", err)
+	}
+	jsonBytes, err := json.MarshalIndent(genesisStateToJSON(state), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode genesis json: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "genesis.ssz"), sszBytes, 0o644); err != nil {
+		return fmt.Errorf("write genesis.ssz: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "genesis.json"), append(jsonBytes, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write genesis.json: %w", err)
+	}
+	return nil
+}
+
+func printGenesisSummary(w io.Writer, state *types.State) error {
+	stateRoot, blockRoot, err := genesis.Roots(state)
+	if err != nil {
+		return err
+	}
+	var genesisTime uint64
+	if state.Config != nil {
+		genesisTime = state.Config.GenesisTime
+	}
+	fmt.Fprintf(w, "genesis_time: %d\nvalidators: %d\nstate_root: 0x%x\nblock_root: 0x%x\n",
+		genesisTime, len(state.Validators), stateRoot, blockRoot)
+	return nil
+}
+
+// verifyGenesisState succeeds when got has the state root of the state built
+// from the config, and otherwise lists the fields that differ.
+func verifyGenesisState(w io.Writer, want, got *types.State) error {
+	wantRoot, _, err := genesis.Roots(want)
+	if err != nil {
+		return err
+	}
+	gotRoot, _, err := genesis.Roots(got)
+	if err != nil {
+		return err
+	}
+	if err := printGenesisSummary(w, got); err != nil {
+		return err
+	}
+	if gotRoot == wantRoot {
+		fmt.Fprintln(w, "genesis state matches config")
+		return nil
+	}
+
+	diffs := genesisDifferences(want, got)
+	fmt.Fprintf(w, "genesis state does not match config (expected state_root 0x%x)\n", wantRoot)
+	for i, d := range diffs {
+		if i == maxReportedDifferences {
+			fmt.Fprintf(w, "  ... %d more\n", len(diffs)-i)
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", d)
+	}
+	return fmt.Errorf("genesis state root 0x%x, want 0x%x", gotRoot, wantRoot)
+}
+
+func genesisDifferences(want, got *types.State) []string {
+	wantJSON, gotJSON := genesisStateToJSON(want), genesisStateToJSON(got)
+	var diffs []string
+	differ := func(field string, w, g any) {
+		if w != g {
+			diffs = append(diffs, fmt.Sprintf("%s: got %v, want %v", field, g, w))
+		}
+	}
+	differ("config.genesis_time", wantJSON.Config.GenesisTime, gotJSON.Config.GenesisTime)
+	differ("slot", wantJSON.Slot, gotJSON.Slot)
+	differ("latest_block_header", wantJSON.LatestBlockHeader, gotJSON.LatestBlockHeader)
+	differ("latest_justified", wantJSON.LatestJustified, gotJSON.LatestJustified)
+	differ("latest_finalized", wantJSON.LatestFinalized, gotJSON.LatestFinalized)
+	differ("len(historical_block_hashes)", len(wantJSON.HistoricalBlockHashes), len(gotJSON.HistoricalBlockHashes))
+	differ("justified_slots", wantJSON.JustifiedSlots, gotJSON.JustifiedSlots)
+	differ("len(justifications_roots)", len(wantJSON.JustificationsRoots), len(gotJSON.JustificationsRoots))
+	differ("justifications_validators", wantJSON.JustificationsValidators, gotJSON.JustificationsValidators)
+	differ("len(validators)", len(wantJSON.Validators), len(gotJSON.Validators))
+	for i := range min(len(wantJSON.Validators), len(gotJSON.Validators)) {
+		differ(fmt.Sprintf("validators[%d]", i), wantJSON.Validators[i], gotJSON.Validators[i])
+	}
+	return diffs
+}
+
+type genesisStateJSON struct {
+	Config                   genesisConfigJSON      `json:"config"`
+	Slot                     uint64                 `json:"slot"`
+	LatestBlockHeader        genesisHeaderJSON      `json:"latest_block_header"`
+	LatestJustified          genesisCheckpointJSON  `json:"latest_justified"`
+	LatestFinalized          genesisCheckpointJSON  `json:"latest_finalized"`
+	HistoricalBlockHashes    []string               `json:"historical_block_hashes"`
+	JustifiedSlots           string                 `json:"justified_slots"`
+	Validators               []genesisValidatorJSON `json:"validators"`
+	JustificationsRoots      []string               `json:"justifications_roots"`
+	JustificationsValidators string                 `json:"justifications_validators"`
+}
+
+type genesisConfigJSON struct {
+	GenesisTime uint64 `json:"genesis_time"`
+}
+
+type genesisHeaderJSON struct {
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type genesisCheckpointJSON struct {
+	Root string `json:"root"`
+	Slot uint64 `json:"slot"`
+}
+
+type genesisValidatorJSON struct {
+	AttestationPubkey string `json:"attestation_pubkey"`
+	ProposalPubkey    string `json:"proposal_pubkey"`
+	Index             uint64 `json:"index"`
+}
+
+// genesisStateToJSON renders byte fields as 0x-prefixed hex and bitlists in
+// their SSZ encoding, so the output diffs cleanly against other clients.
+func genesisStateToJSON(state *types.State) genesisStateJSON {
+	out := genesisStateJSON{
+		Slot:                     state.Slot,
+		HistoricalBlockHashes:    hexList(state.HistoricalBlockHashes),
+		JustifiedSlots:           fmt.Sprintf("0x%x", state.JustifiedSlots),
+		JustificationsRoots:      hexList(state.JustificationsRoots),
+		JustificationsValidators: fmt.Sprintf("0x%x", state.JustificationsValidators),
+		Validators:               make([]genesisValidatorJSON, 0, len(state.Validators)),
+	}
+	if state.Config != nil {
+		out.Config.GenesisTime = state.Config.GenesisTime
+	}
+	if h := state.LatestBlockHeader; h != nil {
+		out.LatestBlockHeader = genesisHeaderJSON{
+			Slot:          h.Slot,
+			ProposerIndex: h.ProposerIndex,
+			ParentRoot:    fmt.Sprintf("0x%x", h.ParentRoot),
+			StateRoot:     fmt.Sprintf("0x%x", h.StateRoot),
+			BodyRoot:      fmt.Sprintf("0x%x", h.BodyRoot),
+		}
+	}
+	out.LatestJustified = checkpointHex(state.LatestJustified)
+	out.LatestFinalized = checkpointHex(state.LatestFinalized)
+	for _, v := range state.Validators {
+		out.Validators = append(out.Validators, genesisValidatorJSON{
+			AttestationPubkey: fmt.Sprintf("0x%x", v.AttestationPubkey),
+			ProposalPubkey:    fmt.Sprintf("0x%x", v.ProposalPubkey),
+			Index:             v.Index,
+		})
+	}
+	return out
+}
+
+func checkpointHex(cp *types.Checkpoint) genesisCheckpointJSON {
+	if cp == nil {
+		return genesisCheckpointJSON{Root: fmt.Sprintf("0x%x", types.ZeroRoot)}
+	}
+	return genesisCheckpointJSON{Root: fmt.Sprintf("0x%x", cp.Root), Slot: cp.Slot}
+}
+
+func hexList(items [][]byte) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = fmt.Sprintf("0x%x", item)
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeGenesisTestConfig(t *testing.T, genesisTime uint64, validators int) string {
+	t.Helper()
+	var b strings.Builder
+	fmt.Fprintf(&b, "GENESIS_TIME: %d\nGENESIS_VALIDATORS:\n", genesisTime)
+	for i := range validators {
+		fmt.Fprintf(&b, "  - attestation_pubkey: \"%s\"\n    proposal_pubkey: \"%s\"\n",
+			strings.Repeat(fmt.Sprintf("%02x", 2*i+1), 52), strings.Repeat(fmt.Sprintf("%02x", 2*i+2), 52))
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestRunGenesisBuildInspectVerify(t *testing.T) {
+	configPath := writeGenesisTestConfig(t, 1770407233, 3)
+	outDir := t.TempDir()
+
+	var built, stderr bytes.Buffer
+	if err := runGenesis([]string{"build", "--config", configPath, "--output-dir", outDir}, &built, &stderr); err != nil {
+		t.Fatalf("build: %v\n%s", err, stderr.String())
+	}
+	if !strings.Contains(built.String(), "validators: 3\n") || !strings.Contains(built.String(), "block_root: 0x") {
+		t.Fatalf("build output:\n%s", built.String())
+	}
+
+	var decoded genesisStateJSON
+	data, err := os.ReadFile(filepath.Join(outDir, "genesis.json"))
+	if err != nil {
+		t.Fatalf("read genesis.json: %v", err)
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode genesis.json: %v", err)
+	}
+	if decoded.Config.GenesisTime != 1770407233 || len(decoded.Validators) != 3 ||
+		decoded.Validators[2].ProposalPubkey != "0x"+strings.Repeat("06", 52) {
+		t.Fatalf("genesis.json=%+v", decoded)
+	}
+
+	statePath := filepath.Join(outDir, "genesis.ssz")
+	var inspected bytes.Buffer
+	if err := runGenesis([]string{"inspect", "--state", statePath}, &inspected, &stderr); err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	if inspected.String() != built.String() {
+		t.Fatalf("inspect of genesis.ssz differs from build:\n%s\n%s", inspected.String(), built.String())
+	}
+
+	var verified bytes.Buffer
+	if err := runGenesis([]string{"verify", "--config", configPath, "--state", statePath}, &verified, &stderr); err != nil {
+		t.Fatalf("verify: %v\n%s", err, verified.String())
+	}
+
+	var mismatch bytes.Buffer
+	other := writeGenesisTestConfig(t, 1770407234, 2)
+	err = runGenesis([]string{"verify", "--config", other, "--state", statePath}, &mismatch, &stderr)
+	if err == nil {
+		t.Fatal("verify against a different config succeeded")
+	}
+	for _, want := range []string{"config.genesis_time: got 1770407233, want 1770407234", "len(validators): got 3, want 2"} {
+		if !strings.Contains(mismatch.String(), want) {
+			t.Fatalf("mismatch report missing %q:\n%s", want, mismatch.String())
+		}
+	}
+}
+
+func TestRunGenesisRejectsUsage(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"export"},
+		{"build"},
+		{"inspect"},
+		{"inspect", "--config", "a", "--state", "b"},
+		{"verify", "--config", "a"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if err := runGenesis(args, &stdout, &stderr); !errors.Is(err, errInvalidConfig) {
+			t.Fatalf("args=%v err=%v, want errInvalidConfig", args, err)
+		}
+	}
+}
@@ -4,16 +4,29 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"io"
+	"net/http"
 	"os"
 
+	"github.com/geanlabs/gean/internal/api"
+	"github.com/geanlabs/gean/internal/clock"
 	"github.com/geanlabs/gean/internal/forkchoice"
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/metrics"
 	"github.com/geanlabs/gean/internal/node"
 	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/tracing"
+	"github.com/geanlabs/gean/xmss"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if sub, ok := subcommands[os.Args[1]]; ok {
+			runSubcommand(os.Args[1], sub, os.Args[2:])
+			return
+		}
+	}
+
 	cfg, err := parseConfig(os.Args[1:], os.Stderr)
 	if err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -27,10 +40,31 @@ func main() {
 	}
 }
 
+var subcommands = map[string]func(args []string, stdout, stderr io.Writer) error{
+	"debug":   runDebug,
+	"genesis": runGenesis,
+	"config":  runConfig,
+}
+
+func runSubcommand(name string, sub func(args []string, stdout, stderr io.Writer) error, args []string) {
+	if err := sub(args, os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		if !errors.Is(err, errInvalidConfig) {
+			logger.Error(logger.Node, "%s: %v", name, err)
+		}
+		os.Exit(1)
+	}
+}
+
 func run(cfg config) error {
 	logger.Info(logger.Node, "gean consensus client starting")
+	if xmss.Insecure {
+		logger.Warn(logger.Node, "built with xmss_insecure: signatures are forgeable, use for local testing only")
+	}
 
-	inputs, err := loadStartupInputs(cfg)
+	inputs, err := loadStartupInputs(&cfg)
 	if err != nil {
 		return err
 	}
@@ -42,14 +76,21 @@ func run(cfg config) error {
 		return err
 	}
 	defer backend.Close()
+	s.UseSpec(inputs.spec)
+
+	apiToken, err := loadAPIToken(cfg.apiTokenFile())
+	if err != nil {
+		return err
+	}
 
 	if err := bootstrapStore(s, inputs.genesisConfig, cfg.CheckpointURL); err != nil {
 		return err
 	}
 
-	if err := recoverStoreTime(s, inputs.genesisConfig.GenesisTime); err != nil {
+	if err := recoverStoreTime(s, inputs.genesisConfig.GenesisTime, clock.System); err != nil {
 		return err
 	}
+	restoreVotes(s)
 
 	headSlot, headRoot, parentRoot, err := forkChoiceAnchor(s)
 	if err != nil {
@@ -71,13 +112,47 @@ func run(cfg config) error {
 
 	aggCtl := role.NewWithHook(cfg.IsAggregator, metrics.SetIsAggregator)
 	n := node.New(s, fc, p2pHost, inputs.keyManager, aggCtl, cfg.CommitteeCount)
+	n.KeyExpiryWarnEpochs = cfg.KeyExpiryWarnEpochs
+	n.MaxSyncDistance = cfg.Health.MaxSyncDistance
+	n.EnableDoppelgangerDetection(cfg.DoppelgangerSlots)
+	n.Monitor.Track(cfg.MonitorValidators...)
+	if cfg.OTLPEndpoint != "" {
+		exporter := tracing.NewExporter(cfg.OTLPEndpoint,
+			tracing.String("service.name", "gean"),
+			tracing.String("service.instance.id", cfg.NodeID))
+		go exporter.Run(ctx)
+		tracing.Enable(exporter, cfg.OTLPSampleRatio)
+		logger.Info(logger.Node, "exporting traces to %s sample_ratio=%g", cfg.OTLPEndpoint, cfg.OTLPSampleRatio)
+	}
 
 	registerReqRespHandlers(p2pHost, s)
-	startNodeNetworking(ctx, n, s, p2pHost, inputs.bootnodes)
-
-	apiAddr, metricsAddr := startHTTPServers(cfg, s, fc, aggCtl)
-	logger.Info(logger.Node, "gean started: api=%s metrics=%s aggregator=%v", apiAddr, metricsAddr, cfg.IsAggregator)
-
-	waitForShutdown(cancel)
+	nodeStopped := startNodeNetworking(ctx, n, s, p2pHost, inputs.bootnodes)
+
+	apiServer, metricsServer := startHTTPServers(cfg, api.Services{
+		Store:          s,
+		ForkChoice:     fc,
+		Debug:          n,
+		BlockTrace:     n.Trace,
+		Aggregator:     aggCtl,
+		Validator:      n,
+		Keys:           inputs.keyManager,
+		KeyManager:     n,
+		AdminToken:     apiToken,
+		Performance:    n.Monitor,
+		Sync:           n,
+		Peers:          p2pHost,
+		Health:         cfg.Health,
+		CommitteeCount: cfg.CommitteeCount,
+	})
+	logger.Info(logger.Node, "gean started: api=%s metrics=%s aggregator=%v", apiServer.Addr, metricsServer.Addr, cfg.IsAggregator)
+
+	waitForShutdown(shutdownSteps{
+		p2p:         p2pHost,
+		cancel:      cancel,
+		nodeStopped: nodeStopped,
+		store:       s,
+		servers:     []*http.Server{apiServer, metricsServer},
+		backend:     backend,
+	}, cfg.ShutdownTimeout)
 	return nil
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/geanlabs/gean/internal/logger"
@@ -61,17 +62,27 @@ func registerReqRespHandlers(p2pHost *p2p.Host, s *store.ConsensusStore) {
 	)
 }
 
-func startNodeNetworking(ctx context.Context, n *node.Engine, s *store.ConsensusStore, p2pHost *p2p.Host, bootnodes []multiaddr.Multiaddr) {
+// startNodeNetworking starts the engine and sync driver. The returned channel
+// is closed once both have returned after ctx is cancelled.
+func startNodeNetworking(ctx context.Context, n *node.Engine, s *store.ConsensusStore, p2pHost *p2p.Host, bootnodes []multiaddr.Multiaddr) <-chan struct{} {
 	p2pHost.StartGossipListeners(n)
-	go n.Run(ctx)
+	var wg sync.WaitGroup
+	wg.Go(func() { n.Run(ctx) })
 
 	syncDriver := syncer.NewSyncDriver(ctx, n, s, p2pHost)
 	p2pHost.Hooks.PeerStatus = syncDriver.OnPeerConnected
-	go syncDriver.Run()
+	wg.Go(syncDriver.Run)
 
 	p2pHost.ConnectBootnodes(ctx, bootnodes)
 	p2pHost.StartBootnodeRedial(ctx, bootnodes)
 	scheduleSubscriptionReannounce(ctx, p2pHost)
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+	return stopped
 }
 
 func scheduleSubscriptionReannounce(ctx context.Context, p2pHost *p2p.Host) {
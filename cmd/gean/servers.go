@@ -1,28 +1,77 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/geanlabs/gean/internal/api"
-	"github.com/geanlabs/gean/internal/forkchoice"
 	"github.com/geanlabs/gean/internal/logger"
-	"github.com/geanlabs/gean/internal/role"
-	"github.com/geanlabs/gean/internal/store"
 )
 
-func startHTTPServers(cfg config, s *store.ConsensusStore, fc *forkchoice.ForkChoice, aggCtl *role.Controller) (string, string) {
-	apiAddr := cfg.apiAddress()
-	metricsAddr := cfg.metricsAddress()
+func startHTTPServers(cfg config, svc api.Services) (apiServer, metricsServer *http.Server) {
+	apiServer = newAPIServer(cfg.apiAddress(), svc)
+	metricsServer = api.NewMetricsServer(cfg.metricsAddress())
 
 	go func() {
-		if err := startAPIServer(apiAddr, s, fc, aggCtl); err != nil {
+		if err := api.Serve(apiServer, "api"); err != nil {
 			logger.Error(logger.Node, "api server error: %v", err)
 		}
 	}()
 
 	go func() {
-		if err := api.StartMetricsServer(metricsAddr); err != nil {
+		if err := api.Serve(metricsServer, "metrics"); err != nil {
 			logger.Error(logger.Node, "metrics server error: %v", err)
 		}
 	}()
 
-	return apiAddr, metricsAddr
+	return apiServer, metricsServer
+}
+
+// shutdownHTTPServers stops accepting connections and waits for in-flight
+// requests until ctx is done, then closes what is left.
+func shutdownHTTPServers(ctx context.Context, servers ...*http.Server) {
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Warn(logger.Node, "http server %s shutdown: %v", srv.Addr, err)
+			srv.Close()
+		}
+	}
+}
+
+// loadAPIToken reads the key management API token from path, generating and
+// writing a random one on first start.
+func loadAPIToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("api token file %s is empty", path)
+		}
+		return token, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("read api token: %w", err)
+	}
+
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate api token: %w", err)
+	}
+	token := hex.EncodeToString(b[:])
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("create api token dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("write api token: %w", err)
+	}
+	logger.Info(logger.Node, "generated key management api token at %s", path)
+	return token, nil
 }
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAPITokenGeneratesOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "api-token.txt")
+	token, err := loadAPIToken(path)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(token) != 64 {
+		t.Fatalf("token %q, want 32 hex-encoded bytes", token)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("token file mode %o, want 600", perm)
+	}
+	again, err := loadAPIToken(path)
+	if err != nil || again != token {
+		t.Fatalf("reload=%q err=%v, want %q", again, err, token)
+	}
+
+	if err := os.WriteFile(path, []byte("  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadAPIToken(path); err == nil {
+		t.Fatal("empty token file should be rejected")
+	}
+}
@@ -2,21 +2,87 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/p2p"
+	"github.com/geanlabs/gean/internal/store"
 )
 
-func waitForShutdown(cancel context.CancelFunc) {
+// goodbyeTimeout bounds how long shutdown waits for peers to take goodbye.
+const goodbyeTimeout = 2 * time.Second
+
+// flusher is the storage backend's flush, so pending writes reach disk
+// before the deferred Close.
+type flusher interface {
+	Flush() error
+}
+
+// shutdownSteps are the parts of a running node that shutdown stops, in
+// order. The p2p host and the database are closed by run's defers.
+type shutdownSteps struct {
+	p2p         *p2p.Host
+	cancel      context.CancelFunc
+	nodeStopped <-chan struct{}
+	store       *store.ConsensusStore
+	servers     []*http.Server
+	backend     flusher
+}
+
+func waitForShutdown(steps shutdownSteps, timeout time.Duration) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 	signal.Stop(sigCh)
 
 	logger.Info(logger.Node, "shutting down...")
-	cancel()
-	time.Sleep(500 * time.Millisecond)
+	steps.run(timeout)
+}
+
+// run stops gossip, says goodbye to peers, drains the HTTP servers, waits
+// for in-flight block imports and aggregation, saves the fork choice votes
+// and flushes storage. Waiting steps share timeout.
+//
+// The servers drain while the node still runs, since validator API handlers
+// wait on the dispatch loop. Votes are only saved once the node has stopped
+// changing them.
+func (s shutdownSteps) run(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if s.p2p != nil {
+		s.p2p.StopGossip()
+		goodbyeCtx, goodbyeCancel := context.WithTimeout(ctx, goodbyeTimeout)
+		s.p2p.SayGoodbye(goodbyeCtx, p2p.GoodbyeClientShutdown)
+		goodbyeCancel()
+	}
+
+	shutdownHTTPServers(ctx, s.servers...)
+
+	s.cancel()
+	select {
+	case <-s.nodeStopped:
+		saveVotes(s.store)
+	case <-ctx.Done():
+		logger.Warn(logger.Node, "shutdown: node did not stop within %s, not saving fork choice votes", timeout)
+	}
+
+	if s.backend != nil {
+		if err := s.backend.Flush(); err != nil {
+			logger.Error(logger.Node, "shutdown: flush storage: %v", err)
+		}
+	}
+	logger.Info(logger.Node, "shutdown complete")
+}
+
+func saveVotes(s *store.ConsensusStore) {
+	if n, err := s.SaveVotes(); err != nil {
+		logger.Error(logger.Node, "shutdown: save fork choice votes: %v", err)
+	} else {
+		logger.Info(logger.Node, "shutdown: saved %d fork choice votes", n)
+	}
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type recordingFlusher struct{ flushed bool }
+
+func (f *recordingFlusher) Flush() error {
+	f.flushed = true
+	return nil
+}
+
+// storeWithVote returns a store holding one fork choice vote to save.
+func storeWithVote() (*storage.InMemoryBackend, *store.ConsensusStore) {
+	backend := storage.NewInMemoryBackend()
+	s := store.NewConsensusStore(backend)
+	participants := types.NewBitlistSSZ(1)
+	types.BitlistSet(participants, 0)
+	data := &types.AttestationData{
+		Slot:   3,
+		Head:   &types.Checkpoint{Slot: 3},
+		Target: &types.Checkpoint{Slot: 3},
+		Source: &types.Checkpoint{},
+	}
+	s.NewPayloads.Push([32]byte{0x01}, data, &types.AggregatedSignatureProof{Participants: participants, ProofData: []byte{0x01}})
+	return backend, s
+}
+
+func TestShutdownStepsRunInOrder(t *testing.T) {
+	backend, s := storeWithVote()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := &http.Server{Handler: http.NotFoundHandler()}
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(listener) }()
+
+	nodeStopped := make(chan struct{})
+	cancelled := false
+	flusher := &recordingFlusher{}
+	shutdownSteps{
+		cancel: func() {
+			if conn, err := net.Dial("tcp", listener.Addr().String()); err == nil {
+				conn.Close()
+				t.Error("node cancelled before the HTTP server drained")
+			}
+			cancelled = true
+			close(nodeStopped)
+		},
+		nodeStopped: nodeStopped,
+		store:       s,
+		servers:     []*http.Server{srv},
+		backend:     flusher,
+	}.run(time.Second)
+
+	if !cancelled {
+		t.Fatal("node context was not cancelled")
+	}
+	if got := backend.CountEntries(storage.TableForkChoiceVotes); got != 1 {
+		t.Fatalf("saved votes=%d, want 1", got)
+	}
+	if err := <-served; err != http.ErrServerClosed {
+		t.Fatalf("serve returned %v, want ErrServerClosed", err)
+	}
+	if !flusher.flushed {
+		t.Fatal("storage was not flushed")
+	}
+}
+
+func TestShutdownStepsGiveUpAfterTimeout(t *testing.T) {
+	backend, s := storeWithVote()
+	start := time.Now()
+	shutdownSteps{
+		cancel:      func() {},
+		nodeStopped: make(chan struct{}),
+		store:       s,
+	}.run(50 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("shutdown took %s with a stuck node, want about the timeout", elapsed)
+	}
+	if got := backend.CountEntries(storage.TableForkChoiceVotes); got != 0 {
+		t.Fatalf("saved votes=%d while the node was still running, want 0", got)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/geanlabs/gean/internal/genesis"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// resolveChainSpec returns the spec from config.yaml. The subnet count comes
+// from --attestation-committee-count when it is given and from the spec
+// otherwise; if both config.yaml and the flag set it they must agree.
+func resolveChainSpec(cfg *config, genesisConfig *genesis.GenesisConfig, stderr io.Writer) (types.ChainSpec, error) {
+	spec, err := genesisConfig.Spec()
+	if err != nil {
+		return types.ChainSpec{}, fmt.Errorf("chain spec: %w", err)
+	}
+
+	switch {
+	case !cfg.committeeCountSet:
+		cfg.CommitteeCount = spec.AttestationCommitteeCount
+	case genesisConfig.AttestationCommitteeCount != nil && cfg.CommitteeCount != spec.AttestationCommitteeCount:
+		return types.ChainSpec{}, fmt.Errorf("--attestation-committee-count=%d disagrees with ATTESTATION_COMMITTEE_COUNT=%d in config.yaml",
+			cfg.CommitteeCount, spec.AttestationCommitteeCount)
+	default:
+		spec.AttestationCommitteeCount = cfg.CommitteeCount
+	}
+	if err := validateAggregateSubnetIDs(cfg.AggregateSubnetIDs, cfg.CommitteeCount, stderr); err != nil {
+		return types.ChainSpec{}, err
+	}
+
+	logger.Info(logger.Node, "chain spec: preset=%s seconds_per_slot=%d intervals_per_slot=%d committees=%d max_attestations_data=%d",
+		spec.Preset, spec.SecondsPerSlot, spec.IntervalsPerSlot, spec.AttestationCommitteeCount, spec.MaxAttestationsData)
+	return spec, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/genesis"
+)
+
+func TestResolveChainSpecCommitteeCount(t *testing.T) {
+	four := uint64(4)
+	var stderr bytes.Buffer
+
+	cfg, err := parseConfig(validFlagArgs(), &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := resolveChainSpec(&cfg, &genesis.GenesisConfig{AttestationCommitteeCount: &four}, &stderr)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if cfg.CommitteeCount != 4 || spec.AttestationCommitteeCount != 4 {
+		t.Fatalf("committee count cfg=%d spec=%d, want 4 from config.yaml", cfg.CommitteeCount, spec.AttestationCommitteeCount)
+	}
+
+	cfg, _ = parseConfig(append(validFlagArgs(), "--attestation-committee-count", "2"), &stderr)
+	spec, err = resolveChainSpec(&cfg, &genesis.GenesisConfig{}, &stderr)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if cfg.CommitteeCount != 2 || spec.AttestationCommitteeCount != 2 {
+		t.Fatalf("committee count cfg=%d spec=%d, want 2 from flag", cfg.CommitteeCount, spec.AttestationCommitteeCount)
+	}
+
+	cfg, _ = parseConfig(append(validFlagArgs(), "--attestation-committee-count", "2"), &stderr)
+	if _, err := resolveChainSpec(&cfg, &genesis.GenesisConfig{AttestationCommitteeCount: &four}, &stderr); err == nil {
+		t.Fatal("expected flag and config.yaml disagreement to fail")
+	}
+}
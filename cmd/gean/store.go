@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
+	"github.com/geanlabs/gean/internal/clock"
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/storage"
 	"github.com/geanlabs/gean/internal/store"
@@ -30,63 +30,10 @@ func openStore(dataDir string) (*storage.PebbleBackend, *store.ConsensusStore, e
 }
 
 func initStoreFromState(s *store.ConsensusStore, state *types.State) ([32]byte, error) {
-	if s == nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: store is nil")
-	}
-	if state == nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: state is nil")
-	}
-	header := state.LatestBlockHeader
-	if header == nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: latest block header is nil")
-	}
-
-	stateRoot, err := state.HashTreeRoot()
-	if err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: state root: %w", err)
-	}
-
-	if header.StateRoot == types.ZeroRoot {
-		header.StateRoot = stateRoot
-	}
-	blockRoot, err := header.HashTreeRoot()
-	if err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: block root: %w", err)
-	}
-
-	anchor := &types.Checkpoint{Root: blockRoot, Slot: header.Slot}
-
-	if err := s.PutConfig(state.Config); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutHead(blockRoot); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutSafeTarget(blockRoot); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutLatestJustified(anchor); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutLatestFinalized(anchor); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutBlockHeader(blockRoot, header); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutState(blockRoot, state); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-	if err := s.PutLiveChainEntry(state.Slot, blockRoot, header.ParentRoot); err != nil {
-		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
-	}
-
-	logger.Info(logger.Store, "store initialized from anchor: slot=%d head=%x parent_root=%x state_root=%x",
-		header.Slot, blockRoot, header.ParentRoot, stateRoot)
-	return blockRoot, nil
+	return s.InitFromState(state)
 }
 
-func recoverStoreTime(s *store.ConsensusStore, genesisTimeSec uint64) error {
+func recoverStoreTime(s *store.ConsensusStore, genesisTimeSec uint64, clk clock.Clock) error {
 	if s == nil {
 		return fmt.Errorf("recover store time: store is nil")
 	}
@@ -94,11 +41,11 @@ func recoverStoreTime(s *store.ConsensusStore, genesisTimeSec uint64) error {
 		return fmt.Errorf("recover store time: genesis time %d overflows milliseconds", genesisTimeSec)
 	}
 	genesisMs := genesisTimeSec * 1000
-	nowMs := uint64(time.Now().UnixMilli())
+	nowMs := uint64(clock.Or(clk).Now().UnixMilli())
 	if nowMs <= genesisMs {
 		return s.PutTime(0)
 	}
-	intervals := (nowMs - genesisMs) / types.MillisecondsPerInterval
+	intervals := (nowMs - genesisMs) / s.Spec().MillisecondsPerInterval()
 	if err := s.PutTime(intervals); err != nil {
 		return fmt.Errorf("recover store time: %w", err)
 	}
@@ -107,6 +54,19 @@ func recoverStoreTime(s *store.ConsensusStore, genesisTimeSec uint64) error {
 	return nil
 }
 
+// restoreVotes reloads the attestation payloads saved at the last shutdown.
+// A failure only costs the votes, so it is logged rather than fatal.
+func restoreVotes(s *store.ConsensusStore) {
+	n, err := s.RestoreVotes()
+	if err != nil {
+		logger.Warn(logger.Node, "restore fork choice votes: %v", err)
+		return
+	}
+	if n > 0 {
+		logger.Info(logger.Node, "restored %d fork choice votes from last shutdown", n)
+	}
+}
+
 func forkChoiceAnchor(s *store.ConsensusStore) (uint64, [32]byte, [32]byte, error) {
 	if s == nil {
 		return 0, types.ZeroRoot, types.ZeroRoot, fmt.Errorf("fork choice anchor: store is nil")
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/geanlabs/gean/internal/keystore"
+	"gopkg.in/yaml.v3"
+)
+
+type convertOptions struct {
+	Dir                  string
+	KeystorePasswordFile string
+	KeystoreKDF          string
+	DeleteRaw            bool
+}
+
+// skFields maps annotated_validators.yaml secret-key fields to the pubkey
+// field they belong to and the key role recorded in the keystore path.
+var skFields = map[string]struct{ pubkey, role string }{
+	"privkey_file":        {"pubkey_hex", "key"},
+	"attestation_sk_file": {"attestation_pubkey_hex", "attestation"},
+	"proposal_sk_file":    {"proposal_pubkey_hex", "proposal"},
+}
+
+func parseConvertOptions(args []string, stderr io.Writer) (convertOptions, error) {
+	opts := convertOptions{}
+	fs := flag.NewFlagSet("keygen convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.Dir, "dir", "testnet", "Config directory with annotated_validators.yaml and hash-sig-keys")
+	fs.StringVar(&opts.KeystorePasswordFile, "keystore-password-file", "", "Password used to encrypt the keystores (required)")
+	fs.StringVar(&opts.KeystoreKDF, "keystore-kdf", "scrypt", "Keystore key derivation function: scrypt or pbkdf2")
+	fs.BoolVar(&opts.DeleteRaw, "delete-raw", false, "Remove raw secret keys after conversion")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.Dir == "" || opts.KeystorePasswordFile == "" {
+		return opts, fmt.Errorf("%w: --dir and --keystore-password-file are required", errInvalidOptions)
+	}
+	return opts, nil
+}
+
+// runConvert encrypts every raw secret key referenced by
+// annotated_validators.yaml and rewrites the references to the keystores.
+func runConvert(args []string, stderr io.Writer) error {
+	opts, err := parseConvertOptions(args, stderr)
+	if err != nil {
+		return err
+	}
+	sealer, err := newKeySealer(opts.KeystorePasswordFile, opts.KeystoreKDF)
+	if err != nil {
+		return err
+	}
+
+	annotatedPath := filepath.Join(opts.Dir, "annotated_validators.yaml")
+	keysDir := filepath.Join(opts.Dir, "hash-sig-keys")
+	data, err := os.ReadFile(annotatedPath)
+	if err != nil {
+		return fmt.Errorf("read annotated validators: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse annotated validators: %w", err)
+	}
+
+	converted := make(map[string]string)
+	if err := convertNode(&doc, keysDir, sealer, converted); err != nil {
+		return err
+	}
+	if len(converted) == 0 {
+		log.Printf("no raw secret keys found in %s", annotatedPath)
+		return nil
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encode annotated validators: %w", err)
+	}
+	if err := os.WriteFile(annotatedPath, out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write annotated validators: %w", err)
+	}
+	if err := renameManifestKeys(filepath.Join(opts.Dir, "manifest.json"), converted); err != nil {
+		return err
+	}
+
+	if opts.DeleteRaw {
+		for raw := range converted {
+			if err := os.Remove(filepath.Join(keysDir, raw)); err != nil {
+				return fmt.Errorf("remove raw key %s: %w", raw, err)
+			}
+		}
+	}
+	log.Printf("converted %d secret keys to keystores (raw keys deleted: %t)", len(converted), opts.DeleteRaw)
+	return nil
+}
+
+func convertNode(node *yaml.Node, keysDir string, sealer *keySealer, converted map[string]string) error {
+	if node.Kind == yaml.MappingNode {
+		if err := convertValidator(node, keysDir, sealer, converted); err != nil {
+			return err
+		}
+	}
+	for _, child := range node.Content {
+		if err := convertNode(child, keysDir, sealer, converted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertValidator(node *yaml.Node, keysDir string, sealer *keySealer, converted map[string]string) error {
+	fields := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fields[node.Content[i].Value] = node.Content[i+1]
+	}
+	index, _ := strconv.Atoi(valueOf(fields["index"]))
+
+	for field, meta := range skFields {
+		skNode := fields[field]
+		if skNode == nil || skNode.Value == "" {
+			continue
+		}
+		raw := skNode.Value
+		if name, ok := converted[raw]; ok {
+			skNode.Value = name
+			continue
+		}
+
+		if filepath.IsAbs(raw) {
+			return fmt.Errorf("validator %d: absolute key path %s is not supported", index, raw)
+		}
+		skBytes, err := os.ReadFile(filepath.Join(keysDir, raw))
+		if err != nil {
+			return fmt.Errorf("read %s: %w", raw, err)
+		}
+		if keystore.IsKeystore(skBytes) {
+			continue
+		}
+		pkBytes, err := hex.DecodeString(strings.TrimPrefix(valueOf(fields[meta.pubkey]), "0x"))
+		if err != nil {
+			return fmt.Errorf("validator %d %s: %w", index, meta.pubkey, err)
+		}
+		name, err := sealer.writeSecretKey(keysDir, raw, skBytes, pkBytes, index, meta.role)
+		if err != nil {
+			return fmt.Errorf("encrypt %s: %w", raw, err)
+		}
+		converted[raw] = name
+		skNode.Value = name
+	}
+	return nil
+}
+
+func renameManifestKeys(path string, converted map[string]string) error {
+	m, err := loadManifest(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load manifest: %w", err)
+	}
+	for i := range m.Validators {
+		v := &m.Validators[i]
+		if name, ok := converted[v.AttestationSkFile]; ok {
+			v.AttestationSkFile = name
+		}
+		if name, ok := converted[v.ProposalSkFile]; ok {
+			v.ProposalSkFile = name
+		}
+	}
+	return saveManifest(path, m)
+}
+
+func valueOf(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	return node.Value
+}
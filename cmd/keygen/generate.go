@@ -1,65 +1,154 @@
 package main
 
 import (
-	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"time"
+	"sort"
+	"sync"
 
+	"github.com/geanlabs/gean/internal/types"
 	"github.com/geanlabs/gean/xmss"
 	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-func generateKeys(opts options, keysDir string) (manifest, error) {
-	var m manifest
+type keyPair interface {
+	PublicKeyBytes() ([types.PubkeySize]byte, error)
+	PrivateKeyBytes() ([]byte, error)
+	Close()
+}
 
-	log.Printf("generating %d XMSS validator keypairs (2 keys each, ~40s per key)...", opts.Validators)
-	for i := range opts.Validators {
-		log.Printf("  generating validator %d/%d...", i+1, opts.Validators)
+var generateKeyPair = func(seedPhrase string, activationEpoch, activeEpochs uint64) (keyPair, error) {
+	return xmss.GenerateKeyPair(seedPhrase, activationEpoch, activeEpochs)
+}
 
-		attPubHex, attSkFile, err := generateAndSaveKey(i, "attestation", keysDir)
-		if err != nil {
-			return manifest{}, err
-		}
-		propPubHex, propSkFile, err := generateAndSaveKey(i, "proposal", keysDir)
-		if err != nil {
-			return manifest{}, err
+// generateKeys creates the validators missing from done across opts.Workers
+// goroutines, calling progress with the manifest so far after each one, and
+// then the node keys.
+func generateKeys(opts options, keysDir string, sealer *keySealer, done []validatorInfo, progress func(manifest) error) (manifest, error) {
+	gen := opts.generation()
+	m := manifest{Generation: &gen, Validators: append([]validatorInfo(nil), done...)}
+
+	have := make(map[int]bool, len(done))
+	for _, v := range done {
+		have[v.Index] = true
+	}
+	var pending []int
+	for i := range opts.Validators {
+		if !have[i] {
+			pending = append(pending, i)
 		}
+	}
 
-		m.Validators = append(m.Validators, validatorInfo{
-			Index:                i,
-			AttestationPubkeyHex: attPubHex,
-			ProposalPubkeyHex:    propPubHex,
-			AttestationSkFile:    attSkFile,
-			ProposalSkFile:       propSkFile,
-		})
+	if len(done) > 0 {
+		log.Printf("resuming: %d/%d validators already generated", len(done), opts.Validators)
+	}
+	if len(pending) > 0 {
+		log.Printf("generating %d XMSS validator keypairs (2 keys each, ~40s per key) on %d workers...",
+			len(pending), min(opts.Workers, len(pending)))
+	}
 
-		log.Printf("  validator %d: att=%s...%s prop=%s...%s",
-			i, attPubHex[:8], attPubHex[len(attPubHex)-8:],
-			propPubHex[:8], propPubHex[len(propPubHex)-8:])
+	type result struct {
+		info validatorInfo
+		err  error
+	}
+	jobs := make(chan int)
+	results := make(chan result)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range min(opts.Workers, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				info, err := generateValidator(opts, idx, keysDir, sealer)
+				results <- result{info, err}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, idx := range pending {
+			select {
+			case jobs <- idx:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	for r := range results {
+		if firstErr != nil {
+			continue
+		}
+		if r.err == nil {
+			m.Validators = append(m.Validators, r.info)
+			sort.Slice(m.Validators, func(i, j int) bool { return m.Validators[i].Index < m.Validators[j].Index })
+			r.err = progress(m)
+			logValidator(r.info, len(m.Validators), opts.Validators)
+		}
+		if r.err != nil {
+			firstErr = r.err
+			close(stop)
+		}
+	}
+	if firstErr != nil {
+		return manifest{}, firstErr
 	}
 
 	log.Printf("generating %d node keys...", opts.Nodes)
-	for i := range opts.Nodes {
-		node, err := generateNodeKey(opts.OutputDir, i)
+	for i, name := range opts.nodeNames() {
+		node, err := generateNodeKey(opts.OutputDir, name, i, opts.Seed)
 		if err != nil {
 			return manifest{}, err
 		}
 		m.Nodes = append(m.Nodes, node)
-		log.Printf("  node%d: peer_id=%s", i, node.PeerID)
+		log.Printf("  %s: peer_id=%s", name, node.PeerID)
 	}
 
 	return m, nil
 }
 
-func generateAndSaveKey(validatorIdx int, keyType, keysDir string) (string, string, error) {
-	seed := fmt.Sprintf("gean-testnet-validator-%d-%s-%d", validatorIdx, keyType, time.Now().UnixNano())
+func generateValidator(opts options, idx int, keysDir string, sealer *keySealer) (validatorInfo, error) {
+	attPubHex, attSkFile, err := generateAndSaveKey(opts, idx, "attestation", keysDir, sealer)
+	if err != nil {
+		return validatorInfo{}, err
+	}
+	propPubHex, propSkFile, err := generateAndSaveKey(opts, idx, "proposal", keysDir, sealer)
+	if err != nil {
+		return validatorInfo{}, err
+	}
+	return validatorInfo{
+		Index:                idx,
+		AttestationPubkeyHex: attPubHex,
+		ProposalPubkeyHex:    propPubHex,
+		AttestationSkFile:    attSkFile,
+		ProposalSkFile:       propSkFile,
+	}, nil
+}
+
+func logValidator(v validatorInfo, done, total int) {
+	log.Printf("  validator %d (%d/%d): att=%s...%s prop=%s...%s",
+		v.Index, done, total,
+		v.AttestationPubkeyHex[:8], v.AttestationPubkeyHex[len(v.AttestationPubkeyHex)-8:],
+		v.ProposalPubkeyHex[:8], v.ProposalPubkeyHex[len(v.ProposalPubkeyHex)-8:])
+}
+
+func generateAndSaveKey(opts options, validatorIdx int, keyType, keysDir string, sealer *keySealer) (string, string, error) {
+	seed, err := validatorKeySeed(opts.Seed, validatorIdx, keyType)
+	if err != nil {
+		return "", "", fmt.Errorf("seed %s key for validator %d: %w", keyType, validatorIdx, err)
+	}
 
-	kp, err := xmss.GenerateKeyPair(seed, 0, 1<<18)
+	kp, err := generateKeyPair(seed, opts.ActivationEpoch, opts.ActiveEpochs)
 	if err != nil {
 		return "", "", fmt.Errorf("generate %s key for validator %d: %w", keyType, validatorIdx, err)
 	}
@@ -75,34 +164,34 @@ func generateAndSaveKey(validatorIdx int, keyType, keysDir string) (string, stri
 		return "", "", fmt.Errorf("serialize %s secret key for validator %d: %w", keyType, validatorIdx, err)
 	}
 
-	skFile := fmt.Sprintf("validator_%d_%s_sk.ssz", validatorIdx, keyType)
-	skPath := filepath.Join(keysDir, skFile)
-	if err := os.WriteFile(skPath, skBytes, 0o600); err != nil {
+	skFile, err := sealer.writeSecretKey(keysDir, fmt.Sprintf("validator_%d_%s_sk.ssz", validatorIdx, keyType),
+		skBytes, pkBytes[:], validatorIdx, keyType)
+	if err != nil {
 		return "", "", fmt.Errorf("write %s secret key for validator %d: %w", keyType, validatorIdx, err)
 	}
 
 	return hex.EncodeToString(pkBytes[:]), skFile, nil
 }
 
-func generateNodeKey(outputDir string, index int) (nodeInfo, error) {
-	keyBytes := make([]byte, 32)
-	if _, err := rand.Read(keyBytes); err != nil {
-		return nodeInfo{}, fmt.Errorf("generate node%d key: %w", index, err)
+func generateNodeKey(outputDir, name string, index int, seed []byte) (nodeInfo, error) {
+	keyBytes, err := nodeKeyBytes(seed, index)
+	if err != nil {
+		return nodeInfo{}, fmt.Errorf("generate %s key: %w", name, err)
 	}
 
 	privKey, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(keyBytes)
 	if err != nil {
-		return nodeInfo{}, fmt.Errorf("parse node%d key: %w", index, err)
+		return nodeInfo{}, fmt.Errorf("parse %s key: %w", name, err)
 	}
 	peerID, err := peer.IDFromPrivateKey(privKey)
 	if err != nil {
-		return nodeInfo{}, fmt.Errorf("derive node%d peer id: %w", index, err)
+		return nodeInfo{}, fmt.Errorf("derive %s peer id: %w", name, err)
 	}
 
-	keyFile := fmt.Sprintf("node%d.key", index)
+	keyFile := name + ".key"
 	keyPath := filepath.Join(outputDir, keyFile)
 	if err := os.WriteFile(keyPath, []byte(hex.EncodeToString(keyBytes)), 0o600); err != nil {
-		return nodeInfo{}, fmt.Errorf("write node%d key: %w", index, err)
+		return nodeInfo{}, fmt.Errorf("write %s key: %w", name, err)
 	}
 
 	return nodeInfo{KeyFile: keyFile, PeerID: peerID.String()}, nil
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type fakeKeyPair struct {
+	seed string
+}
+
+func (k fakeKeyPair) PublicKeyBytes() ([types.PubkeySize]byte, error) {
+	var pk [types.PubkeySize]byte
+	sum := sha256.Sum256([]byte(k.seed))
+	copy(pk[:], sum[:])
+	return pk, nil
+}
+
+func (k fakeKeyPair) PrivateKeyBytes() ([]byte, error) { return []byte(k.seed), nil }
+
+func (k fakeKeyPair) Close() {}
+
+func useFakeKeyPairs(t *testing.T) *atomic.Int32 {
+	t.Helper()
+	var calls atomic.Int32
+	orig := generateKeyPair
+	generateKeyPair = func(seed string, activationEpoch, activeEpochs uint64) (keyPair, error) {
+		calls.Add(1)
+		if activeEpochs != 64 {
+			return nil, errors.New("active epochs not passed through")
+		}
+		return fakeKeyPair{seed: seed}, nil
+	}
+	t.Cleanup(func() { generateKeyPair = orig })
+	return &calls
+}
+
+func seededOptions(t *testing.T, dir string) options {
+	t.Helper()
+	var stderr bytes.Buffer
+	opts, err := parseOptions([]string{
+		"--validators", "4", "--nodes", "2", "--output", dir, "--workers", "3",
+		"--seed", "000102030405060708090a0b0c0d0e0f", "--active-epochs", "64",
+	}, &stderr)
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	return opts
+}
+
+func generateInto(t *testing.T, opts options) manifest {
+	t.Helper()
+	keysDir := filepath.Join(opts.OutputDir, "hash-sig-keys")
+	if err := os.MkdirAll(keysDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	m, _, err := loadOrGenerate(opts, keysDir, filepath.Join(opts.OutputDir, "manifest.json"), nil)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	return m
+}
+
+func TestSeededGenerationIsReproducible(t *testing.T) {
+	useFakeKeyPairs(t)
+	a := generateInto(t, seededOptions(t, t.TempDir()))
+	b := generateInto(t, seededOptions(t, t.TempDir()))
+
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("manifests differ:\n%+v\n%+v", a, b)
+	}
+	if len(a.Validators) != 4 || a.Validators[3].Index != 3 || a.Generation == nil || a.Generation.ActiveEpochs != 64 {
+		t.Fatalf("manifest=%+v", a)
+	}
+	if a.Validators[0].AttestationPubkeyHex == a.Validators[0].ProposalPubkeyHex {
+		t.Fatal("attestation and proposal keys share a seed")
+	}
+}
+
+func TestSeededGenerationResumesPartialManifest(t *testing.T) {
+	calls := useFakeKeyPairs(t)
+	dir := t.TempDir()
+	opts := seededOptions(t, dir)
+	full := generateInto(t, opts)
+
+	partial := full
+	partial.Validators = full.Validators[:1]
+	partial.Nodes = nil
+	if err := saveManifest(filepath.Join(dir, "manifest.json"), &partial); err != nil {
+		t.Fatalf("save partial: %v", err)
+	}
+	calls.Store(0)
+
+	resumed := generateInto(t, opts)
+	if got := calls.Load(); got != 6 {
+		t.Fatalf("generated %d keys on resume, want 6", got)
+	}
+	if !reflect.DeepEqual(resumed, full) {
+		t.Fatalf("resumed manifest differs:\n%+v\n%+v", resumed, full)
+	}
+
+	other := opts
+	other.Seed = []byte("another master seed!")
+	calls.Store(0)
+	generateInto(t, other)
+	if got := calls.Load(); got != 8 {
+		t.Fatalf("generated %d keys for a different seed, want 8", got)
+	}
+}
+
+func TestMasterSeed(t *testing.T) {
+	phrase := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	a, err := masterSeed("", phrase)
+	if err != nil {
+		t.Fatalf("mnemonic: %v", err)
+	}
+	b, _ := masterSeed("", "  abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about ")
+	if !bytes.Equal(a, b) || len(a) != 64 {
+		t.Fatalf("mnemonic seeds differ or have wrong length: %x %x", a, b)
+	}
+	// BIP-39 test vector for this phrase with an empty passphrase.
+	if got := hex.EncodeToString(a[:8]); got != "5eb00bbddcf06908" {
+		t.Fatalf("mnemonic seed=%s..., want 5eb00bbddcf06908...", got)
+	}
+
+	for _, tc := range [][2]string{
+		{"0011", ""},
+		{"zz", ""},
+		{"", "too short"},
+		{"000102030405060708090a0b0c0d0e0f", phrase},
+	} {
+		if _, err := masterSeed(tc[0], tc[1]); !errors.Is(err, errInvalidOptions) {
+			t.Fatalf("seed=%q mnemonic=%q err=%v, want errInvalidOptions", tc[0], tc[1], err)
+		}
+	}
+}
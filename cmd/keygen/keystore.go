@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/geanlabs/gean/internal/keystore"
+)
+
+// keySealer encrypts secret keys into EIP-2335 keystores. A nil sealer
+// writes raw SSZ secret keys.
+type keySealer struct {
+	password string
+	params   keystore.Params
+}
+
+func newKeySealer(passwordFile, kdf string) (*keySealer, error) {
+	if passwordFile == "" {
+		return nil, nil
+	}
+	params, err := kdfParams(kdf)
+	if err != nil {
+		return nil, err
+	}
+	password, err := keystore.ReadPasswordFile(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+	if password == "" {
+		return nil, fmt.Errorf("%w: keystore password file %s is empty", errInvalidOptions, passwordFile)
+	}
+	return &keySealer{password: password, params: params}, nil
+}
+
+func kdfParams(kdf string) (keystore.Params, error) {
+	switch kdf {
+	case keystore.KDFScrypt:
+		return keystore.DefaultScrypt, nil
+	case keystore.KDFPBKDF2:
+		return keystore.DefaultPBKDF2, nil
+	default:
+		return keystore.Params{}, fmt.Errorf("%w: unknown keystore kdf %q", errInvalidOptions, kdf)
+	}
+}
+
+func keystoreFileName(rawFile string) string {
+	return strings.TrimSuffix(rawFile, filepath.Ext(rawFile)) + ".json"
+}
+
+func keystorePath(validatorIdx int, keyType string) string {
+	return fmt.Sprintf("m/%d/%s", validatorIdx, keyType)
+}
+
+// writeSecretKey stores skBytes under keysDir and returns the file name used.
+func (s *keySealer) writeSecretKey(keysDir, rawFile string, skBytes, pkBytes []byte, validatorIdx int, keyType string) (string, error) {
+	if s == nil {
+		return rawFile, os.WriteFile(filepath.Join(keysDir, rawFile), skBytes, 0o600)
+	}
+	ks, err := keystore.Encrypt(skBytes, s.password, pkBytes, keystorePath(validatorIdx, keyType),
+		fmt.Sprintf("validator %d %s key", validatorIdx, keyType), s.params)
+	if err != nil {
+		return "", err
+	}
+	data, err := ks.Marshal()
+	if err != nil {
+		return "", err
+	}
+	name := keystoreFileName(rawFile)
+	return name, os.WriteFile(filepath.Join(keysDir, name), data, 0o600)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writeLayoutOutputs writes the files describing a layout devnet: validators
+// assigned by node name, ENR bootnodes and launch.sh.
+func writeLayoutOutputs(outputDir string, l *layout, m manifest) error {
+	if err := writeOutput(outputDir, "annotated_validators.yaml", renderLayoutValidatorsYAML(m.Validators, l)); err != nil {
+		return err
+	}
+
+	nodeKeys := make([][]byte, len(m.Nodes))
+	for i, n := range m.Nodes {
+		data, err := os.ReadFile(filepath.Join(outputDir, n.KeyFile))
+		if err != nil {
+			return fmt.Errorf("read node key: %w", err)
+		}
+		if nodeKeys[i], err = hex.DecodeString(strings.TrimSpace(string(data))); err != nil {
+			return fmt.Errorf("decode %s: %w", n.KeyFile, err)
+		}
+	}
+	nodes, err := renderNodeENRsYAML(l, nodeKeys)
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(outputDir, "nodes.yaml", nodes); err != nil {
+		return err
+	}
+
+	if err := writeOutput(outputDir, "launch.sh", renderLaunchScript(l)); err != nil {
+		return err
+	}
+	return os.Chmod(filepath.Join(outputDir, "launch.sh"), 0o755)
+}
+
+// renderLaunchScript starts every gean node that runs on this machine. Nodes
+// of other clients, and gean nodes on other hosts, are listed as comments so
+// the script still documents the whole devnet.
+func renderLaunchScript(l *layout) string {
+	var out strings.Builder
+	out.WriteString(`#!/usr/bin/env bash
+# Generated by keygen. Starts the local gean nodes of this devnet; set GEAN to
+# the gean binary and DATA_DIR to where node databases go.
+set -euo pipefail
+DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+GEAN="${GEAN:-bin/gean}"
+DATA_DIR="${DATA_DIR:-data}"
+trap 'kill 0' INT TERM
+`)
+	for _, n := range l.Nodes {
+		fmt.Fprintf(&out, "\n# %s: %s at %s, validators [%s]", n.Name, n.Client,
+			net.JoinHostPort(n.Host, fmt.Sprint(n.QUICPort)), n.Validators)
+		if n.Aggregator {
+			out.WriteString(", aggregator")
+		}
+		out.WriteString("\n")
+		if n.Client != clientGean {
+			fmt.Fprintf(&out, "# start with the %s tooling using node key %s.key and this directory's config\n", n.Client, n.Name)
+			continue
+		}
+		cmd := geanCommand(n)
+		if !isLocalHost(n.Host) {
+			fmt.Fprintf(&out, "# run on %s: %s\n", n.Host, cmd)
+			continue
+		}
+		fmt.Fprintf(&out, "%s &\n", cmd)
+	}
+	out.WriteString("\nwait\n")
+	return out.String()
+}
+
+func geanCommand(n layoutNode) string {
+	args := []string{
+		`"$GEAN"`,
+		`--custom-network-config-dir "$DIR"`,
+		fmt.Sprintf(`--node-key "$DIR/%s.key"`, n.Name),
+		"--node-id " + n.Name,
+		fmt.Sprintf("--gossipsub-port %d", n.QUICPort),
+		fmt.Sprintf("--api-port %d", n.apiPort()),
+		fmt.Sprintf("--metrics-port %d", n.metricsPort()),
+	}
+	if n.Aggregator {
+		args = append(args, "--is-aggregator")
+	}
+	args = append(args, fmt.Sprintf(`--data-dir "$DATA_DIR/%s"`, n.Name))
+	return strings.Join(args, " ")
+}
+
+func isLocalHost(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/geanlabs/gean/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	clientGean = "gean"
+
+	geanDefaultAPIPort     = 5052
+	geanDefaultMetricsPort = 5054
+)
+
+// layout describes a devnet: which nodes run, where they listen and which
+// validators each one holds.
+type layout struct {
+	Nodes []layoutNode `yaml:"nodes"`
+}
+
+type layoutNode struct {
+	Name        string `yaml:"name"`
+	Client      string `yaml:"client"`
+	Host        string `yaml:"host"`
+	QUICPort    int    `yaml:"quic_port"`
+	APIPort     int    `yaml:"api_port"`
+	MetricsPort int    `yaml:"metrics_port"`
+	Aggregator  bool   `yaml:"aggregator"`
+	// Validators is a comma-separated list of indices and inclusive ranges,
+	// e.g. "0-3,7".
+	Validators string `yaml:"validators"`
+
+	indices []int
+}
+
+var nodeNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+func loadLayout(path string) (*layout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read layout: %v", errInvalidOptions, err)
+	}
+	var l layout
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&l); err != nil {
+		return nil, fmt.Errorf("%w: parse layout %s: %v", errInvalidOptions, path, err)
+	}
+	if err := l.normalize(); err != nil {
+		return nil, fmt.Errorf("%w: layout %s: %v", errInvalidOptions, path, err)
+	}
+	return &l, nil
+}
+
+// normalize fills in defaults, parses the validator ranges and checks that
+// names and listen addresses are unique and that every validator index from
+// 0 up is held by exactly one node.
+func (l *layout) normalize() error {
+	if len(l.Nodes) == 0 {
+		return fmt.Errorf("no nodes")
+	}
+	names := make(map[string]bool, len(l.Nodes))
+	ports := make(map[string]string)
+	owner := make(map[int]string)
+	for i := range l.Nodes {
+		n := &l.Nodes[i]
+		if !nodeNamePattern.MatchString(n.Name) {
+			return fmt.Errorf("node %d: invalid name %q", i, n.Name)
+		}
+		if names[n.Name] {
+			return fmt.Errorf("duplicate node name %q", n.Name)
+		}
+		names[n.Name] = true
+		if n.Client == "" {
+			n.Client = clientGean
+		}
+		// The client name is written into launch.sh.
+		if !nodeNamePattern.MatchString(n.Client) {
+			return fmt.Errorf("node %s: invalid client %q", n.Name, n.Client)
+		}
+		if n.Host == "" {
+			n.Host = "127.0.0.1"
+		}
+		if ip := net.ParseIP(n.Host); ip == nil || ip.IsUnspecified() {
+			return fmt.Errorf("node %s: host %q is not a reachable IP address", n.Name, n.Host)
+		}
+
+		for _, p := range n.listenPorts() {
+			if p.port < 1 || p.port > 65535 {
+				return fmt.Errorf("node %s: %s %d outside 1..65535", n.Name, p.name, p.port)
+			}
+			addr := net.JoinHostPort(n.Host, strconv.Itoa(p.port))
+			if other, ok := ports[addr]; ok {
+				return fmt.Errorf("node %s: %s %s already used by %s", n.Name, p.name, addr, other)
+			}
+			ports[addr] = n.Name
+		}
+
+		indices, err := parseIndexRanges(n.Validators)
+		if err != nil {
+			return fmt.Errorf("node %s: validators: %v", n.Name, err)
+		}
+		for _, idx := range indices {
+			if other, ok := owner[idx]; ok {
+				return fmt.Errorf("validator %d assigned to both %s and %s", idx, other, n.Name)
+			}
+			owner[idx] = n.Name
+		}
+		n.indices = indices
+	}
+	if len(owner) == 0 {
+		return fmt.Errorf("no validators assigned")
+	}
+	for idx := range len(owner) {
+		if _, ok := owner[idx]; !ok {
+			return fmt.Errorf("validator %d is not assigned to any node", idx)
+		}
+	}
+	return nil
+}
+
+type namedPort struct {
+	name string
+	port int
+}
+
+// listenPorts are the ports the node binds. gean nodes without an explicit
+// API or metrics port use gean's defaults, which must not collide either.
+func (n layoutNode) listenPorts() []namedPort {
+	ports := []namedPort{{"quic_port", n.QUICPort}}
+	api, metrics := n.APIPort, n.MetricsPort
+	if n.Client == clientGean {
+		api, metrics = n.apiPort(), n.metricsPort()
+	}
+	if api != 0 {
+		ports = append(ports, namedPort{"api_port", api})
+	}
+	if metrics != 0 {
+		ports = append(ports, namedPort{"metrics_port", metrics})
+	}
+	return ports
+}
+
+func (n layoutNode) apiPort() int {
+	if n.APIPort == 0 {
+		return geanDefaultAPIPort
+	}
+	return n.APIPort
+}
+
+func (n layoutNode) metricsPort() int {
+	if n.MetricsPort == 0 {
+		return geanDefaultMetricsPort
+	}
+	return n.MetricsPort
+}
+
+func (l *layout) validatorCount() int {
+	total := 0
+	for _, n := range l.Nodes {
+		total += len(n.indices)
+	}
+	return total
+}
+
+func (l *layout) nodeNames() []string {
+	names := make([]string, len(l.Nodes))
+	for i, n := range l.Nodes {
+		names[i] = n.Name
+	}
+	return names
+}
+
+// parseIndexRanges parses "0-3,7" into [0 1 2 3 7]. An empty string holds
+// no validators.
+func parseIndexRanges(raw string) ([]int, error) {
+	var out []int
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid index %q", part)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.Atoi(strings.TrimSpace(hi))
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		}
+		if uint64(last) >= types.ValidatorRegistryLimit {
+			return nil, fmt.Errorf("index %d exceeds the validator registry limit", last)
+		}
+		for idx := first; idx <= last; idx++ {
+			if seen[idx] {
+				return nil, fmt.Errorf("index %d listed twice", idx)
+			}
+			seen[idx] = true
+			out = append(out, idx)
+		}
+	}
+	return out, nil
+}
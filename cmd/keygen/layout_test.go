@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/p2p"
+)
+
+const testLayout = `nodes:
+  - name: gean_0
+    quic_port: 9000
+    aggregator: true
+    validators: "0-2"
+  - name: zeam_0
+    client: zeam
+    quic_port: 9001
+    api_port: 5053
+    validators: "3, 5"
+  - name: gean_1
+    host: 10.0.0.2
+    quic_port: 9000
+    validators: "4"
+`
+
+func writeLayout(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "layout.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write layout: %v", err)
+	}
+	return path
+}
+
+func TestLoadLayout(t *testing.T) {
+	l, err := loadLayout(writeLayout(t, testLayout))
+	if err != nil {
+		t.Fatalf("load layout: %v", err)
+	}
+	if l.validatorCount() != 6 || !reflect.DeepEqual(l.nodeNames(), []string{"gean_0", "zeam_0", "gean_1"}) {
+		t.Fatalf("layout=%+v", l)
+	}
+	if n := l.Nodes[0]; n.Client != clientGean || n.Host != "127.0.0.1" || !reflect.DeepEqual(n.indices, []int{0, 1, 2}) {
+		t.Fatalf("defaults not applied: %+v", n)
+	}
+	if got := l.Nodes[1].indices; !reflect.DeepEqual(got, []int{3, 5}) {
+		t.Fatalf("zeam_0 indices=%v", got)
+	}
+}
+
+func TestLoadLayoutRejectsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no nodes":        "nodes: []\n",
+		"unknown field":   "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"0\"\n    port: 1\n",
+		"bad name":        "nodes:\n  - name: ../a\n    quic_port: 9000\n    validators: \"0\"\n",
+		"bad client":      "nodes:\n  - name: a\n    client: \"zeam\\nrm -rf /\"\n    quic_port: 9000\n    validators: \"0\"\n",
+		"duplicate name":  "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"0\"\n  - name: a\n    quic_port: 9001\n    validators: \"1\"\n",
+		"bad host":        "nodes:\n  - name: a\n    host: example.com\n    quic_port: 9000\n    validators: \"0\"\n",
+		"missing port":    "nodes:\n  - name: a\n    validators: \"0\"\n",
+		"port collision":  "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"0\"\n  - name: b\n    quic_port: 9001\n    validators: \"1\"\n",
+		"overlap":         "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"0-1\"\n  - name: b\n    quic_port: 9001\n    api_port: 6052\n    metrics_port: 6054\n    validators: \"1\"\n",
+		"gap":             "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"0,2\"\n",
+		"reversed range":  "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"3-1\"\n",
+		"no validators":   "nodes:\n  - name: a\n    quic_port: 9000\n",
+		"index too large": "nodes:\n  - name: a\n    quic_port: 9000\n    validators: \"0-99999999\"\n",
+	}
+	for name, content := range tests {
+		if _, err := loadLayout(writeLayout(t, content)); !errors.Is(err, errInvalidOptions) {
+			t.Fatalf("%s: err=%v, want errInvalidOptions", name, err)
+		}
+	}
+}
+
+func TestParseOptionsLayout(t *testing.T) {
+	path := writeLayout(t, testLayout)
+	var stderr bytes.Buffer
+	opts, err := parseOptions([]string{"--layout", path}, &stderr)
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	if opts.Layout == nil || opts.Validators != 6 || opts.Nodes != 3 {
+		t.Fatalf("opts=%+v", opts)
+	}
+	if _, err := parseOptions([]string{"--layout", path, "--nodes", "3"}, &stderr); !errors.Is(err, errInvalidOptions) {
+		t.Fatalf("--nodes with --layout: err=%v", err)
+	}
+}
+
+func TestLayoutOutputs(t *testing.T) {
+	useFakeKeyPairs(t)
+	dir := t.TempDir()
+	var stderr bytes.Buffer
+	err := run([]string{
+		"--layout", writeLayout(t, testLayout), "--output", dir, "--active-epochs", "64",
+		"--seed", "000102030405060708090a0b0c0d0e0f", "--genesis-time", "1700000000",
+	}, &stderr)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	for _, name := range []string{"gean_0.key", "zeam_0.key", "gean_1.key"} {
+		if !fileExists(filepath.Join(dir, name)) {
+			t.Fatalf("missing %s", name)
+		}
+	}
+
+	annotated := readOutput(t, dir, "annotated_validators.yaml")
+	zeam := annotated[strings.Index(annotated, "zeam_0:"):strings.Index(annotated, "gean_1:")]
+	if !strings.Contains(zeam, "index: 3\n") || !strings.Contains(zeam, "index: 5\n") || strings.Contains(zeam, "index: 4\n") {
+		t.Fatalf("zeam_0 validators:\n%s", zeam)
+	}
+
+	addrs, err := p2p.LoadBootnodes(filepath.Join(dir, "nodes.yaml"))
+	if err != nil {
+		t.Fatalf("load nodes.yaml: %v", err)
+	}
+	if len(addrs) != 3 || !strings.HasPrefix(addrs[2].String(), "/ip4/10.0.0.2/udp/9000/quic-v1/p2p/") {
+		t.Fatalf("bootnodes=%v", addrs)
+	}
+
+	script := readOutput(t, dir, "launch.sh")
+	if !strings.Contains(script, `--node-id gean_0 --gossipsub-port 9000 --api-port 5052 --metrics-port 5054 --is-aggregator`) {
+		t.Fatalf("launch.sh does not start gean_0:\n%s", script)
+	}
+	if !strings.Contains(script, "# run on 10.0.0.2: ") || !strings.Contains(script, "# start with the zeam tooling") {
+		t.Fatalf("launch.sh does not describe remote and other-client nodes:\n%s", script)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "launch.sh")); err != nil || info.Mode()&0o100 == 0 {
+		t.Fatalf("launch.sh not executable: %v", err)
+	}
+}
+
+func readOutput(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(data)
+}
@@ -8,7 +8,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
+
+	"github.com/geanlabs/gean/xmss"
 )
 
 var errInvalidOptions = errors.New("invalid keygen options")
@@ -20,10 +23,21 @@ func main() {
 }
 
 func run(args []string, stderr io.Writer) error {
+	if xmss.Insecure {
+		fmt.Fprintln(stderr, "warning: built with xmss_insecure, generated keys are for local testing only")
+	}
+	if len(args) > 0 && args[0] == "convert" {
+		return runConvert(args[1:], stderr)
+	}
+
 	opts, err := parseOptions(args, stderr)
 	if err != nil {
 		return err
 	}
+	sealer, err := newKeySealer(opts.KeystorePasswordFile, opts.KeystoreKDF)
+	if err != nil {
+		return err
+	}
 
 	keysDir := filepath.Join(opts.OutputDir, "hash-sig-keys")
 	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
@@ -34,7 +48,7 @@ func run(args []string, stderr io.Writer) error {
 	}
 
 	manifestPath := filepath.Join(opts.OutputDir, "manifest.json")
-	m, reused, err := loadOrGenerate(opts, keysDir, manifestPath)
+	m, reused, err := loadOrGenerate(opts, keysDir, manifestPath, sealer)
 	if err != nil {
 		return err
 	}
@@ -43,15 +57,24 @@ func run(args []string, stderr io.Writer) error {
 			len(m.Validators), len(m.Nodes))
 	}
 
-	genesisTime := uint64(time.Now().Unix()) + 30
-	if err := writeConfigYAML(opts.OutputDir, genesisTime, m.Validators); err != nil {
-		return err
+	genesisTime := opts.GenesisTime
+	if genesisTime == 0 {
+		genesisTime = uint64(time.Now().Unix()) + 30
 	}
-	if err := writeAnnotatedValidatorsYAML(opts.OutputDir, m.Validators, opts.Nodes); err != nil {
+	if err := writeConfigYAML(opts.OutputDir, genesisTime, m.Validators); err != nil {
 		return err
 	}
-	if err := writeNodesYAML(opts.OutputDir, m.Nodes, opts.BasePort); err != nil {
-		return err
+	if opts.Layout != nil {
+		if err := writeLayoutOutputs(opts.OutputDir, opts.Layout, m); err != nil {
+			return err
+		}
+	} else {
+		if err := writeAnnotatedValidatorsYAML(opts.OutputDir, m.Validators, opts.Nodes); err != nil {
+			return err
+		}
+		if err := writeNodesYAML(opts.OutputDir, m.Nodes, opts.BasePort); err != nil {
+			return err
+		}
 	}
 
 	logSummary(opts, genesisTime, m)
@@ -66,10 +89,37 @@ func parseOptions(args []string, stderr io.Writer) (options, error) {
 	fs.IntVar(&opts.Nodes, "nodes", 3, "Number of nodes")
 	fs.StringVar(&opts.OutputDir, "output", "testnet", "Output directory")
 	fs.IntVar(&opts.BasePort, "base-port", 9000, "Base P2P port (incremented per node)")
+	fs.StringVar(&opts.KeystorePasswordFile, "keystore-password-file", "", "Write EIP-2335 keystores encrypted with this password instead of raw secret keys")
+	fs.StringVar(&opts.KeystoreKDF, "keystore-kdf", "scrypt", "Keystore key derivation function: scrypt or pbkdf2")
+	seedHex := fs.String("seed", "", "Hex master seed; derives the same validator and node keys on every run")
+	mnemonic := fs.String("mnemonic", "", "Mnemonic phrase to derive the master seed from, instead of --seed")
+	fs.Uint64Var(&opts.ActivationEpoch, "activation-epoch", 0, "First epoch the validator keys can sign")
+	fs.Uint64Var(&opts.ActiveEpochs, "active-epochs", 1<<18, "Number of epochs the validator keys can sign")
+	fs.IntVar(&opts.Workers, "workers", runtime.NumCPU(), "Validators generated in parallel")
+	fs.Uint64Var(&opts.GenesisTime, "genesis-time", 0, "GENESIS_TIME written to config.yaml (default: 30 seconds from now)")
+	layoutPath := fs.String("layout", "", "YAML devnet layout naming the nodes and their validators; replaces --validators, --nodes and --base-port")
 
 	if err := fs.Parse(args); err != nil {
 		return opts, err
 	}
+	if *layoutPath != "" {
+		var conflict string
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "validators" || f.Name == "nodes" || f.Name == "base-port" {
+				conflict = f.Name
+			}
+		})
+		if conflict != "" {
+			return opts, fmt.Errorf("%w: --%s cannot be combined with --layout", errInvalidOptions, conflict)
+		}
+		l, err := loadLayout(*layoutPath)
+		if err != nil {
+			return opts, err
+		}
+		opts.Layout = l
+		opts.Validators = l.validatorCount()
+		opts.Nodes = len(l.Nodes)
+	}
 	if opts.Validators < 1 || opts.Nodes < 1 {
 		return opts, fmt.Errorf("%w: need at least 1 validator and 1 node", errInvalidOptions)
 	}
@@ -82,16 +132,34 @@ func parseOptions(args []string, stderr io.Writer) (options, error) {
 	if opts.Nodes > 65535-opts.BasePort+1 {
 		return opts, fmt.Errorf("%w: base port range exceeds 1..65535", errInvalidOptions)
 	}
+	if _, err := kdfParams(opts.KeystoreKDF); err != nil {
+		return opts, err
+	}
+	if opts.ActiveEpochs == 0 {
+		return opts, fmt.Errorf("%w: --active-epochs must be > 0", errInvalidOptions)
+	}
+	if opts.Workers < 1 {
+		return opts, fmt.Errorf("%w: --workers must be >= 1", errInvalidOptions)
+	}
+	seed, err := masterSeed(*seedHex, *mnemonic)
+	if err != nil {
+		return opts, err
+	}
+	opts.Seed = seed
 	return opts, nil
 }
 
 func logSummary(opts options, genesisTime uint64, m manifest) {
 	log.Println("---")
 	log.Printf("output: %s", opts.OutputDir)
-	log.Printf("genesis time: %d (in 30 seconds: %s)", genesisTime,
+	log.Printf("genesis time: %d (%s)", genesisTime,
 		time.Unix(int64(genesisTime), 0).Format(time.RFC3339))
 	log.Printf("validators: %d, nodes: %d", len(m.Validators), len(m.Nodes))
 	log.Println("")
+	if opts.Layout != nil {
+		log.Printf("run immediately: %s/launch.sh", opts.OutputDir)
+		return
+	}
 	log.Println("run immediately:")
 	log.Printf("  bin/gean --custom-network-config-dir %s --node-key %s/node0.key --node-id node0 --is-aggregator --data-dir data/node0",
 		opts.OutputDir, opts.OutputDir)
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/geanlabs/gean/internal/keystore"
 )
 
 func TestParseOptionsDefaults(t *testing.T) {
@@ -27,6 +29,7 @@ func TestParseOptionsRejectsInvalidValues(t *testing.T) {
 		{"--output", ""},
 		{"--base-port", "0"},
 		{"--base-port", "65535", "--nodes", "2"},
+		{"--keystore-kdf", "argon2"},
 	}
 	for _, args := range tests {
 		var stderr bytes.Buffer
@@ -193,3 +196,73 @@ func withAttestationPubkey(v validatorInfo, pubkey string) validatorInfo {
 	v.AttestationPubkeyHex = pubkey
 	return v
 }
+
+func TestConvertEncryptsRawKeys(t *testing.T) {
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "hash-sig-keys")
+	if err := os.Mkdir(keysDir, 0o755); err != nil {
+		t.Fatalf("mkdir keys: %v", err)
+	}
+	attPub := strings.Repeat("aa", 52)
+	propPub := strings.Repeat("bb", 52)
+	validators := []validatorInfo{{
+		Index:                0,
+		AttestationPubkeyHex: attPub,
+		ProposalPubkeyHex:    propPub,
+		AttestationSkFile:    "validator_0_attestation_sk.ssz",
+		ProposalSkFile:       "validator_0_proposal_sk.ssz",
+	}}
+	if err := os.WriteFile(filepath.Join(keysDir, "validator_0_attestation_sk.ssz"), []byte{0x01, 0x02}, 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(keysDir, "validator_0_proposal_sk.ssz"), []byte{0x03}, 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := writeAnnotatedValidatorsYAML(dir, validators, 1); err != nil {
+		t.Fatalf("write annotated: %v", err)
+	}
+	if err := saveManifest(filepath.Join(dir, "manifest.json"), &manifest{Validators: validators}); err != nil {
+		t.Fatalf("save manifest: %v", err)
+	}
+	passwordFile := filepath.Join(dir, "pw.txt")
+	if err := os.WriteFile(passwordFile, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("write password: %v", err)
+	}
+
+	var stderr bytes.Buffer
+	args := []string{"convert", "--dir", dir, "--keystore-password-file", passwordFile, "--keystore-kdf", "pbkdf2", "--delete-raw"}
+	if err := run(args, &stderr); err != nil {
+		t.Fatalf("convert: %v\n%s", err, stderr.String())
+	}
+
+	annotated, err := os.ReadFile(filepath.Join(dir, "annotated_validators.yaml"))
+	if err != nil {
+		t.Fatalf("read annotated: %v", err)
+	}
+	if !strings.Contains(string(annotated), "attestation_sk_file: validator_0_attestation_sk.json") {
+		t.Fatalf("annotated validators not rewritten:\n%s", annotated)
+	}
+	if fileExists(filepath.Join(keysDir, "validator_0_attestation_sk.ssz")) {
+		t.Fatal("raw key should be deleted")
+	}
+	m, err := loadManifest(filepath.Join(dir, "manifest.json"))
+	if err != nil || m.Validators[0].ProposalSkFile != "validator_0_proposal_sk.json" {
+		t.Fatalf("manifest=%+v err=%v", m, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(keysDir, "validator_0_proposal_sk.json"))
+	if err != nil {
+		t.Fatalf("read keystore: %v", err)
+	}
+	ks, err := keystore.Parse(data)
+	if err != nil {
+		t.Fatalf("parse keystore: %v", err)
+	}
+	if ks.Pubkey != propPub || ks.Path != "m/0/proposal" {
+		t.Fatalf("keystore metadata pubkey=%s path=%s", ks.Pubkey, ks.Path)
+	}
+	secret, err := ks.Decrypt("hunter2")
+	if err != nil || !bytes.Equal(secret, []byte{0x03}) {
+		t.Fatalf("decrypt secret=%x err=%v", secret, err)
+	}
+}
@@ -3,18 +3,29 @@ package main
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/geanlabs/gean/internal/types"
 )
 
-func loadOrGenerate(opts options, keysDir, manifestPath string) (manifest, bool, error) {
-	if existing, err := loadManifest(manifestPath); err == nil && manifestUsable(existing, opts, keysDir) {
+func loadOrGenerate(opts options, keysDir, manifestPath string, sealer *keySealer) (manifest, bool, error) {
+	existing, err := loadManifest(manifestPath)
+	if err == nil && manifestUsable(existing, opts, keysDir) {
+		if sealer != nil && hasRawKeys(existing.Validators) {
+			return manifest{}, false, fmt.Errorf("%s holds unencrypted keys; run keygen convert to encrypt them", keysDir)
+		}
 		return *existing, true, nil
 	}
 
-	m, err := generateKeys(opts, keysDir)
+	var done []validatorInfo
+	if err == nil && generationMatches(existing.Generation, opts.generation()) {
+		done = resumableValidators(existing.Validators, opts.Validators, keysDir, sealer != nil)
+	}
+	m, err := generateKeys(opts, keysDir, sealer, done, func(partial manifest) error {
+		return saveManifest(manifestPath, &partial)
+	})
 	if err != nil {
 		return manifest{}, false, err
 	}
@@ -26,8 +37,37 @@ func loadOrGenerate(opts options, keysDir, manifestPath string) (manifest, bool,
 
 func manifestUsable(m *manifest, opts options, keysDir string) bool {
 	return m != nil &&
+		generationMatches(m.Generation, opts.generation()) &&
 		validatorsUsable(m.Validators, opts.Validators, keysDir) &&
-		nodesUsable(m.Nodes, opts.Nodes, opts.OutputDir)
+		nodesUsable(m.Nodes, opts.nodeNames(), opts.OutputDir)
+}
+
+// generationMatches reports whether keys recorded with got are the ones want
+// would produce. Manifests written before seeded generation carry no record
+// and stand for random keys.
+func generationMatches(got *generationInfo, want generationInfo) bool {
+	if got == nil {
+		return want.SeedFingerprint == ""
+	}
+	return *got == want
+}
+
+// resumableValidators keeps the entries of an interrupted run whose key files
+// are on disk in the format this run writes.
+func resumableValidators(validators []validatorInfo, want int, keysDir string, encrypted bool) []validatorInfo {
+	var out []validatorInfo
+	seen := make(map[int]bool, len(validators))
+	for _, v := range validators {
+		if v.Index < 0 || v.Index >= want || seen[v.Index] {
+			continue
+		}
+		if !validatorUsable(v, keysDir) || hasRawKeys([]validatorInfo{v}) == encrypted {
+			continue
+		}
+		seen[v.Index] = true
+		out = append(out, v)
+	}
+	return out
 }
 
 func loadManifest(path string) (*manifest, error) {
@@ -63,28 +103,32 @@ func validatorsUsable(validators []validatorInfo, want int, keysDir string) bool
 			return false
 		}
 		seen[v.Index] = true
-		if !validPubkeyHex(v.AttestationPubkeyHex) || !validPubkeyHex(v.ProposalPubkeyHex) {
-			return false
-		}
-		if v.AttestationSkFile == "" || v.ProposalSkFile == "" {
-			return false
-		}
-		if !fileExists(filepath.Join(keysDir, v.AttestationSkFile)) ||
-			!fileExists(filepath.Join(keysDir, v.ProposalSkFile)) {
+		if !validatorUsable(v, keysDir) {
 			return false
 		}
 	}
 	return true
 }
 
-func nodesUsable(nodes []nodeInfo, want int, outputDir string) bool {
-	if len(nodes) != want {
+func validatorUsable(v validatorInfo, keysDir string) bool {
+	if !validPubkeyHex(v.AttestationPubkeyHex) || !validPubkeyHex(v.ProposalPubkeyHex) {
+		return false
+	}
+	if v.AttestationSkFile == "" || v.ProposalSkFile == "" {
+		return false
+	}
+	return fileExists(filepath.Join(keysDir, v.AttestationSkFile)) &&
+		fileExists(filepath.Join(keysDir, v.ProposalSkFile))
+}
+
+func nodesUsable(nodes []nodeInfo, names []string, outputDir string) bool {
+	if len(nodes) != len(names) {
 		return false
 	}
 	seenKeys := make(map[string]bool, len(nodes))
 	seenPeers := make(map[string]bool, len(nodes))
-	for _, n := range nodes {
-		if n.KeyFile == "" || n.PeerID == "" || seenKeys[n.KeyFile] || seenPeers[n.PeerID] {
+	for i, n := range nodes {
+		if n.KeyFile != names[i]+".key" || n.PeerID == "" || seenKeys[n.KeyFile] || seenPeers[n.PeerID] {
 			return false
 		}
 		seenKeys[n.KeyFile] = true
@@ -104,6 +148,15 @@ func validPubkeyHex(s string) bool {
 	return err == nil
 }
 
+func hasRawKeys(validators []validatorInfo) bool {
+	for _, v := range validators {
+		if filepath.Ext(v.AttestationSkFile) != ".json" || filepath.Ext(v.ProposalSkFile) != ".json" {
+			return true
+		}
+	}
+	return false
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
@@ -1,17 +1,42 @@
 package main
 
+import "fmt"
+
 type options struct {
 	Validators int
 	Nodes      int
 	OutputDir  string
 	BasePort   int
+
+	KeystorePasswordFile string
+	KeystoreKDF          string
+
+	// Seed is the master seed for deterministic keys; nil means random keys.
+	Seed            []byte
+	ActivationEpoch uint64
+	ActiveEpochs    uint64
+	Workers         int
+	GenesisTime     uint64
+
+	// Layout, when set, names the nodes and assigns validators to them;
+	// Validators and Nodes are derived from it.
+	Layout *layout
 }
 
 type manifest struct {
+	Generation *generationInfo `json:"generation,omitempty"`
 	Validators []validatorInfo `json:"validators"`
 	Nodes      []nodeInfo      `json:"nodes"`
 }
 
+// generationInfo records the inputs keys were generated from, so a rerun can
+// tell whether the keys on disk are the ones it would produce.
+type generationInfo struct {
+	SeedFingerprint string `json:"seed_fingerprint,omitempty"`
+	ActivationEpoch uint64 `json:"activation_epoch"`
+	ActiveEpochs    uint64 `json:"active_epochs"`
+}
+
 type validatorInfo struct {
 	Index                int    `json:"index"`
 	AttestationPubkeyHex string `json:"attestation_pubkey_hex"`
@@ -24,3 +49,22 @@ type nodeInfo struct {
 	KeyFile string `json:"key_file"`
 	PeerID  string `json:"peer_id"`
 }
+
+func (o options) nodeNames() []string {
+	if o.Layout != nil {
+		return o.Layout.nodeNames()
+	}
+	names := make([]string, o.Nodes)
+	for i := range names {
+		names[i] = fmt.Sprintf("node%d", i)
+	}
+	return names
+}
+
+func (o options) generation() generationInfo {
+	return generationInfo{
+		SeedFingerprint: seedFingerprint(o.Seed),
+		ActivationEpoch: o.ActivationEpoch,
+		ActiveEpochs:    o.ActiveEpochs,
+	}
+}
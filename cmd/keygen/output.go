@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/geanlabs/gean/internal/p2p"
 )
 
 func writeConfigYAML(outputDir string, genesisTime uint64, validators []validatorInfo) error {
@@ -33,16 +36,42 @@ func renderAnnotatedValidatorsYAML(validators []validatorInfo, numNodes int) str
 	if numNodes < 1 {
 		return ""
 	}
-	nodeValidators := make(map[int][]validatorInfo)
+	names := make([]string, numNodes)
+	groups := make([][]int, numNodes)
+	for i := range names {
+		names[i] = fmt.Sprintf("node%d", i)
+	}
 	for _, v := range validators {
 		nodeIdx := v.Index % numNodes
-		nodeValidators[nodeIdx] = append(nodeValidators[nodeIdx], v)
+		groups[nodeIdx] = append(groups[nodeIdx], v.Index)
+	}
+	return renderValidatorGroups(validators, names, groups)
+}
+
+// renderLayoutValidatorsYAML assigns validators to the layout's nodes by
+// name, as listed in each node's validator ranges.
+func renderLayoutValidatorsYAML(validators []validatorInfo, l *layout) string {
+	groups := make([][]int, len(l.Nodes))
+	for i, n := range l.Nodes {
+		groups[i] = n.indices
+	}
+	return renderValidatorGroups(validators, l.nodeNames(), groups)
+}
+
+func renderValidatorGroups(validators []validatorInfo, names []string, groups [][]int) string {
+	byIndex := make(map[int]validatorInfo, len(validators))
+	for _, v := range validators {
+		byIndex[v.Index] = v
 	}
 
 	var out strings.Builder
-	for i := range numNodes {
-		fmt.Fprintf(&out, "node%d:\n", i)
-		for _, v := range nodeValidators[i] {
+	for i, name := range names {
+		fmt.Fprintf(&out, "%s:\n", name)
+		for _, idx := range groups[i] {
+			v, ok := byIndex[idx]
+			if !ok {
+				continue
+			}
 			fmt.Fprintf(&out, "  - index: %d\n    attestation_pubkey_hex: %s\n    proposal_pubkey_hex: %s\n    attestation_sk_file: %s\n    proposal_sk_file: %s\n",
 				v.Index, v.AttestationPubkeyHex, v.ProposalPubkeyHex,
 				v.AttestationSkFile, v.ProposalSkFile)
@@ -60,6 +89,20 @@ func renderNodesYAML(nodes []nodeInfo, basePort int) string {
 	return out.String()
 }
 
+// renderNodeENRsYAML lists each layout node as an ENR built from its node
+// key, host and QUIC port, in the format lean-quickstart uses.
+func renderNodeENRsYAML(l *layout, nodeKeys [][]byte) (string, error) {
+	var out strings.Builder
+	for i, n := range l.Nodes {
+		record, err := p2p.EncodeENR(nodeKeys[i], net.ParseIP(n.Host), uint16(n.QUICPort), 1)
+		if err != nil {
+			return "", fmt.Errorf("encode ENR for %s: %w", n.Name, err)
+		}
+		fmt.Fprintf(&out, "- %s\n", record)
+	}
+	return out.String(), nil
+}
+
 func writeOutput(outputDir, name, data string) error {
 	path := filepath.Join(outputDir, name)
 	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
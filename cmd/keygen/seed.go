@@ -0,0 +1,79 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const minSeedBytes = 16
+
+// masterSeed returns the seed keys are derived from, or nil for random keys.
+// A mnemonic is stretched like a BIP-39 seed (the word list checksum is not
+// checked), so the same phrase always yields the same keys.
+func masterSeed(seedHex, mnemonic string) ([]byte, error) {
+	switch {
+	case seedHex != "" && mnemonic != "":
+		return nil, fmt.Errorf("%w: --seed and --mnemonic are mutually exclusive", errInvalidOptions)
+	case seedHex != "":
+		seed, err := hex.DecodeString(strings.TrimPrefix(seedHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: --seed is not hex: %v", errInvalidOptions, err)
+		}
+		if len(seed) < minSeedBytes {
+			return nil, fmt.Errorf("%w: --seed must be at least %d bytes", errInvalidOptions, minSeedBytes)
+		}
+		return seed, nil
+	case mnemonic != "":
+		words := strings.Fields(mnemonic)
+		if len(words) < 12 {
+			return nil, fmt.Errorf("%w: --mnemonic needs at least 12 words", errInvalidOptions)
+		}
+		return pbkdf2.Key(sha512.New, strings.Join(words, " "), []byte("mnemonic"), 2048, 64)
+	}
+	return nil, nil
+}
+
+// seedFingerprint identifies a master seed in manifest.json without
+// revealing it.
+func seedFingerprint(seed []byte) string {
+	if seed == nil {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte("gean-keygen-fingerprint:"), seed...))
+	return hex.EncodeToString(sum[:8])
+}
+
+func deriveBytes(seed []byte, path string) []byte {
+	mac := hmac.New(sha256.New, seed)
+	mac.Write([]byte(path))
+	return mac.Sum(nil)
+}
+
+// validatorKeySeed is the seed phrase handed to the hash-sig key generator.
+func validatorKeySeed(seed []byte, validatorIdx int, keyType string) (string, error) {
+	if seed == nil {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(buf), nil
+	}
+	return hex.EncodeToString(deriveBytes(seed, fmt.Sprintf("gean/validator/%d/%s", validatorIdx, keyType))), nil
+}
+
+func nodeKeyBytes(seed []byte, index int) ([]byte, error) {
+	if seed == nil {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+	return deriveBytes(seed, fmt.Sprintf("gean/node/%d", index)), nil
+}
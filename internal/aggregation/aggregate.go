@@ -8,6 +8,7 @@ import (
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/metrics"
 	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/tracing"
 	"github.com/geanlabs/gean/internal/types"
 	"github.com/geanlabs/gean/xmss"
 )
@@ -107,12 +108,17 @@ func aggregateFromSnapshot(snap *Snapshot, cache *xmss.PubKeyCache) ([]*types.Si
 
 			metrics.ObserveAggregationPrepTime(time.Since(prepStart).Seconds())
 
+			span := tracing.Start(tracing.TraceIDFromRoot(dataRoot), "aggregation.aggregate",
+				tracing.Uint("attestation.slot", attData.Slot),
+				tracing.Int("aggregation.raw", int64(len(*rawIDsBuf))),
+				tracing.Int("aggregation.children", int64(len(*childProofsBuf))))
 			aggStart := time.Now()
 			proofBytes, err := xmss.AggregateWithChildren(*rawPubkeysBuf, *rawSigsBuf, *childProofsBuf, dataRootHash, slot)
 			aggDuration := time.Since(aggStart)
 			if err != nil {
-				logger.Error(logger.Signature, "aggregate: failed slot=%d raw=%d children=%d duration=%v: %v",
-					slot, len(*rawIDsBuf), len(*childProofsBuf), aggDuration, err)
+				span.End(err)
+				logger.Error(logger.Signature, "aggregate: failed slot=%d raw=%d children=%d duration=%v: %v%s",
+					slot, len(*rawIDsBuf), len(*childProofsBuf), aggDuration, err, span.LogFields())
 				return
 			}
 
@@ -127,8 +133,10 @@ func aggregateFromSnapshot(snap *Snapshot, cache *xmss.PubKeyCache) ([]*types.Si
 				ProofData:    proofBytes,
 			}
 
-			logger.Info(logger.Signature, "aggregate: slot=%d raw=%d children=%d total=%d proof=%d bytes duration=%v",
-				slot, len(*rawIDsBuf), len(*childProofsBuf), len(allIDs), len(proofBytes), aggDuration)
+			span.SetAttrs(tracing.Int("aggregation.participants", int64(len(allIDs))))
+			span.End(nil)
+			logger.Info(logger.Signature, "aggregate: slot=%d raw=%d children=%d total=%d proof=%d bytes duration=%v%s",
+				slot, len(*rawIDsBuf), len(*childProofsBuf), len(allIDs), len(proofBytes), aggDuration, span.LogFields())
 
 			metrics.ObservePqSigAggBuildingTime(aggDuration.Seconds())
 			metrics.ObserveCommitteeSignaturesAggregationTime(aggDuration.Seconds())
@@ -35,17 +35,28 @@ func RunWorker(
 			if !ok {
 				return
 			}
-			if dispatch.Snapshot == nil {
-				continue
-			}
-
-			workerStart := time.Now()
-			aggs, payloads, deletes := aggregateFromSnapshot(dispatch.Snapshot, cache)
-			applyAggregationMutations(consensusStore, payloads, deletes)
-			publishAggregates(ctx, publisher, aggs)
-			metrics.ObserveAggregationWorkerTotalTime(time.Since(workerStart).Seconds())
-			logger.Info(logger.Signature, "aggregation worker: slot=%d produced=%d duration=%v",
-				dispatch.Slot, len(aggs), time.Since(workerStart))
+			Handle(ctx, dispatch, consensusStore, cache, publisher)
 		}
 	}
 }
+
+// Handle runs one aggregation cycle on the calling goroutine.
+func Handle(
+	ctx context.Context,
+	dispatch Dispatch,
+	consensusStore *store.ConsensusStore,
+	cache *xmss.PubKeyCache,
+	publisher Publisher,
+) {
+	if dispatch.Snapshot == nil {
+		return
+	}
+
+	workerStart := time.Now()
+	aggs, payloads, deletes := aggregateFromSnapshot(dispatch.Snapshot, cache)
+	applyAggregationMutations(consensusStore, payloads, deletes)
+	publishAggregates(ctx, publisher, aggs)
+	metrics.ObserveAggregationWorkerTotalTime(time.Since(workerStart).Seconds())
+	logger.Info(logger.Signature, "aggregation worker: slot=%d produced=%d duration=%v",
+		dispatch.Slot, len(aggs), time.Since(workerStart))
+}
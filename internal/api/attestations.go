@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/geanlabs/gean/internal/attestation"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type attestationDataJSON struct {
+	Slot   uint64             `json:"slot"`
+	Head   checkpointResponse `json:"head"`
+	Target checkpointResponse `json:"target"`
+	Source checkpointResponse `json:"source"`
+}
+
+type signedAttestationJSON struct {
+	ValidatorID uint64              `json:"validator_id"`
+	Data        attestationDataJSON `json:"data"`
+	Signature   string              `json:"signature"`
+}
+
+func AttestationDataHandler(s *store.ConsensusStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		slot, err := parseUintQuery(r, "slot", 0)
+		if err != nil || !r.URL.Query().Has("slot") {
+			http.Error(w, "missing or invalid slot", http.StatusBadRequest)
+			return
+		}
+		data := attestation.ProduceAttestationData(s, slot)
+		if data == nil {
+			http.Error(w, "attestation data not available", http.StatusServiceUnavailable)
+			return
+		}
+		if wantsJSON(r) {
+			writeJSON(w, http.StatusOK, attestationDataToJSON(data))
+			return
+		}
+		writeSSZ(w, data)
+	}
+}
+
+func SubmitAttestationHandler(v ValidatorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := readBody(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		att, err := decodeSignedAttestation(body, isJSONRequest(r))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("decode attestation: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), validatorRequestTimeout)
+		defer cancel()
+		if err := v.SubmitAttestation(ctx, att); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func decodeSignedAttestation(body []byte, isJSON bool) (*types.SignedAttestation, error) {
+	if !isJSON {
+		att := new(types.SignedAttestation)
+		if err := att.UnmarshalSSZ(body); err != nil {
+			return nil, err
+		}
+		return att, nil
+	}
+
+	var raw signedAttestationJSON
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	data, err := raw.Data.toAttestationData()
+	if err != nil {
+		return nil, err
+	}
+	att := &types.SignedAttestation{ValidatorID: raw.ValidatorID, Data: data}
+	if err := decodeHexFixed("signature", raw.Signature, att.Signature[:]); err != nil {
+		return nil, err
+	}
+	return att, nil
+}
+
+func attestationDataToJSON(data *types.AttestationData) attestationDataJSON {
+	return attestationDataJSON{
+		Slot:   data.Slot,
+		Head:   checkpointToJSON(data.Head),
+		Target: checkpointToJSON(data.Target),
+		Source: checkpointToJSON(data.Source),
+	}
+}
+
+func (j attestationDataJSON) toAttestationData() (*types.AttestationData, error) {
+	data := &types.AttestationData{Slot: j.Slot}
+	var err error
+	if data.Head, err = j.Head.toCheckpoint("head"); err != nil {
+		return nil, err
+	}
+	if data.Target, err = j.Target.toCheckpoint("target"); err != nil {
+		return nil, err
+	}
+	if data.Source, err = j.Source.toCheckpoint("source"); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func checkpointToJSON(cp *types.Checkpoint) checkpointResponse {
+	if cp == nil {
+		return checkpointResponse{Root: fmt.Sprintf("0x%x", types.ZeroRoot)}
+	}
+	return checkpointResponse{Slot: cp.Slot, Root: fmt.Sprintf("0x%x", cp.Root)}
+}
+
+func (c checkpointResponse) toCheckpoint(field string) (*types.Checkpoint, error) {
+	cp := &types.Checkpoint{Slot: c.Slot}
+	if err := decodeHexFixed(field+".root", c.Root, cp.Root[:]); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
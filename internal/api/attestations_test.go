@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAttestationDataHandlerJSON(t *testing.T) {
+	s := validatorTestStore(t, 3)
+
+	req := httptest.NewRequest(http.MethodGet, "/lean/v0/validator/attestation_data?slot=4", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	AttestationDataHandler(s)(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("content-type=%q, want application/json", ct)
+	}
+	var body attestationDataJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Slot != 4 || !strings.HasPrefix(body.Head.Root, "0x01") {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+}
+
+func TestSubmitAttestationHandlerJSON(t *testing.T) {
+	svc := &fakeValidatorService{}
+	root := fmt.Sprintf("0x%064x", 1)
+	sig := "0x" + strings.Repeat("ab", 2536)
+	payload := fmt.Sprintf(`{"validator_id":1,"data":{"slot":4,"head":{"slot":3,"root":%q},"target":{"slot":3,"root":%q},"source":{"slot":3,"root":%q}},"signature":%q}`,
+		root, root, root, sig)
+
+	req := httptest.NewRequest(http.MethodPost, "/lean/v0/validator/attestations", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	SubmitAttestationHandler(svc)(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if len(svc.attestations) != 1 {
+		t.Fatalf("attestations=%d, want 1", len(svc.attestations))
+	}
+	att := svc.attestations[0]
+	if att.ValidatorID != 1 || att.Data.Head.Slot != 3 || att.Data.Head.Root[31] != 1 || att.Signature[0] != 0xab {
+		t.Fatalf("unexpected attestation: validator=%d head=%+v", att.ValidatorID, att.Data.Head)
+	}
+
+	short := strings.Replace(payload, sig, "0xabcd", 1)
+	req = httptest.NewRequest(http.MethodPost, "/lean/v0/validator/attestations", bytes.NewReader([]byte(short)))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	rec = httptest.NewRecorder()
+	SubmitAttestationHandler(svc)(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("short signature status=%d, want 400", rec.Code)
+	}
+}
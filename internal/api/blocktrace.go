@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geanlabs/gean/internal/blocktrace"
+)
+
+// BlockTraceSource returns the recorded import timeline of a recent block.
+type BlockTraceSource interface {
+	Timeline(root [32]byte) (blocktrace.Timeline, bool)
+}
+
+type blockTimelineResponse struct {
+	Root           string               `json:"root"`
+	Slot           uint64               `json:"slot"`
+	ProposerIndex  uint64               `json:"proposer_index"`
+	Local          bool                 `json:"local"`
+	Outcome        string               `json:"outcome"`
+	Error          string               `json:"error,omitempty"`
+	SlotStartMs    int64                `json:"slot_start_ms"`
+	ReceivedAtMs   int64                `json:"received_at_ms,omitempty"`
+	ReceiveDelayMs *int64               `json:"receive_delay_ms,omitempty"`
+	Stages         []blockTimelineStage `json:"stages"`
+}
+
+// SlotOffsetMs is the stage start relative to the start of the block's slot.
+type blockTimelineStage struct {
+	Name         string  `json:"name"`
+	StartMs      int64   `json:"start_ms"`
+	SlotOffsetMs int64   `json:"slot_offset_ms"`
+	DurationMs   float64 `json:"duration_ms"`
+}
+
+func BlockTimelineHandler(src BlockTraceSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var root [32]byte
+		if err := decodeHexFixed("root", r.PathValue("root"), root[:]); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tl, ok := src.Timeline(root)
+		if !ok {
+			http.Error(w, "no timeline recorded for block", http.StatusNotFound)
+			return
+		}
+
+		resp := blockTimelineResponse{
+			Root:          fmt.Sprintf("0x%x", tl.Root),
+			Slot:          tl.Slot,
+			ProposerIndex: tl.Proposer,
+			Local:         tl.Local,
+			Outcome:       tl.Outcome,
+			Error:         tl.Error,
+			SlotStartMs:   tl.SlotStart.UnixMilli(),
+			Stages:        make([]blockTimelineStage, 0, len(tl.Stages)),
+		}
+		if !tl.ReceivedAt.IsZero() {
+			resp.ReceivedAtMs = tl.ReceivedAt.UnixMilli()
+			delay := tl.ReceivedAt.Sub(tl.SlotStart).Milliseconds()
+			resp.ReceiveDelayMs = &delay
+		}
+		for _, st := range tl.Stages {
+			resp.Stages = append(resp.Stages, blockTimelineStage{
+				Name:         st.Name,
+				StartMs:      st.Start.UnixMilli(),
+				SlotOffsetMs: st.Start.Sub(tl.SlotStart).Milliseconds(),
+				DurationMs:   float64(st.Duration) / float64(time.Millisecond),
+			})
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/blocktrace"
+	"github.com/geanlabs/gean/internal/role"
+)
+
+type fakeBlockTrace map[[32]byte]blocktrace.Timeline
+
+func (f fakeBlockTrace) Timeline(root [32]byte) (blocktrace.Timeline, bool) {
+	tl, ok := f[root]
+	return tl, ok
+}
+
+func TestBlockTimelineRoute(t *testing.T) {
+	root := [32]byte{7}
+	slotStart := time.UnixMilli(4000)
+	traces := fakeBlockTrace{root: {
+		Root:       root,
+		Slot:       1,
+		SlotStart:  slotStart,
+		ReceivedAt: slotStart.Add(1200 * time.Millisecond),
+		Outcome:    blocktrace.OutcomeImported,
+		Stages: []blocktrace.Stage{
+			{Name: blocktrace.StageQueue, Start: slotStart.Add(1200 * time.Millisecond), Duration: 1500 * time.Microsecond},
+		},
+	}}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), BlockTrace: traces})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/lean/v0/debug/blocks/0x%x/timeline", root), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d: %s", rec.Code, rec.Body.String())
+	}
+	var body blockTimelineResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.ReceiveDelayMs == nil || *body.ReceiveDelayMs != 1200 || body.Outcome != "imported" {
+		t.Fatalf("body=%+v", body)
+	}
+	if len(body.Stages) != 1 || body.Stages[0].SlotOffsetMs != 1200 || body.Stages[0].DurationMs != 1.5 {
+		t.Fatalf("stages=%+v", body.Stages)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/lean/v0/debug/blocks/0x%x/timeline", [32]byte{8}), nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown root status=%d, want 404", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/debug/blocks/abc/timeline", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad root status=%d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/geanlabs/gean/internal/p2p"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+const (
+	maxDutySlots      = 1024
+	maxDutyValidators = types.ValidatorRegistryLimit
+)
+
+type proposerDuty struct {
+	Slot           uint64 `json:"slot"`
+	ValidatorIndex uint64 `json:"validator_index"`
+}
+
+type proposerDutiesResponse struct {
+	NumValidators uint64         `json:"num_validators"`
+	Duties        []proposerDuty `json:"duties"`
+}
+
+type attesterDuty struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	SubnetID       uint64 `json:"subnet_id"`
+}
+
+type attesterDutiesResponse struct {
+	NumValidators  uint64         `json:"num_validators"`
+	CommitteeCount uint64         `json:"committee_count"`
+	Duties         []attesterDuty `json:"duties"`
+}
+
+func ProposerDutiesHandler(s *store.ConsensusStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fromSlot, err := parseUintQuery(r, "from_slot", s.HeadSlot()+1)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		count, err := parseUintQuery(r, "count", 1)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if count == 0 || count > maxDutySlots {
+			http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxDutySlots), http.StatusBadRequest)
+			return
+		}
+		if fromSlot > ^uint64(0)-count {
+			http.Error(w, "from_slot + count overflows", http.StatusBadRequest)
+			return
+		}
+
+		numValidators, ok := headValidatorCount(s)
+		if !ok {
+			http.Error(w, "head state not available", http.StatusServiceUnavailable)
+			return
+		}
+		filter, err := parseValidatorFilter(r.URL.Query().Get("validators"), numValidators)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		duties := make([]proposerDuty, 0, count)
+		for slot := fromSlot; slot < fromSlot+count; slot++ {
+			proposer := types.ProposerIndex(slot, numValidators)
+			if filter != nil && !filter[proposer] {
+				continue
+			}
+			duties = append(duties, proposerDuty{Slot: slot, ValidatorIndex: proposer})
+		}
+		writeJSON(w, http.StatusOK, proposerDutiesResponse{NumValidators: numValidators, Duties: duties})
+	}
+}
+
+// AttesterDutiesHandler reports subnet assignments. Every active validator
+// attests once per slot, so the subnet is the only per-validator duty.
+func AttesterDutiesHandler(s *store.ConsensusStore, committeeCount uint64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raw := r.URL.Query().Get("validators")
+		if raw == "" {
+			http.Error(w, "missing validators", http.StatusBadRequest)
+			return
+		}
+		numValidators, ok := headValidatorCount(s)
+		if !ok {
+			http.Error(w, "head state not available", http.StatusServiceUnavailable)
+			return
+		}
+		ids, err := parseValidatorIndices(raw, numValidators)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		duties := make([]attesterDuty, 0, len(ids))
+		for _, vid := range ids {
+			duties = append(duties, attesterDuty{ValidatorIndex: vid, SubnetID: p2p.SubnetID(vid, committeeCount)})
+		}
+		writeJSON(w, http.StatusOK, attesterDutiesResponse{
+			NumValidators:  numValidators,
+			CommitteeCount: committeeCount,
+			Duties:         duties,
+		})
+	}
+}
+
+func headValidatorCount(s *store.ConsensusStore) (uint64, bool) {
+	headState := s.GetState(s.Head())
+	if headState == nil || headState.NumValidators() == 0 {
+		return 0, false
+	}
+	return headState.NumValidators(), true
+}
+
+func parseValidatorFilter(raw string, numValidators uint64) (map[uint64]bool, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	ids, err := parseValidatorIndices(raw, numValidators)
+	if err != nil {
+		return nil, err
+	}
+	filter := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		filter[id] = true
+	}
+	return filter, nil
+}
+
+func parseValidatorIndices(raw string, numValidators uint64) ([]uint64, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxDutyValidators {
+		return nil, fmt.Errorf("too many validators, max %d", maxDutyValidators)
+	}
+	seen := make(map[uint64]bool, len(parts))
+	ids := make([]uint64, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator index %q", part)
+		}
+		if id >= numValidators {
+			return nil, fmt.Errorf("validator %d out of range (registry size %d)", id, numValidators)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no validator indices given")
+	}
+	return ids, nil
+}
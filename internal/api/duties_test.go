@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProposerDutiesHandler(t *testing.T) {
+	s := validatorTestStore(t, 3)
+
+	rec := httptest.NewRecorder()
+	ProposerDutiesHandler(s)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/duties/proposer?from_slot=4&count=4", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var body proposerDutiesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.NumValidators != 3 || len(body.Duties) != 4 {
+		t.Fatalf("got num_validators=%d duties=%d, want 3/4", body.NumValidators, len(body.Duties))
+	}
+	for _, duty := range body.Duties {
+		if duty.ValidatorIndex != duty.Slot%3 {
+			t.Fatalf("slot %d proposer=%d, want %d", duty.Slot, duty.ValidatorIndex, duty.Slot%3)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	ProposerDutiesHandler(s)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/duties/proposer?count=5000", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("oversized count status=%d, want 400", rec.Code)
+	}
+}
+
+func TestProposerDutiesHandlerFiltersValidators(t *testing.T) {
+	s := validatorTestStore(t, 3)
+
+	rec := httptest.NewRecorder()
+	ProposerDutiesHandler(s)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/duties/proposer?from_slot=0&count=9&validators=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var body proposerDutiesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if len(body.Duties) != 3 {
+		t.Fatalf("duties=%+v, want slots 1,4,7", body.Duties)
+	}
+	for i, want := range []uint64{1, 4, 7} {
+		if body.Duties[i].Slot != want || body.Duties[i].ValidatorIndex != 1 {
+			t.Fatalf("duty %d=%+v, want slot %d validator 1", i, body.Duties[i], want)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	ProposerDutiesHandler(s)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/duties/proposer?validators=3", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("out of range validator status=%d, want 400", rec.Code)
+	}
+}
+
+func TestAttesterDutiesHandler(t *testing.T) {
+	s := validatorTestStore(t, 5)
+
+	rec := httptest.NewRecorder()
+	AttesterDutiesHandler(s, 2)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/duties/attester?validators=4,1,4", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var body attesterDutiesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.NumValidators != 5 || body.CommitteeCount != 2 || len(body.Duties) != 2 {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+	if body.Duties[0] != (attesterDuty{ValidatorIndex: 4, SubnetID: 0}) || body.Duties[1] != (attesterDuty{ValidatorIndex: 1, SubnetID: 1}) {
+		t.Fatalf("duties=%+v", body.Duties)
+	}
+
+	for _, query := range []string{"", "?validators=", "?validators=x", "?validators=5"} {
+		rec = httptest.NewRecorder()
+		AttesterDutiesHandler(s, 2)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/duties/attester"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("query %q status=%d, want 400", query, rec.Code)
+		}
+	}
+}
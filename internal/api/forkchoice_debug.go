@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/geanlabs/gean/internal/forkchoice"
+)
+
+// ForkChoiceDebugSource snapshots the fork choice with its vote store.
+type ForkChoiceDebugSource interface {
+	ForkChoiceDebug(ctx context.Context) (forkchoice.Debug, error)
+}
+
+type forkChoiceDebugResponse struct {
+	Head          string                `json:"head"`
+	SafeTarget    string                `json:"safe_target"`
+	Justified     checkpointResponse    `json:"justified"`
+	Finalized     checkpointResponse    `json:"finalized"`
+	NumValidators uint64                `json:"validator_count"`
+	QuorumScore   int64                 `json:"quorum_score"`
+	Nodes         []forkChoiceDebugNode `json:"nodes"`
+	Votes         []forkChoiceDebugVote `json:"votes"`
+}
+
+type forkChoiceDebugNode struct {
+	Index              int    `json:"index"`
+	Root               string `json:"root"`
+	Slot               uint64 `json:"slot"`
+	ParentRoot         string `json:"parent_root"`
+	Weight             int64  `json:"weight"`
+	BestChild          string `json:"best_child,omitempty"`
+	BestDescendant     string `json:"best_descendant,omitempty"`
+	SafeWeight         int64  `json:"safe_weight"`
+	SafeBestChild      string `json:"safe_best_child,omitempty"`
+	SafeBestDescendant string `json:"safe_best_descendant,omitempty"`
+	KnownVotes         int    `json:"known_votes"`
+	NewVotes           int    `json:"new_votes"`
+	Justified          bool   `json:"justified"`
+	Finalized          bool   `json:"finalized"`
+}
+
+type forkChoiceDebugVote struct {
+	ValidatorIndex uint64               `json:"validator_index"`
+	Known          *forkChoiceDebugHead `json:"known"`
+	New            *forkChoiceDebugHead `json:"new"`
+}
+
+type forkChoiceDebugHead struct {
+	Root string `json:"root"`
+	Slot uint64 `json:"slot"`
+}
+
+// ForkChoiceDebugHandler serves the snapshot as JSON, or as Graphviz DOT with
+// ?format=dot.
+func ForkChoiceDebugHandler(src ForkChoiceDebugSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "dot" {
+			http.Error(w, `format must be "json" or "dot"`, http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), validatorRequestTimeout)
+		defer cancel()
+		d, err := src.ForkChoiceDebug(ctx)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			d.WriteDOT(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, toForkChoiceDebugResponse(d))
+	}
+}
+
+func toForkChoiceDebugResponse(d forkchoice.Debug) forkChoiceDebugResponse {
+	nodeRoot := func(idx int) string {
+		if idx < 0 || idx >= len(d.Nodes) {
+			return ""
+		}
+		return fmt.Sprintf("0x%x", d.Nodes[idx].Root)
+	}
+
+	resp := forkChoiceDebugResponse{
+		Head:          fmt.Sprintf("0x%x", d.Head),
+		SafeTarget:    fmt.Sprintf("0x%x", d.SafeTarget),
+		Justified:     checkpointResponse{Slot: d.Justified.Slot, Root: fmt.Sprintf("0x%x", d.Justified.Root)},
+		Finalized:     checkpointResponse{Slot: d.Finalized.Slot, Root: fmt.Sprintf("0x%x", d.Finalized.Root)},
+		NumValidators: d.NumValidators,
+		QuorumScore:   d.QuorumScore,
+		Nodes:         make([]forkChoiceDebugNode, 0, len(d.Nodes)),
+		Votes:         make([]forkChoiceDebugVote, 0, len(d.Votes)),
+	}
+	for _, n := range d.Nodes {
+		resp.Nodes = append(resp.Nodes, forkChoiceDebugNode{
+			Index:              n.Index,
+			Root:               fmt.Sprintf("0x%x", n.Root),
+			Slot:               n.Slot,
+			ParentRoot:         fmt.Sprintf("0x%x", n.ParentRoot),
+			Weight:             n.Weight,
+			BestChild:          nodeRoot(n.BestChild),
+			BestDescendant:     nodeRoot(n.BestDescendant),
+			SafeWeight:         n.SafeWeight,
+			SafeBestChild:      nodeRoot(n.SafeBestChild),
+			SafeBestDescendant: nodeRoot(n.SafeBestDescendant),
+			KnownVotes:         n.KnownVotes,
+			NewVotes:           n.NewVotes,
+			Justified:          n.Root == d.Justified.Root,
+			Finalized:          n.Root == d.Finalized.Root,
+		})
+	}
+	for _, v := range d.Votes {
+		vote := forkChoiceDebugVote{ValidatorIndex: v.ValidatorID}
+		if v.Known != nil {
+			vote.Known = &forkChoiceDebugHead{Root: nodeRoot(v.Known.Index), Slot: v.Known.Slot}
+		}
+		if v.New != nil {
+			vote.New = &forkChoiceDebugHead{Root: nodeRoot(v.New.Index), Slot: v.New.Slot}
+		}
+		resp.Votes = append(resp.Votes, vote)
+	}
+	return resp
+}
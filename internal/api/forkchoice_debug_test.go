@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/forkchoice"
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type fakeForkChoiceDebug struct{ fc *forkchoice.ForkChoice }
+
+func (f fakeForkChoiceDebug) ForkChoiceDebug(context.Context) (forkchoice.Debug, error) {
+	anchor := types.Checkpoint{Root: [32]byte{1}}
+	return f.fc.Debug(anchor, anchor, 3), nil
+}
+
+func TestForkChoiceDebugRoute(t *testing.T) {
+	rootA, rootB := [32]byte{1}, [32]byte{2}
+	fc := forkchoice.New(0, rootA, [32]byte{})
+	fc.OnBlock(1, rootB, rootA)
+	fc.SetKnownVote(4, rootB, 1, &types.AttestationData{Slot: 1, Head: &types.Checkpoint{Root: rootB, Slot: 1}})
+
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), Debug: fakeForkChoiceDebug{fc}})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/debug/fork_choice", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d: %s", rec.Code, rec.Body.String())
+	}
+	var body forkChoiceDebugResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Nodes) != 2 || !body.Nodes[0].Justified || !body.Nodes[0].Finalized {
+		t.Fatalf("nodes=%+v", body.Nodes)
+	}
+	if body.Nodes[0].BestChild != body.Nodes[1].Root || body.Nodes[1].Weight != 1 || body.QuorumScore != 2 {
+		t.Fatalf("body=%+v", body)
+	}
+	if len(body.Votes) != 1 || body.Votes[0].ValidatorIndex != 4 || body.Votes[0].Known.Root != body.Nodes[1].Root || body.Votes[0].New != nil {
+		t.Fatalf("votes=%+v", body.Votes)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/debug/fork_choice?format=dot", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "digraph forkchoice {") {
+		t.Fatalf("dot status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/debug/fork_choice?format=svg", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad format status=%d, want 400", rec.Code)
+	}
+}
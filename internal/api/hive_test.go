@@ -15,7 +15,7 @@ import (
 
 func TestBuildAPIMuxWithTestDriverRegistersRoutes(t *testing.T) {
 	s := store.NewConsensusStore(storage.NewInMemoryBackend())
-	mux := buildAPIMuxWithTestDriver(s, nil, role.New(false))
+	mux := buildAPIMuxWithTestDriver(Services{Store: s, Aggregator: role.New(false)})
 
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/health", nil))
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
+	"strings"
 )
 
 func writeJSON(w http.ResponseWriter, status int, v any) {
@@ -16,3 +19,33 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.WriteHeader(status)
 	w.Write(data)
 }
+
+func isJSONRequest(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/json"
+}
+
+func wantsJSON(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := mime.ParseMediaType(strings.TrimSpace(accept))
+		if mediaType == "application/json" {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeHexFixed(field, s string, out []byte) error {
+	if !strings.HasPrefix(s, "0x") {
+		return fmt.Errorf("%s: missing 0x prefix", field)
+	}
+	raw, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	if len(raw) != len(out) {
+		return fmt.Errorf("%s: got %d bytes, want %d", field, len(raw), len(out))
+	}
+	copy(out, raw)
+	return nil
+}
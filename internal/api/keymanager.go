@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/geanlabs/gean/xmss"
+)
+
+const maxKeyImportBatch = 64
+
+// KeyManagerService adds and removes validator keys on a running node.
+type KeyManagerService interface {
+	ValidatorIDs() []uint64
+	ImportValidatorKeys(ctx context.Context, keys *xmss.ValidatorKeys, history []xmss.SigningRecord) error
+	RemoveValidatorKeys(ctx context.Context, validatorIndex uint64) ([]xmss.SigningRecord, bool, error)
+}
+
+// loadKeystoreKeys is swapped out in tests to avoid the hash-sig FFI.
+var loadKeystoreKeys = xmss.LoadKeystoreKeys
+
+const (
+	keyStatusImported  = "imported"
+	keyStatusDuplicate = "duplicate"
+	keyStatusDeleted   = "deleted"
+	keyStatusNotFound  = "not_found"
+	keyStatusError     = "error"
+)
+
+type listKeysResponse struct {
+	Data []listedKeyJSON `json:"data"`
+}
+
+type listedKeyJSON struct {
+	ValidatorIndex uint64             `json:"validator_index"`
+	Roles          []xmss.SigningRole `json:"roles"`
+}
+
+type importKeysRequest struct {
+	Keys           []importKeyJSON      `json:"keys"`
+	SigningHistory []xmss.SigningRecord `json:"signing_history"`
+}
+
+type importKeyJSON struct {
+	ValidatorIndex      uint64 `json:"validator_index"`
+	AttestationKeystore string `json:"attestation_keystore"`
+	ProposalKeystore    string `json:"proposal_keystore"`
+	Password            string `json:"password"`
+}
+
+type keyStatusJSON struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	Status         string `json:"status"`
+	Message        string `json:"message,omitempty"`
+}
+
+type keyStatusResponse struct {
+	Data []keyStatusJSON `json:"data"`
+}
+
+type deleteKeysRequest struct {
+	ValidatorIndices []uint64 `json:"validator_indices"`
+}
+
+type deleteKeysResponse struct {
+	Data           []keyStatusJSON      `json:"data"`
+	SigningHistory []xmss.SigningRecord `json:"signing_history"`
+}
+
+// RequireBearerToken rejects requests whose Authorization header does not
+// carry token as a bearer credential.
+func RequireBearerToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func ListKeysHandler(km KeyManagerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids := km.ValidatorIDs()
+		resp := listKeysResponse{Data: make([]listedKeyJSON, 0, len(ids))}
+		for _, vid := range ids {
+			resp.Data = append(resp.Data, listedKeyJSON{
+				ValidatorIndex: vid,
+				Roles:          []xmss.SigningRole{xmss.RoleAttestation, xmss.RoleProposal},
+			})
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func ImportKeysHandler(km KeyManagerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body importKeysRequest
+		if err := decodeJSONBody(r, &body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body.Keys) == 0 || len(body.Keys) > maxKeyImportBatch {
+			http.Error(w, "keys must list between 1 and 64 validators", http.StatusBadRequest)
+			return
+		}
+
+		resp := keyStatusResponse{Data: make([]keyStatusJSON, 0, len(body.Keys))}
+		for _, key := range body.Keys {
+			resp.Data = append(resp.Data, importKey(r.Context(), km, key, historyFor(body.SigningHistory, key.ValidatorIndex)))
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func importKey(ctx context.Context, km KeyManagerService, key importKeyJSON, history []xmss.SigningRecord) keyStatusJSON {
+	status := keyStatusJSON{ValidatorIndex: key.ValidatorIndex}
+	if slices.Contains(km.ValidatorIDs(), key.ValidatorIndex) {
+		status.Status = keyStatusDuplicate
+		return status
+	}
+
+	keys, err := loadKeystoreKeys(key.ValidatorIndex, []byte(key.AttestationKeystore), []byte(key.ProposalKeystore), key.Password)
+	if err != nil {
+		status.Status, status.Message = keyStatusError, err.Error()
+		return status
+	}
+	ctx, cancel := context.WithTimeout(ctx, validatorRequestTimeout)
+	defer cancel()
+	err = km.ImportValidatorKeys(ctx, keys, history)
+	switch {
+	case err == nil:
+		status.Status = keyStatusImported
+	case errors.Is(err, xmss.ErrValidatorKeysExist):
+		keys.Close()
+		status.Status = keyStatusDuplicate
+	default:
+		keys.Close()
+		status.Status, status.Message = keyStatusError, err.Error()
+	}
+	return status
+}
+
+func historyFor(records []xmss.SigningRecord, validatorIndex uint64) []xmss.SigningRecord {
+	var out []xmss.SigningRecord
+	for _, rec := range records {
+		if rec.ValidatorIndex == validatorIndex {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+func DeleteKeysHandler(km KeyManagerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body deleteKeysRequest
+		if err := decodeJSONBody(r, &body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body.ValidatorIndices) == 0 {
+			http.Error(w, `missing required field "validator_indices"`, http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), validatorRequestTimeout)
+		defer cancel()
+		resp := deleteKeysResponse{
+			Data:           make([]keyStatusJSON, 0, len(body.ValidatorIndices)),
+			SigningHistory: []xmss.SigningRecord{},
+		}
+		for _, vid := range body.ValidatorIndices {
+			records, removed, err := km.RemoveValidatorKeys(ctx, vid)
+			if err != nil {
+				writeServiceError(w, err)
+				return
+			}
+			status := keyStatusJSON{ValidatorIndex: vid, Status: keyStatusNotFound}
+			if removed {
+				status.Status = keyStatusDeleted
+				resp.SigningHistory = append(resp.SigningHistory, records...)
+			}
+			resp.Data = append(resp.Data, status)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func decodeJSONBody(r *http.Request, v any) error {
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.New("invalid json body")
+	}
+	return nil
+}
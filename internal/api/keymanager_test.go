@@ -0,0 +1,159 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/xmss"
+)
+
+type fakeKeyManager struct {
+	ids       []uint64
+	histories map[uint64][]xmss.SigningRecord
+	importErr error
+}
+
+func (f *fakeKeyManager) ValidatorIDs() []uint64 { return f.ids }
+
+func (f *fakeKeyManager) ImportValidatorKeys(ctx context.Context, keys *xmss.ValidatorKeys, history []xmss.SigningRecord) error {
+	if f.importErr != nil {
+		return f.importErr
+	}
+	f.ids = append(f.ids, keys.ValidatorIndex)
+	f.histories[keys.ValidatorIndex] = history
+	return nil
+}
+
+func (f *fakeKeyManager) RemoveValidatorKeys(ctx context.Context, validatorIndex uint64) ([]xmss.SigningRecord, bool, error) {
+	i := slices.Index(f.ids, validatorIndex)
+	if i < 0 {
+		return nil, false, nil
+	}
+	f.ids = slices.Delete(f.ids, i, i+1)
+	return f.histories[validatorIndex], true, nil
+}
+
+func stubKeystoreLoader(t *testing.T) {
+	t.Helper()
+	orig := loadKeystoreKeys
+	loadKeystoreKeys = func(index uint64, att, prop []byte, password string) (*xmss.ValidatorKeys, error) {
+		if password != "secret" {
+			return nil, errors.New("invalid password")
+		}
+		return &xmss.ValidatorKeys{ValidatorIndex: index}, nil
+	}
+	t.Cleanup(func() { loadKeystoreKeys = orig })
+}
+
+func TestKeyManagerRoutes(t *testing.T) {
+	stubKeystoreLoader(t)
+	km := &fakeKeyManager{ids: []uint64{0}, histories: map[uint64][]xmss.SigningRecord{}}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 4), Aggregator: role.New(false), KeyManager: km, AdminToken: "admin-token"})
+	do := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/lean/v0/admin/keys", bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	imported := do(http.MethodPost, `{
+		"keys": [
+			{"validator_index": 0, "attestation_keystore": "{}", "proposal_keystore": "{}", "password": "secret"},
+			{"validator_index": 2, "attestation_keystore": "{}", "proposal_keystore": "{}", "password": "secret"},
+			{"validator_index": 3, "attestation_keystore": "{}", "proposal_keystore": "{}", "password": "wrong"}
+		],
+		"signing_history": [
+			{"validator_index": 2, "role": "attestation", "slot": 9, "message_root": "0x00"},
+			{"validator_index": 7, "role": "attestation", "slot": 1, "message_root": "0x00"}
+		]
+	}`)
+	if imported.Code != http.StatusOK {
+		t.Fatalf("import status=%d: %s", imported.Code, imported.Body.String())
+	}
+	var importResp keyStatusResponse
+	if err := json.Unmarshal(imported.Body.Bytes(), &importResp); err != nil {
+		t.Fatalf("decode import: %v", err)
+	}
+	statuses := []string{importResp.Data[0].Status, importResp.Data[1].Status, importResp.Data[2].Status}
+	if !slices.Equal(statuses, []string{keyStatusDuplicate, keyStatusImported, keyStatusError}) {
+		t.Fatalf("statuses=%v", statuses)
+	}
+	if len(km.histories[2]) != 1 || km.histories[2][0].Slot != 9 {
+		t.Fatalf("history for validator 2=%+v", km.histories[2])
+	}
+
+	listed := do(http.MethodGet, "")
+	var listResp listKeysResponse
+	if err := json.Unmarshal(listed.Body.Bytes(), &listResp); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(listResp.Data) != 2 || listResp.Data[1].ValidatorIndex != 2 {
+		t.Fatalf("listed=%+v, want validators 0 and 2", listResp.Data)
+	}
+
+	deleted := do(http.MethodDelete, `{"validator_indices": [2, 5]}`)
+	var deleteResp deleteKeysResponse
+	if err := json.Unmarshal(deleted.Body.Bytes(), &deleteResp); err != nil {
+		t.Fatalf("decode delete: %v", err)
+	}
+	if deleteResp.Data[0].Status != keyStatusDeleted || deleteResp.Data[1].Status != keyStatusNotFound {
+		t.Fatalf("delete statuses=%+v", deleteResp.Data)
+	}
+	if len(deleteResp.SigningHistory) != 1 || deleteResp.SigningHistory[0].ValidatorIndex != 2 {
+		t.Fatalf("exported history=%+v", deleteResp.SigningHistory)
+	}
+
+	if rec := do(http.MethodPost, `{"keys": []}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("empty import status=%d, want 400", rec.Code)
+	}
+	if rec := do(http.MethodDelete, `not json`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad delete status=%d, want 400", rec.Code)
+	}
+}
+
+func TestKeyManagerRoutesRequireService(t *testing.T) {
+	km := &fakeKeyManager{histories: map[uint64][]xmss.SigningRecord{}}
+	for name, svc := range map[string]Services{
+		"no key manager": {AdminToken: "admin-token"},
+		"no admin token": {KeyManager: km},
+	} {
+		svc.Store, svc.Aggregator = validatorTestStore(t, 1), role.New(false)
+		rec := httptest.NewRecorder()
+		buildAPIMux(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/admin/keys", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s: status=%d, want 404", name, rec.Code)
+		}
+	}
+}
+
+func TestKeyManagerRoutesRequireBearerToken(t *testing.T) {
+	stubKeystoreLoader(t)
+	km := &fakeKeyManager{histories: map[uint64][]xmss.SigningRecord{}}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 4), Aggregator: role.New(false), KeyManager: km, AdminToken: "admin-token"})
+	body := `{"keys": [{"validator_index": 1, "attestation_keystore": "{}", "proposal_keystore": "{}", "password": "secret"}]}`
+
+	for _, auth := range []string{"", "admin-token", "Bearer wrong", "Basic admin-token"} {
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+			req := httptest.NewRequest(method, "/lean/v0/admin/keys", bytes.NewBufferString(body))
+			if auth != "" {
+				req.Header.Set("Authorization", auth)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("%s with Authorization %q: status=%d, want 401", method, auth, rec.Code)
+			}
+		}
+	}
+	if len(km.ids) != 0 {
+		t.Fatalf("unauthorised import added keys %v", km.ids)
+	}
+}
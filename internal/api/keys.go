@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/xmss"
+)
+
+// KeySource reports the active ranges of the node's validator keys.
+type KeySource interface {
+	Lifetimes() ([]xmss.KeyLifetime, error)
+}
+
+type keyLifetimeJSON struct {
+	ValidatorIndex  uint64           `json:"validator_index"`
+	Role            xmss.SigningRole `json:"role"`
+	ActivationSlot  uint64           `json:"activation_slot"`
+	EndSlot         uint64           `json:"end_slot"`
+	RemainingEpochs uint64           `json:"remaining_epochs"`
+	Expired         bool             `json:"expired"`
+}
+
+type keyLifetimesResponse struct {
+	CurrentSlot uint64            `json:"current_slot"`
+	Keys        []keyLifetimeJSON `json:"keys"`
+	Errors      []string          `json:"errors,omitempty"`
+}
+
+func KeyLifetimesHandler(s *store.ConsensusStore, keys KeySource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		slot := s.Spec().CurrentSlot(s.Config().GenesisTime, uint64(time.Now().UnixMilli()))
+		lifetimes, err := keys.Lifetimes()
+
+		resp := keyLifetimesResponse{CurrentSlot: slot, Keys: make([]keyLifetimeJSON, 0, len(lifetimes))}
+		for _, lt := range lifetimes {
+			resp.Keys = append(resp.Keys, keyLifetimeJSON{
+				ValidatorIndex:  lt.ValidatorIndex,
+				Role:            lt.Role,
+				ActivationSlot:  lt.Start,
+				EndSlot:         lt.End,
+				RemainingEpochs: lt.Remaining(slot),
+				Expired:         lt.Expired(slot),
+			})
+		}
+		if err != nil {
+			resp.Errors = append(resp.Errors, err.Error())
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+type fakeKeySource struct {
+	lifetimes []xmss.KeyLifetime
+	err       error
+}
+
+func (f fakeKeySource) Lifetimes() ([]xmss.KeyLifetime, error) { return f.lifetimes, f.err }
+
+func TestKeyLifetimesRoute(t *testing.T) {
+	s := validatorTestStore(t, 2)
+	genesis := uint64(time.Now().Unix()) - 10*types.SecondsPerSlot - 1
+	s.SetConfig(&types.ChainConfig{GenesisTime: genesis})
+	keys := fakeKeySource{
+		lifetimes: []xmss.KeyLifetime{
+			{ValidatorIndex: 0, Role: xmss.RoleAttestation, ActiveRange: xmss.ActiveRange{Start: 0, End: 25}},
+			{ValidatorIndex: 1, Role: xmss.RoleProposal, ActiveRange: xmss.ActiveRange{Start: 0, End: 8}},
+		},
+		err: errors.New("validator 2 attestation key: closed"),
+	}
+	mux := buildAPIMux(Services{Store: s, Aggregator: role.New(false), Keys: keys})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/keys", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var body keyLifetimesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.CurrentSlot != 10 || len(body.Keys) != 2 || len(body.Errors) != 1 {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+	if body.Keys[0].RemainingEpochs != 15 || body.Keys[0].Expired {
+		t.Fatalf("key 0=%+v, want 15 remaining", body.Keys[0])
+	}
+	if body.Keys[1].RemainingEpochs != 0 || !body.Keys[1].Expired {
+		t.Fatalf("key 1=%+v, want expired", body.Keys[1])
+	}
+}
@@ -1,16 +1,13 @@
 package api
 
 import (
-	"fmt"
-	"net"
 	"net/http"
 	"net/http/pprof"
 
-	"github.com/geanlabs/gean/internal/logger"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func StartMetricsServer(address string) error {
+func NewMetricsServer(address string) *http.Server {
 	mux := http.NewServeMux()
 	mux.Handle("GET /metrics", promhttp.Handler())
 
@@ -20,11 +17,5 @@ func StartMetricsServer(address string) error {
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 
-	listener, err := net.Listen("tcp", address)
-	if err != nil {
-		return fmt.Errorf("metrics listen: %w", err)
-	}
-
-	logger.Info(logger.Network, "metrics server listening on %s", address)
-	return http.Serve(listener, mux)
+	return &http.Server{Addr: address, Handler: mux}
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geanlabs/gean/internal/syncer"
+)
+
+// SyncSource reports the node's current sync position.
+type SyncSource interface {
+	SyncState() syncer.State
+}
+
+// HealthConfig decides how /lean/v0/node/health maps sync state to a status
+// code. A node is not ready (503) without a head state, with fewer than
+// MinPeers peers or while the network is stalled; it is syncing when its head
+// trails the wall clock by more than MaxSyncDistance slots. The node's own
+// sync status must use the same MaxSyncDistance.
+type HealthConfig struct {
+	MinPeers        int
+	MaxSyncDistance uint64
+	SyncingStatus   int
+}
+
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{MinPeers: 1, MaxSyncDistance: 2, SyncingStatus: http.StatusPartialContent}
+}
+
+type syncingResponse struct {
+	HeadSlot     uint64 `json:"head_slot"`
+	WallSlot     uint64 `json:"wall_slot"`
+	SyncDistance uint64 `json:"sync_distance"`
+	Status       string `json:"status"`
+	IsSyncing    bool   `json:"is_syncing"`
+	IsStalled    bool   `json:"is_stalled"`
+	HasHeadState bool   `json:"has_head_state"`
+	Peers        int    `json:"peers"`
+}
+
+type nodeHealthResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NodeSyncingHandler(src SyncSource, cfg HealthConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state := src.SyncState()
+		writeJSON(w, http.StatusOK, syncingResponse{
+			HeadSlot:     state.HeadSlot,
+			WallSlot:     state.WallSlot,
+			SyncDistance: state.Distance,
+			Status:       state.Status.String(),
+			IsSyncing:    state.Distance > cfg.MaxSyncDistance,
+			IsStalled:    state.Stalled,
+			HasHeadState: state.HasHeadState,
+			Peers:        state.Peers,
+		})
+	}
+}
+
+func NodeHealthHandler(src SyncSource, cfg HealthConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		syncingStatus := cfg.SyncingStatus
+		if raw := r.URL.Query().Get("syncing_status"); raw != "" {
+			code, err := strconv.Atoi(raw)
+			if err != nil || code < 100 || code > 599 {
+				http.Error(w, "invalid syncing_status", http.StatusBadRequest)
+				return
+			}
+			syncingStatus = code
+		}
+
+		code, resp := nodeHealth(src.SyncState(), cfg, syncingStatus)
+		writeJSON(w, code, resp)
+	}
+}
+
+func nodeHealth(state syncer.State, cfg HealthConfig, syncingStatus int) (int, nodeHealthResponse) {
+	switch {
+	case !state.HasHeadState:
+		return http.StatusServiceUnavailable, nodeHealthResponse{Status: "not_ready", Reason: "no head state"}
+	case state.Peers < cfg.MinPeers:
+		return http.StatusServiceUnavailable, nodeHealthResponse{Status: "not_ready", Reason: "not enough peers"}
+	case state.Stalled:
+		return http.StatusServiceUnavailable, nodeHealthResponse{Status: "stalled", Reason: "no recent blocks on the network"}
+	case state.Distance > cfg.MaxSyncDistance:
+		return syncingStatus, nodeHealthResponse{Status: "syncing"}
+	}
+	return http.StatusOK, nodeHealthResponse{Status: "synced"}
+}
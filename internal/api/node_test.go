@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/syncer"
+)
+
+type fakeSync syncer.State
+
+func (f *fakeSync) SyncState() syncer.State { return syncer.State(*f) }
+
+func TestNodeHealthRoute(t *testing.T) {
+	state := &fakeSync{Status: syncer.SyncSynced, HeadSlot: 10, WallSlot: 10, Peers: 3, HasHeadState: true}
+	mux := buildAPIMux(Services{
+		Store:      validatorTestStore(t, 2),
+		Aggregator: role.New(false),
+		Sync:       state,
+		Health:     DefaultHealthConfig(),
+	})
+
+	get := func(query string) (int, nodeHealthResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/node/health"+query, nil))
+		var body nodeHealthResponse
+		if rec.Code != http.StatusBadRequest {
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+		}
+		return rec.Code, body
+	}
+
+	if code, body := get(""); code != http.StatusOK || body.Status != "synced" {
+		t.Fatalf("synced: code=%d body=%+v", code, body)
+	}
+
+	state.WallSlot, state.Distance, state.Status = 20, 10, syncer.SyncSyncing
+	if code, body := get(""); code != http.StatusPartialContent || body.Status != "syncing" {
+		t.Fatalf("syncing: code=%d body=%+v", code, body)
+	}
+	if code, _ := get("?syncing_status=200"); code != http.StatusOK {
+		t.Fatalf("syncing override: code=%d, want 200", code)
+	}
+	if code, _ := get("?syncing_status=abc"); code != http.StatusBadRequest {
+		t.Fatalf("bad override: code=%d, want 400", code)
+	}
+
+	state.Stalled = true
+	if code, body := get(""); code != http.StatusServiceUnavailable || body.Status != "stalled" {
+		t.Fatalf("stalled: code=%d body=%+v", code, body)
+	}
+	if code, _ := get("?syncing_status=200"); code != http.StatusServiceUnavailable {
+		t.Fatalf("stalled with syncing override: code=%d, want 503", code)
+	}
+	state.Stalled = false
+
+	state.Peers = 0
+	if code, body := get(""); code != http.StatusServiceUnavailable || body.Reason != "not enough peers" {
+		t.Fatalf("no peers: code=%d body=%+v", code, body)
+	}
+	state.Peers, state.HasHeadState = 3, false
+	if code, body := get(""); code != http.StatusServiceUnavailable || body.Reason != "no head state" {
+		t.Fatalf("no head state: code=%d body=%+v", code, body)
+	}
+}
+
+func TestNodeSyncingRoute(t *testing.T) {
+	state := &fakeSync{Status: syncer.SyncSyncing, HeadSlot: 4, WallSlot: 20, Distance: 16, Peers: 2, HasHeadState: true, Stalled: true}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), Sync: state, Health: DefaultHealthConfig()})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/node/syncing", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200", rec.Code)
+	}
+	var body syncingResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	want := syncingResponse{HeadSlot: 4, WallSlot: 20, SyncDistance: 16, Status: "syncing", IsSyncing: true, IsStalled: true, HasHeadState: true, Peers: 2}
+	if body != want {
+		t.Fatalf("body=%+v, want %+v", body, want)
+	}
+}
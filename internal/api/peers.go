@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/geanlabs/gean/internal/p2p"
+)
+
+const peerDialTimeout = 10 * time.Second
+
+// PeerService exposes the p2p host's view of connected peers and the runtime
+// controls for dialing, disconnecting and banning them.
+type PeerService interface {
+	PeerInfos() []p2p.PeerInfo
+	PeerInfo(id peer.ID) (p2p.PeerInfo, bool)
+	MeshPeerCount() int
+	ConnectPeer(ctx context.Context, addr multiaddr.Multiaddr) error
+	DisconnectPeer(id peer.ID) error
+	BanPeer(id peer.ID) error
+	UnbanPeer(id peer.ID) bool
+}
+
+type peerStatusJSON struct {
+	HeadSlot      uint64 `json:"head_slot"`
+	HeadRoot      string `json:"head_root"`
+	FinalizedSlot uint64 `json:"finalized_slot"`
+	FinalizedRoot string `json:"finalized_root"`
+	ReceivedAt    int64  `json:"received_at"`
+}
+
+type peerJSON struct {
+	PeerID           string          `json:"peer_id"`
+	Addresses        []string        `json:"addresses"`
+	Direction        string          `json:"direction"`
+	ConnectedAt      int64           `json:"connected_at"`
+	ConnectedSeconds uint64          `json:"connected_seconds"`
+	Agent            string          `json:"agent"`
+	Score            int             `json:"score"`
+	Status           *peerStatusJSON `json:"status"`
+}
+
+type peersResponse struct {
+	Peers []peerJSON `json:"peers"`
+}
+
+type peerCountResponse struct {
+	Connected int `json:"connected"`
+	Inbound   int `json:"inbound"`
+	Outbound  int `json:"outbound"`
+	Mesh      int `json:"mesh"`
+}
+
+type peerAdminRequest struct {
+	Action string `json:"action"`
+	Addr   string `json:"addr"`
+	PeerID string `json:"peer_id"`
+}
+
+type peerAdminResponse struct {
+	Action string `json:"action"`
+	PeerID string `json:"peer_id"`
+}
+
+func PeersHandler(svc PeerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		infos := svc.PeerInfos()
+		sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+		resp := peersResponse{Peers: make([]peerJSON, 0, len(infos))}
+		now := time.Now()
+		for _, info := range infos {
+			resp.Peers = append(resp.Peers, toPeerJSON(info, now))
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func PeerHandler(svc PeerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := peer.Decode(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "invalid peer id", http.StatusBadRequest)
+			return
+		}
+		info, ok := svc.PeerInfo(id)
+		if !ok {
+			http.Error(w, "peer not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, toPeerJSON(info, time.Now()))
+	}
+}
+
+func PeerCountHandler(svc PeerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := peerCountResponse{Mesh: svc.MeshPeerCount()}
+		for _, info := range svc.PeerInfos() {
+			resp.Connected++
+			if info.Direction == "outbound" {
+				resp.Outbound++
+			} else {
+				resp.Inbound++
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// PeerAdminHandler applies one of connect, disconnect, ban or unban. connect
+// takes a multiaddr with a /p2p component or an ENR in "addr"; the others
+// take "peer_id".
+func PeerAdminHandler(svc PeerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req peerAdminRequest
+		if err := decodeJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Action == "connect" {
+			addr, id, err := parsePeerAddr(req.Addr)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), peerDialTimeout)
+			defer cancel()
+			if err := svc.ConnectPeer(ctx, addr); err != nil {
+				http.Error(w, fmt.Sprintf("connect: %v", err), http.StatusBadGateway)
+				return
+			}
+			writeJSON(w, http.StatusOK, peerAdminResponse{Action: req.Action, PeerID: id.String()})
+			return
+		}
+
+		id, err := peer.Decode(req.PeerID)
+		if err != nil {
+			http.Error(w, "invalid peer_id", http.StatusBadRequest)
+			return
+		}
+		switch req.Action {
+		case "disconnect":
+			err = svc.DisconnectPeer(id)
+		case "ban":
+			err = svc.BanPeer(id)
+		case "unban":
+			if !svc.UnbanPeer(id) {
+				http.Error(w, "peer not banned", http.StatusNotFound)
+				return
+			}
+		default:
+			http.Error(w, `action must be one of "connect", "disconnect", "ban", "unban"`, http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, p2p.ErrPeerNotConnected) {
+				code = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		writeJSON(w, http.StatusOK, peerAdminResponse{Action: req.Action, PeerID: id.String()})
+	}
+}
+
+func parsePeerAddr(raw string) (multiaddr.Multiaddr, peer.ID, error) {
+	raw = strings.TrimSpace(raw)
+	var (
+		addr multiaddr.Multiaddr
+		err  error
+	)
+	if strings.HasPrefix(raw, "enr:") {
+		addr, err = p2p.ParseENR(raw)
+	} else {
+		addr, err = multiaddr.NewMultiaddr(raw)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid addr: %v", err)
+	}
+	info, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return nil, "", fmt.Errorf("addr must include a /p2p peer id: %v", err)
+	}
+	return addr, info.ID, nil
+}
+
+func toPeerJSON(info p2p.PeerInfo, now time.Time) peerJSON {
+	out := peerJSON{
+		PeerID:      info.ID.String(),
+		Addresses:   make([]string, 0, len(info.Addrs)),
+		Direction:   info.Direction,
+		ConnectedAt: info.ConnectedAt.Unix(),
+		Agent:       info.Agent,
+		Score:       info.Score,
+	}
+	if now.After(info.ConnectedAt) {
+		out.ConnectedSeconds = uint64(now.Sub(info.ConnectedAt) / time.Second)
+	}
+	for _, addr := range info.Addrs {
+		out.Addresses = append(out.Addresses, addr.String())
+	}
+	if st := info.Status; st != nil {
+		out.Status = &peerStatusJSON{
+			HeadSlot:      st.HeadSlot,
+			HeadRoot:      fmt.Sprintf("0x%x", st.HeadRoot),
+			FinalizedSlot: st.FinalizedSlot,
+			FinalizedRoot: fmt.Sprintf("0x%x", st.FinalizedRoot),
+			ReceivedAt:    info.StatusAt.Unix(),
+		}
+	}
+	return out
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/geanlabs/gean/internal/p2p"
+	"github.com/geanlabs/gean/internal/role"
+)
+
+type fakePeers struct {
+	infos  []p2p.PeerInfo
+	dialed []string
+	banned map[peer.ID]bool
+}
+
+func (f *fakePeers) PeerInfos() []p2p.PeerInfo { return f.infos }
+
+func (f *fakePeers) PeerInfo(id peer.ID) (p2p.PeerInfo, bool) {
+	for _, info := range f.infos {
+		if info.ID == id {
+			return info, true
+		}
+	}
+	return p2p.PeerInfo{}, false
+}
+
+func (f *fakePeers) MeshPeerCount() int { return 1 }
+
+func (f *fakePeers) ConnectPeer(_ context.Context, addr multiaddr.Multiaddr) error {
+	f.dialed = append(f.dialed, addr.String())
+	return nil
+}
+
+func (f *fakePeers) DisconnectPeer(id peer.ID) error {
+	if _, ok := f.PeerInfo(id); !ok {
+		return p2p.ErrPeerNotConnected
+	}
+	return nil
+}
+
+func (f *fakePeers) BanPeer(id peer.ID) error {
+	f.banned[id] = true
+	return nil
+}
+
+func (f *fakePeers) UnbanPeer(id peer.ID) bool {
+	ok := f.banned[id]
+	delete(f.banned, id)
+	return ok
+}
+
+func testPeerID(t *testing.T) peer.ID {
+	t.Helper()
+	_, pub, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatalf("peer id: %v", err)
+	}
+	return id
+}
+
+func TestPeerRoutes(t *testing.T) {
+	in, out := testPeerID(t), testPeerID(t)
+	addr := multiaddr.StringCast("/ip4/10.0.0.1/udp/9000/quic-v1")
+	peers := &fakePeers{
+		infos: []p2p.PeerInfo{
+			{ID: in, Direction: "inbound", ConnectedAt: time.Now().Add(-time.Minute), Addrs: []multiaddr.Multiaddr{addr}, Score: -2},
+			{ID: out, Direction: "outbound", ConnectedAt: time.Now(), Agent: "zeam/0.1", Status: &p2p.StatusMessage{HeadSlot: 12}},
+		},
+		banned: map[peer.ID]bool{},
+	}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), Peers: peers})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/node/peers", nil))
+	var list peersResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || len(list.Peers) != 2 {
+		t.Fatalf("list: code=%d body=%s err=%v", rec.Code, rec.Body.String(), err)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/node/peers/"+in.String(), nil))
+	var one peerJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &one); err != nil {
+		t.Fatalf("decode peer: %v", err)
+	}
+	if one.Direction != "inbound" || one.Score != -2 || one.ConnectedSeconds < 59 || len(one.Addresses) != 1 || one.Status != nil {
+		t.Fatalf("peer=%+v", one)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/node/peers/"+testPeerID(t).String(), nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown peer status=%d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/node/peer_count", nil))
+	var count peerCountResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &count); err != nil {
+		t.Fatalf("decode count: %v", err)
+	}
+	if count != (peerCountResponse{Connected: 2, Inbound: 1, Outbound: 1, Mesh: 1}) {
+		t.Fatalf("count=%+v", count)
+	}
+}
+
+func TestPeerAdminRoute(t *testing.T) {
+	id := testPeerID(t)
+	peers := &fakePeers{infos: []p2p.PeerInfo{{ID: id}}, banned: map[peer.ID]bool{}}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), Peers: peers, AdminToken: "secret"})
+
+	postAs := func(token, body string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/lean/v0/admin/peers", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	post := func(body string) int {
+		t.Helper()
+		return postAs("secret", body)
+	}
+
+	banBody := `{"action":"ban","peer_id":"` + id.String() + `"}`
+	for _, token := range []string{"", "wrong"} {
+		if code := postAs(token, banBody); code != http.StatusUnauthorized || peers.banned[id] {
+			t.Fatalf("token %q: status=%d banned=%v, want 401 and no ban", token, code, peers.banned)
+		}
+	}
+
+	target := "/ip4/10.0.0.2/udp/9000/quic-v1/p2p/" + id.String()
+	if code := post(`{"action":"connect","addr":"` + target + `"}`); code != http.StatusOK {
+		t.Fatalf("connect status=%d", code)
+	}
+	if len(peers.dialed) != 1 || peers.dialed[0] != target {
+		t.Fatalf("dialed=%v", peers.dialed)
+	}
+	if code := post(`{"action":"connect","addr":"/ip4/10.0.0.2/udp/9000/quic-v1"}`); code != http.StatusBadRequest {
+		t.Fatalf("connect without peer id status=%d, want 400", code)
+	}
+	if code := post(banBody); code != http.StatusOK || !peers.banned[id] {
+		t.Fatalf("ban status=%d banned=%v", code, peers.banned)
+	}
+	if code := post(`{"action":"unban","peer_id":"` + id.String() + `"}`); code != http.StatusOK {
+		t.Fatalf("unban status=%d", code)
+	}
+	if code := post(`{"action":"unban","peer_id":"` + id.String() + `"}`); code != http.StatusNotFound {
+		t.Fatalf("second unban status=%d, want 404", code)
+	}
+	if code := post(`{"action":"disconnect","peer_id":"` + testPeerID(t).String() + `"}`); code != http.StatusNotFound {
+		t.Fatalf("disconnect unknown status=%d, want 404", code)
+	}
+	if code := post(`{"action":"reboot","peer_id":"` + id.String() + `"}`); code != http.StatusBadRequest {
+		t.Fatalf("unknown action status=%d, want 400", code)
+	}
+}
+
+func TestPeerAdminRouteNeedsAdminToken(t *testing.T) {
+	peers := &fakePeers{banned: map[peer.ID]bool{}}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), Peers: peers})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/lean/v0/admin/peers",
+		strings.NewReader(`{"action":"ban","peer_id":"`+testPeerID(t).String()+`"}`)))
+	if rec.Code == http.StatusOK || len(peers.banned) != 0 {
+		t.Fatalf("status=%d banned=%v, want the route unregistered", rec.Code, peers.banned)
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/validatormonitor"
+)
+
+// PerformanceSource reports the validator monitor's running totals.
+type PerformanceSource interface {
+	Summaries() []validatormonitor.Summary
+}
+
+type performanceResponse struct {
+	CurrentSlot          uint64                     `json:"current_slot"`
+	EvaluationDelaySlots uint64                     `json:"evaluation_delay_slots"`
+	Validators           []validatormonitor.Summary `json:"validators"`
+}
+
+func ValidatorPerformanceHandler(s *store.ConsensusStore, perf PerformanceSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, err := parseUintQuery(r, "validator_index", ^uint64(0))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := performanceResponse{
+			CurrentSlot:          s.Spec().CurrentSlot(s.Config().GenesisTime, uint64(time.Now().UnixMilli())),
+			EvaluationDelaySlots: validatormonitor.EvaluationDelaySlots,
+			Validators:           []validatormonitor.Summary{},
+		}
+		for _, summary := range perf.Summaries() {
+			if filter != ^uint64(0) && summary.ValidatorIndex != filter {
+				continue
+			}
+			resp.Validators = append(resp.Validators, summary)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
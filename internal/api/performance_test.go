@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/validatormonitor"
+)
+
+type fakePerformance []validatormonitor.Summary
+
+func (f fakePerformance) Summaries() []validatormonitor.Summary { return f }
+
+func TestValidatorPerformanceRoute(t *testing.T) {
+	perf := fakePerformance{
+		{ValidatorIndex: 1, Local: true, AttestationsIncluded: 4, AverageInclusionDelay: 1.5},
+		{ValidatorIndex: 9, AttestationsMissed: 2},
+	}
+	mux := buildAPIMux(Services{Store: validatorTestStore(t, 2), Aggregator: role.New(false), Performance: perf})
+
+	get := func(query string) performanceResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/performance"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+		}
+		var body performanceResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		return body
+	}
+
+	all := get("")
+	if len(all.Validators) != 2 || all.EvaluationDelaySlots != validatormonitor.EvaluationDelaySlots {
+		t.Fatalf("unexpected body: %+v", all)
+	}
+	one := get("?validator_index=9")
+	if len(one.Validators) != 1 || one.Validators[0].AttestationsMissed != 2 {
+		t.Fatalf("filtered body: %+v", one)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/performance?validator_index=x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad filter status=%d, want 400", rec.Code)
+	}
+}
@@ -2,22 +2,58 @@ package api
 
 import (
 	"net/http"
-
-	"github.com/geanlabs/gean/internal/forkchoice"
-	"github.com/geanlabs/gean/internal/role"
-	"github.com/geanlabs/gean/internal/store"
 )
 
-func buildAPIMux(s *store.ConsensusStore, fc *forkchoice.ForkChoice, aggCtl *role.Controller) *http.ServeMux {
+func buildAPIMux(svc Services) *http.ServeMux {
 	mux := http.NewServeMux()
+	s := svc.Store
 
 	mux.HandleFunc("GET /lean/v0/health", HealthHandler)
+	mux.HandleFunc("GET /lean/v0/config/spec", SpecHandler(s))
 	mux.HandleFunc("GET /lean/v0/states/finalized", FinalizedStateHandler(s))
 	mux.HandleFunc("GET /lean/v0/blocks/finalized", FinalizedBlockHandler(s))
 	mux.HandleFunc("GET /lean/v0/checkpoints/justified", JustifiedCheckpointHandler(s))
-	mux.HandleFunc("GET /lean/v0/fork_choice", ForkChoiceHandler(s, fc))
-	mux.HandleFunc("GET /lean/v0/admin/aggregator", AggregatorStatusHandler(aggCtl))
-	mux.HandleFunc("POST /lean/v0/admin/aggregator", AggregatorToggleHandler(aggCtl))
+	mux.HandleFunc("GET /lean/v0/fork_choice", ForkChoiceHandler(s, svc.ForkChoice))
+	if svc.Debug != nil {
+		mux.HandleFunc("GET /lean/v0/debug/fork_choice", ForkChoiceDebugHandler(svc.Debug))
+	}
+	if svc.BlockTrace != nil {
+		mux.HandleFunc("GET /lean/v0/debug/blocks/{root}/timeline", BlockTimelineHandler(svc.BlockTrace))
+	}
+	if svc.Sync != nil {
+		mux.HandleFunc("GET /lean/v0/node/syncing", NodeSyncingHandler(svc.Sync, svc.Health))
+		mux.HandleFunc("GET /lean/v0/node/health", NodeHealthHandler(svc.Sync, svc.Health))
+	}
+	if svc.Peers != nil {
+		mux.HandleFunc("GET /lean/v0/node/peers", PeersHandler(svc.Peers))
+		mux.HandleFunc("GET /lean/v0/node/peers/{id}", PeerHandler(svc.Peers))
+		mux.HandleFunc("GET /lean/v0/node/peer_count", PeerCountHandler(svc.Peers))
+		if svc.AdminToken != "" {
+			mux.Handle("POST /lean/v0/admin/peers", RequireBearerToken(svc.AdminToken, PeerAdminHandler(svc.Peers)))
+		}
+	}
+	mux.HandleFunc("GET /lean/v0/admin/aggregator", AggregatorStatusHandler(svc.Aggregator))
+	mux.HandleFunc("POST /lean/v0/admin/aggregator", AggregatorToggleHandler(svc.Aggregator))
+
+	mux.HandleFunc("GET /lean/v0/validator/duties/proposer", ProposerDutiesHandler(s))
+	mux.HandleFunc("GET /lean/v0/validator/duties/attester", AttesterDutiesHandler(s, svc.CommitteeCount))
+	mux.HandleFunc("GET /lean/v0/validator/attestation_data", AttestationDataHandler(s))
+	if svc.Validator != nil {
+		mux.HandleFunc("POST /lean/v0/validator/attestations", SubmitAttestationHandler(svc.Validator))
+		mux.HandleFunc("GET /lean/v0/validator/blocks/{slot}", ProduceBlockHandler(s, svc.Validator))
+		mux.HandleFunc("POST /lean/v0/blocks", PublishBlockHandler(svc.Validator))
+	}
+	if svc.Keys != nil {
+		mux.HandleFunc("GET /lean/v0/validator/keys", KeyLifetimesHandler(s, svc.Keys))
+	}
+	if svc.Performance != nil {
+		mux.HandleFunc("GET /lean/v0/validator/performance", ValidatorPerformanceHandler(s, svc.Performance))
+	}
+	if svc.KeyManager != nil && svc.AdminToken != "" {
+		mux.Handle("GET /lean/v0/admin/keys", RequireBearerToken(svc.AdminToken, ListKeysHandler(svc.KeyManager)))
+		mux.Handle("POST /lean/v0/admin/keys", RequireBearerToken(svc.AdminToken, ImportKeysHandler(svc.KeyManager)))
+		mux.Handle("DELETE /lean/v0/admin/keys", RequireBearerToken(svc.AdminToken, DeleteKeysHandler(svc.KeyManager)))
+	}
 
 	return mux
 }
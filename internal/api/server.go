@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -11,14 +12,38 @@ import (
 	"github.com/geanlabs/gean/internal/store"
 )
 
-func StartAPIServer(address string, s *store.ConsensusStore, fc *forkchoice.ForkChoice, aggCtl *role.Controller) error {
-	mux := buildAPIMux(s, fc, aggCtl)
+type Services struct {
+	Store          *store.ConsensusStore
+	ForkChoice     *forkchoice.ForkChoice
+	Debug          ForkChoiceDebugSource
+	BlockTrace     BlockTraceSource
+	Aggregator     *role.Controller
+	Validator      ValidatorService
+	Keys           KeySource
+	KeyManager     KeyManagerService
+	AdminToken     string
+	Performance    PerformanceSource
+	Sync           SyncSource
+	Peers          PeerService
+	Health         HealthConfig
+	CommitteeCount uint64
+}
+
+func NewAPIServer(address string, svc Services) *http.Server {
+	return &http.Server{Addr: address, Handler: buildAPIMux(svc)}
+}
 
-	listener, err := net.Listen("tcp", address)
+// Serve listens on srv.Addr and serves until the server is shut down, which
+// is not reported as an error.
+func Serve(srv *http.Server, name string) error {
+	listener, err := net.Listen("tcp", srv.Addr)
 	if err != nil {
-		return fmt.Errorf("api listen: %w", err)
+		return fmt.Errorf("%s listen: %w", name, err)
 	}
 
-	logger.Info(logger.Network, "api server listening on %s", address)
-	return http.Serve(listener, mux)
+	logger.Info(logger.Network, "%s server listening on %s", name, srv.Addr)
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type specResponse struct {
+	types.ChainSpec
+	MillisecondsPerSlot     uint64 `json:"milliseconds_per_slot"`
+	MillisecondsPerInterval uint64 `json:"milliseconds_per_interval"`
+	GenesisTime             uint64 `json:"genesis_time"`
+}
+
+// SpecHandler reports the chain spec the node runs with so tools can check
+// they agree on slot timing and limits.
+func SpecHandler(s *store.ConsensusStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec := s.Spec()
+		resp := specResponse{
+			ChainSpec:               spec,
+			MillisecondsPerSlot:     spec.MillisecondsPerSlot(),
+			MillisecondsPerInterval: spec.MillisecondsPerInterval(),
+		}
+		if s != nil {
+			if cfg := s.Config(); cfg != nil {
+				resp.GenesisTime = cfg.GenesisTime
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
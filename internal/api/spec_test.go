@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestSpecHandlerReportsActiveSpec(t *testing.T) {
+	minimal, err := types.PresetSpec(types.PresetMinimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := store.NewConsensusStore(storage.NewInMemoryBackend())
+	s.UseSpec(minimal)
+	rec := httptest.NewRecorder()
+	buildAPIMux(Services{Store: s}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/config/spec", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200", rec.Code)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["preset"] != "minimal" || body["seconds_per_slot"] != float64(2) ||
+		body["milliseconds_per_interval"] != float64(400) || body["max_attestations_data"] != float64(8) {
+		t.Fatalf("body=%v", body)
+	}
+}
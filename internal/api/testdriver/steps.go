@@ -22,7 +22,7 @@ func (sess *Session) applyTick(step *specfixtures.ForkChoiceStep) error {
 		if timestampMs < genesisMs {
 			sess.store.SetTime(0)
 		} else {
-			sess.store.SetTime((timestampMs - genesisMs) / types.MillisecondsPerInterval)
+			sess.store.SetTime((timestampMs - genesisMs) / sess.store.Spec().MillisecondsPerInterval())
 		}
 	case step.Interval != nil:
 		sess.store.SetTime(*step.Interval)
@@ -49,7 +49,7 @@ func (sess *Session) applyBlock(step *specfixtures.ForkChoiceStep) error {
 	}
 	signedBlock := &types.SignedBlock{Block: block, Signature: &types.BlockSignatures{AttestationSignatures: attSigs}}
 
-	minTime := block.Slot * types.IntervalsPerSlot
+	minTime := sess.store.Spec().IntervalsFromSlot(block.Slot)
 	if sess.store.Time() < minTime {
 		sess.store.SetTime(minTime)
 	}
@@ -87,7 +87,7 @@ func (sess *Session) applyAttestation(step *specfixtures.ForkChoiceStep) error {
 	}
 
 	if step.Valid {
-		minTime := attData.Slot * types.IntervalsPerSlot
+		minTime := sess.store.Spec().IntervalsFromSlot(attData.Slot)
 		if sess.store.Time() < minTime {
 			sess.store.SetTime(minTime)
 		}
@@ -124,7 +124,7 @@ func (sess *Session) applyAggregatedAttestation(step *specfixtures.ForkChoiceSte
 	}
 
 	if step.Valid {
-		minTime := attData.Slot * types.IntervalsPerSlot
+		minTime := sess.store.Spec().IntervalsFromSlot(attData.Slot)
 		if sess.store.Time() < minTime {
 			sess.store.SetTime(minTime)
 		}
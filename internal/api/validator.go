@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geanlabs/gean/internal/blockbuilder"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+const (
+	validatorRequestTimeout = 2 * time.Second
+	maxRequestBodyBytes     = 10 << 20
+)
+
+type ValidatorService interface {
+	SubmitAttestation(ctx context.Context, att *types.SignedAttestation) error
+	ProduceBlock(ctx context.Context, slot, proposerIndex uint64) (*blockbuilder.Result, error)
+	CheckBlockForBroadcast(ctx context.Context, signedBlock *types.SignedBlock) error
+	ImportBlock(ctx context.Context, signedBlock *types.SignedBlock) error
+	PublishBlock(ctx context.Context, signedBlock *types.SignedBlock) error
+}
+
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+}
+
+func writeSSZ(w http.ResponseWriter, v sszMarshaler) {
+	data, err := v.MarshalSSZ()
+	if err != nil {
+		http.Error(w, "ssz marshal failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(data)
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	if len(body) > maxRequestBodyBytes {
+		return nil, fmt.Errorf("request body too large")
+	}
+	return body, nil
+}
+
+func parseUintQuery(r *http.Request, name string, fallback uint64) (uint64, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return v, nil
+}
+
+func writeServiceError(w http.ResponseWriter, err error) {
+	var storeErr *store.StoreError
+	switch {
+	case errors.As(err, &storeErr):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		http.Error(w, "node busy", http.StatusServiceUnavailable)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/geanlabs/gean/internal/blockbuilder"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+const (
+	broadcastValidationGossip    = "gossip"
+	broadcastValidationConsensus = "consensus"
+)
+
+type payloadErrorJSON struct {
+	DataRoot     string `json:"data_root"`
+	Error        string `json:"error"`
+	ExpectedSkip bool   `json:"expected_skip"`
+}
+
+type produceBlockResponse struct {
+	Slot                  uint64             `json:"slot"`
+	ProposerIndex         uint64             `json:"proposer_index"`
+	BlockRoot             string             `json:"block_root"`
+	Block                 string             `json:"block"`
+	AttestationSignatures []string           `json:"attestation_signatures"`
+	PayloadErrors         []payloadErrorJSON `json:"payload_errors"`
+}
+
+type publishBlockResponse struct {
+	BlockRoot           string `json:"block_root"`
+	BroadcastValidation string `json:"broadcast_validation"`
+	Published           bool   `json:"published"`
+	Imported            bool   `json:"imported"`
+	ImportError         string `json:"import_error,omitempty"`
+}
+
+func ProduceBlockHandler(s *store.ConsensusStore, v ValidatorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		slot, err := strconv.ParseUint(r.PathValue("slot"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid slot", http.StatusBadRequest)
+			return
+		}
+		if !r.URL.Query().Has("proposer_index") {
+			http.Error(w, "missing proposer_index", http.StatusBadRequest)
+			return
+		}
+		proposerIndex, err := parseUintQuery(r, "proposer_index", 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if headSlot := s.HeadSlot(); slot <= headSlot {
+			http.Error(w, fmt.Sprintf("slot %d is not after head slot %d", slot, headSlot), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), validatorRequestTimeout)
+		defer cancel()
+		result, err := v.ProduceBlock(ctx, slot, proposerIndex)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+
+		if wantsJSON(r) {
+			resp, err := produceBlockToJSON(result)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+		w.Header().Set("X-Payload-Error-Count", strconv.Itoa(len(result.PayloadErrors)))
+		writeSSZ(w, &types.SignedBlock{
+			Block: result.Block,
+			Signature: &types.BlockSignatures{
+				AttestationSignatures: result.AttestationProofs,
+				ProposerSignature:     types.BlankXMSSSignature(),
+			},
+		})
+	}
+}
+
+// PublishBlockHandler supports two broadcast_validation modes. "consensus"
+// (the default) imports the block first and only gossips it if the state
+// transition succeeds. "gossip" checks only what a relaying peer would,
+// broadcasts, and then imports; an import failure is reported with 202.
+func PublishBlockHandler(v ValidatorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mode := r.URL.Query().Get("broadcast_validation")
+		if mode == "" {
+			mode = broadcastValidationConsensus
+		}
+		if mode != broadcastValidationGossip && mode != broadcastValidationConsensus {
+			http.Error(w, fmt.Sprintf("unknown broadcast_validation %q", mode), http.StatusBadRequest)
+			return
+		}
+
+		body, err := readBody(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		signedBlock := new(types.SignedBlock)
+		if err := signedBlock.UnmarshalSSZ(body); err != nil {
+			http.Error(w, fmt.Sprintf("decode block: %v", err), http.StatusBadRequest)
+			return
+		}
+		if signedBlock.Block == nil || signedBlock.Block.Body == nil || signedBlock.Signature == nil {
+			http.Error(w, "decode block: incomplete signed block", http.StatusBadRequest)
+			return
+		}
+		blockRoot, err := signedBlock.Block.HashTreeRoot()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("block root: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), validatorRequestTimeout)
+		defer cancel()
+		resp := publishBlockResponse{
+			BlockRoot:           fmt.Sprintf("0x%x", blockRoot),
+			BroadcastValidation: mode,
+		}
+
+		if mode == broadcastValidationConsensus {
+			if err := v.ImportBlock(ctx, signedBlock); err != nil {
+				writeServiceError(w, err)
+				return
+			}
+			resp.Imported = true
+			if err := v.PublishBlock(ctx, signedBlock); err != nil {
+				http.Error(w, fmt.Sprintf("block imported but publish failed: %v", err), http.StatusInternalServerError)
+				return
+			}
+			resp.Published = true
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+
+		if err := v.CheckBlockForBroadcast(ctx, signedBlock); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		if err := v.PublishBlock(ctx, signedBlock); err != nil {
+			http.Error(w, fmt.Sprintf("publish block: %v", err), http.StatusInternalServerError)
+			return
+		}
+		resp.Published = true
+		if err := v.ImportBlock(ctx, signedBlock); err != nil {
+			logger.Warn(logger.Validator, "broadcast block failed import block_root=0x%x: %v", blockRoot, err)
+			resp.ImportError = err.Error()
+			writeJSON(w, http.StatusAccepted, resp)
+			return
+		}
+		resp.Imported = true
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func produceBlockToJSON(result *blockbuilder.Result) (produceBlockResponse, error) {
+	block := result.Block
+	blockRoot, err := block.HashTreeRoot()
+	if err != nil {
+		return produceBlockResponse{}, fmt.Errorf("block root: %w", err)
+	}
+	blockSSZ, err := block.MarshalSSZ()
+	if err != nil {
+		return produceBlockResponse{}, fmt.Errorf("marshal block: %w", err)
+	}
+
+	resp := produceBlockResponse{
+		Slot:                  block.Slot,
+		ProposerIndex:         block.ProposerIndex,
+		BlockRoot:             fmt.Sprintf("0x%x", blockRoot),
+		Block:                 "0x" + hex.EncodeToString(blockSSZ),
+		AttestationSignatures: make([]string, 0, len(result.AttestationProofs)),
+		PayloadErrors:         make([]payloadErrorJSON, 0, len(result.PayloadErrors)),
+	}
+	for _, proof := range result.AttestationProofs {
+		proofSSZ, err := proof.MarshalSSZ()
+		if err != nil {
+			return produceBlockResponse{}, fmt.Errorf("marshal attestation signature: %w", err)
+		}
+		resp.AttestationSignatures = append(resp.AttestationSignatures, "0x"+hex.EncodeToString(proofSSZ))
+	}
+	for _, payloadErr := range result.PayloadErrors {
+		resp.PayloadErrors = append(resp.PayloadErrors, payloadErrorJSON{
+			DataRoot:     fmt.Sprintf("0x%x", payloadErr.DataRoot),
+			Error:        payloadErr.Err.Error(),
+			ExpectedSkip: blockbuilder.IsExpectedSkip(payloadErr.Err),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/blockbuilder"
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type fakeValidatorService struct {
+	attestations []*types.SignedAttestation
+	imported     []*types.SignedBlock
+	published    []*types.SignedBlock
+	payloadErrs  []blockbuilder.PayloadError
+	produceErr   error
+	submitErr    error
+	checkErr     error
+	importErr    error
+}
+
+func (f *fakeValidatorService) SubmitAttestation(ctx context.Context, att *types.SignedAttestation) error {
+	if f.submitErr != nil {
+		return f.submitErr
+	}
+	f.attestations = append(f.attestations, att)
+	return nil
+}
+
+func (f *fakeValidatorService) ProduceBlock(ctx context.Context, slot, proposerIndex uint64) (*blockbuilder.Result, error) {
+	if f.produceErr != nil {
+		return nil, f.produceErr
+	}
+	return &blockbuilder.Result{
+		Block: &types.Block{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+			Body:          &types.BlockBody{},
+		},
+		AttestationProofs: []*types.AggregatedSignatureProof{{Participants: []byte{0x03}, ProofData: []byte{0xaa}}},
+		PayloadErrors:     f.payloadErrs,
+	}, nil
+}
+
+func (f *fakeValidatorService) CheckBlockForBroadcast(ctx context.Context, signedBlock *types.SignedBlock) error {
+	return f.checkErr
+}
+
+func (f *fakeValidatorService) ImportBlock(ctx context.Context, signedBlock *types.SignedBlock) error {
+	if f.importErr != nil {
+		return f.importErr
+	}
+	f.imported = append(f.imported, signedBlock)
+	return nil
+}
+
+func (f *fakeValidatorService) PublishBlock(ctx context.Context, signedBlock *types.SignedBlock) error {
+	f.published = append(f.published, signedBlock)
+	return nil
+}
+
+func validatorTestStore(t *testing.T, numValidators int) *store.ConsensusStore {
+	t.Helper()
+	s := store.NewConsensusStore(storage.NewInMemoryBackend())
+	root := [32]byte{0x01}
+	s.SetHead(root)
+	s.SetSafeTarget(root)
+	s.SetLatestJustified(&types.Checkpoint{Root: root, Slot: 3})
+	s.SetLatestFinalized(&types.Checkpoint{Root: root, Slot: 3})
+	s.InsertBlockHeader(root, &types.BlockHeader{Slot: 3})
+	state := testState(3)
+	for i := range numValidators {
+		state.Validators = append(state.Validators, &types.Validator{Index: uint64(i)})
+	}
+	s.InsertState(root, state)
+	return s
+}
+
+func TestAttestationDataHandler(t *testing.T) {
+	s := validatorTestStore(t, 3)
+
+	rec := httptest.NewRecorder()
+	AttestationDataHandler(s)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/attestation_data?slot=4", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	data := new(types.AttestationData)
+	if err := data.UnmarshalSSZ(rec.Body.Bytes()); err != nil {
+		t.Fatalf("decode attestation data: %v", err)
+	}
+	if data.Slot != 4 || data.Head.Root != ([32]byte{0x01}) {
+		t.Fatalf("unexpected attestation data slot=%d head=0x%x", data.Slot, data.Head.Root)
+	}
+
+	rec = httptest.NewRecorder()
+	AttestationDataHandler(s)(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/attestation_data", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("missing slot status=%d, want 400", rec.Code)
+	}
+}
+
+func TestSubmitAttestationHandler(t *testing.T) {
+	svc := &fakeValidatorService{}
+	att := &types.SignedAttestation{
+		ValidatorID: 2,
+		Data: &types.AttestationData{
+			Slot:   4,
+			Head:   &types.Checkpoint{},
+			Target: &types.Checkpoint{},
+			Source: &types.Checkpoint{},
+		},
+	}
+	body, err := att.MarshalSSZ()
+	if err != nil {
+		t.Fatalf("marshal attestation: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	SubmitAttestationHandler(svc)(rec, httptest.NewRequest(http.MethodPost, "/lean/v0/validator/attestations", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if len(svc.attestations) != 1 || svc.attestations[0].ValidatorID != 2 {
+		t.Fatalf("attestation not forwarded: %+v", svc.attestations)
+	}
+
+	svc.submitErr = &store.StoreError{Kind: store.ErrUnknownHeadBlock, Message: "unknown head"}
+	rec = httptest.NewRecorder()
+	SubmitAttestationHandler(svc)(rec, httptest.NewRequest(http.MethodPost, "/lean/v0/validator/attestations", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("rejected status=%d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	SubmitAttestationHandler(svc)(rec, httptest.NewRequest(http.MethodPost, "/lean/v0/validator/attestations", bytes.NewReader([]byte{0x01})))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("garbage status=%d, want 400", rec.Code)
+	}
+}
+
+func TestProduceBlockRoute(t *testing.T) {
+	s := validatorTestStore(t, 3)
+	svc := &fakeValidatorService{}
+	mux := buildAPIMux(Services{Store: s, Aggregator: role.New(false), Validator: svc})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/blocks/5?proposer_index=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("produce status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	signedBlock := new(types.SignedBlock)
+	if err := signedBlock.UnmarshalSSZ(rec.Body.Bytes()); err != nil {
+		t.Fatalf("decode block: %v", err)
+	}
+	if signedBlock.Block.Slot != 5 || signedBlock.Block.ProposerIndex != 2 {
+		t.Fatalf("block slot=%d proposer=%d, want 5/2", signedBlock.Block.Slot, signedBlock.Block.ProposerIndex)
+	}
+	if len(signedBlock.Signature.AttestationSignatures) != 1 {
+		t.Fatalf("attestation signatures=%d, want 1", len(signedBlock.Signature.AttestationSignatures))
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lean/v0/validator/blocks/3?proposer_index=0", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("stale slot status=%d, want 400", rec.Code)
+	}
+}
+
+func TestProduceBlockRouteJSONReportsPayloadErrors(t *testing.T) {
+	s := validatorTestStore(t, 3)
+	svc := &fakeValidatorService{payloadErrs: []blockbuilder.PayloadError{
+		{DataRoot: [32]byte{0x0f}, Err: blockbuilder.ErrPayloadRootMismatch},
+	}}
+	mux := buildAPIMux(Services{Store: s, Aggregator: role.New(false), Validator: svc})
+
+	req := httptest.NewRequest(http.MethodGet, "/lean/v0/validator/blocks/5?proposer_index=2", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var body produceBlockResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Slot != 5 || len(body.AttestationSignatures) != 1 {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+	if len(body.PayloadErrors) != 1 || body.PayloadErrors[0].ExpectedSkip || body.PayloadErrors[0].Error == "" {
+		t.Fatalf("payload errors=%+v, want one unexpected error", body.PayloadErrors)
+	}
+}
+
+func TestPublishBlockBroadcastValidation(t *testing.T) {
+	signedBlock := &types.SignedBlock{
+		Block:     &types.Block{Slot: 5, ProposerIndex: 2, Body: &types.BlockBody{}},
+		Signature: &types.BlockSignatures{},
+	}
+	body, err := signedBlock.MarshalSSZ()
+	if err != nil {
+		t.Fatalf("marshal block: %v", err)
+	}
+	post := func(svc *fakeValidatorService, query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		PublishBlockHandler(svc)(rec, httptest.NewRequest(http.MethodPost, "/lean/v0/blocks"+query, bytes.NewReader(body)))
+		return rec
+	}
+	rejected := &store.StoreError{Kind: store.ErrStateTransitionFailed, Message: "bad transition"}
+
+	svc := &fakeValidatorService{}
+	if rec := post(svc, ""); rec.Code != http.StatusOK || len(svc.imported) != 1 || len(svc.published) != 1 {
+		t.Fatalf("consensus status=%d imported=%d published=%d", rec.Code, len(svc.imported), len(svc.published))
+	}
+
+	svc = &fakeValidatorService{importErr: rejected}
+	if rec := post(svc, "?broadcast_validation=consensus"); rec.Code != http.StatusBadRequest || len(svc.published) != 0 {
+		t.Fatalf("consensus reject status=%d published=%d, want 400 and no publish", rec.Code, len(svc.published))
+	}
+
+	svc = &fakeValidatorService{importErr: rejected}
+	rec := post(svc, "?broadcast_validation=gossip")
+	if rec.Code != http.StatusAccepted || len(svc.published) != 1 {
+		t.Fatalf("gossip status=%d published=%d, want 202 and publish", rec.Code, len(svc.published))
+	}
+	var resp publishBlockResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if !resp.Published || resp.Imported || resp.ImportError == "" {
+		t.Fatalf("unexpected gossip response: %+v", resp)
+	}
+
+	svc = &fakeValidatorService{checkErr: &store.StoreError{Kind: store.ErrNotProposer, Message: "not proposer"}}
+	if rec := post(svc, "?broadcast_validation=gossip"); rec.Code != http.StatusBadRequest || len(svc.published) != 0 {
+		t.Fatalf("gossip check status=%d published=%d, want 400 and no publish", rec.Code, len(svc.published))
+	}
+
+	svc = &fakeValidatorService{importErr: context.DeadlineExceeded}
+	if rec := post(svc, ""); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("timeout status=%d, want 503", rec.Code)
+	}
+
+	if rec := post(&fakeValidatorService{}, "?broadcast_validation=everything"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown mode status=%d, want 400", rec.Code)
+	}
+}
+
+func TestValidatorRoutesRequireService(t *testing.T) {
+	s := validatorTestStore(t, 3)
+	mux := buildAPIMux(Services{Store: s, Aggregator: role.New(false)})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/lean/v0/blocks", nil))
+	if rec.Code != http.StatusNotFound && rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status=%d, want route to be absent", rec.Code)
+	}
+}
@@ -19,7 +19,7 @@ func GetAttestationTarget(s *store.ConsensusStore) *types.Checkpoint {
 		safeTargetSlot = safeTargetHeader.Slot
 	}
 
-	for range uint64(types.JustificationLookbackSlots) {
+	for range s.Spec().JustificationLookbackSlots {
 		if targetHeader.Slot <= safeTargetSlot {
 			break
 		}
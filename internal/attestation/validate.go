@@ -2,7 +2,6 @@ package attestation
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/geanlabs/gean/internal/store"
 	"github.com/geanlabs/gean/internal/types"
@@ -81,8 +80,7 @@ func ValidateAttestationData(s *store.ConsensusStore, data *types.AttestationDat
 	if headHeader.Slot != data.Head.Slot {
 		return errHeadSlotMismatch(data.Head.Slot, headHeader.Slot)
 	}
-	if data.Slot > math.MaxUint64/types.IntervalsPerSlot ||
-		data.Slot*types.IntervalsPerSlot > s.Time()+types.GossipDisparityIntervals {
+	if s.Spec().IntervalsFromSlot(data.Slot) > s.Time()+types.GossipDisparityIntervals {
 		return errAttestationTooFarInFuture(data.Slot, s.Time())
 	}
 
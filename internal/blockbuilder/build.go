@@ -33,6 +33,8 @@ func Build(input Input) (*Result, error) {
 			plan.postState.LatestJustified, required)
 	}
 
+	traceInclusions(finalBlock, aggStart)
+
 	return &Result{
 		Block:             finalBlock,
 		AttestationProofs: plan.proofs,
@@ -26,6 +26,10 @@ type Input struct {
 	Payloads          []AttestationPayload
 	RequiredJustified *types.Checkpoint
 	ProofMerger       attestationproof.MergeProvider
+
+	// MaxAttestationsData caps distinct AttestationData in the block. Zero
+	// means types.Spec().MaxAttestationsData.
+	MaxAttestationsData uint64
 }
 
 type Result struct {
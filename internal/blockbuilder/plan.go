@@ -21,6 +21,7 @@ type planner struct {
 	knownRoots    KnownRoots
 	proofMerger   proofMerger
 	payloads      []AttestationPayload
+	maxData       int
 	processed     map[[32]byte]bool
 	attestations  []*types.AggregatedAttestation
 	proofs        []*types.AggregatedSignatureProof
@@ -52,6 +53,7 @@ func planAttestations(input Input) (planResult, error) {
 		knownRoots:    input.KnownBlockRoots,
 		proofMerger:   input.ProofMerger,
 		payloads:      sorted,
+		maxData:       maxAttestationsData(input),
 		processed:     make(map[[32]byte]bool),
 		state:         workingState,
 		progress:      captureProgress(workingState),
@@ -63,8 +65,15 @@ func planAttestations(input Input) (planResult, error) {
 	return planner.result(), nil
 }
 
+func maxAttestationsData(input Input) int {
+	if input.MaxAttestationsData == 0 {
+		return int(types.Spec().MaxAttestationsData)
+	}
+	return int(input.MaxAttestationsData)
+}
+
 func (p *planner) run() error {
-	for len(p.attestations) < int(types.MaxAttestationsData) {
+	for len(p.attestations) < p.maxData {
 		if !p.runRound() {
 			return nil
 		}
@@ -72,7 +81,7 @@ func (p *planner) run() error {
 		if err != nil {
 			return err
 		}
-		if len(p.attestations) >= int(types.MaxAttestationsData) {
+		if len(p.attestations) >= p.maxData {
 			p.full = true
 			return nil
 		}
@@ -87,7 +96,7 @@ func (p *planner) run() error {
 func (p *planner) runRound() bool {
 	added := false
 	for _, payload := range p.payloads {
-		if len(p.attestations) >= int(types.MaxAttestationsData) {
+		if len(p.attestations) >= p.maxData {
 			return added
 		}
 		if p.tryPayload(payload) {
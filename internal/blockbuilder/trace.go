@@ -0,0 +1,39 @@
+package blockbuilder
+
+import (
+	"time"
+
+	"github.com/geanlabs/gean/internal/tracing"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// traceInclusions emits one span per attestation packed into block, in the
+// trace of its attestation data, covering the build from start.
+func traceInclusions(block *types.Block, start time.Time) {
+	if !tracing.Enabled() || block == nil || block.Body == nil {
+		return
+	}
+	end := time.Now()
+	for _, att := range block.Body.Attestations {
+		if att == nil || att.Data == nil {
+			continue
+		}
+		trace, ok := tracing.TraceFor(att.Data)
+		if !ok {
+			continue
+		}
+		tracing.Emit(tracing.Span{
+			Trace: trace,
+			ID:    tracing.NewSpanID(),
+			Name:  "block.include",
+			Start: start,
+			End:   end,
+			Attrs: []tracing.Attr{
+				tracing.Uint("attestation.slot", att.Data.Slot),
+				tracing.Uint("block.slot", block.Slot),
+				tracing.Uint("block.proposer", block.ProposerIndex),
+				tracing.Uint("aggregation.participants", types.BitlistCount(att.AggregationBits)),
+			},
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package blockbuilder
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/tracing"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestTraceInclusionsExportsSpanPerAttestation(t *testing.T) {
+	bodies := make(chan []byte, 4)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer collector.Close()
+
+	exp := tracing.NewExporter(collector.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	go exp.Run(ctx)
+	tracing.Enable(exp, 1)
+	defer tracing.Disable()
+
+	data := mockAttestationData()
+	block := newBlock(11, 2, [32]byte{}, []*types.AggregatedAttestation{
+		{AggregationBits: types.BitlistFromIndices([]uint64{0, 1, 3}), Data: data},
+	})
+	traceInclusions(block, time.Now())
+	cancel()
+	exp.Wait()
+
+	var req struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID string `json:"traceId"`
+					Name    string `json:"name"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	select {
+	case body := <-bodies:
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	default:
+		t.Fatal("collector received nothing")
+	}
+
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	root := hashAttestationData(t, data)
+	if len(spans) != 1 || spans[0].Name != "block.include" || spans[0].TraceID != hex.EncodeToString(root[:16]) {
+		t.Fatalf("spans=%+v", spans)
+	}
+}
@@ -10,15 +10,33 @@ import (
 	"github.com/geanlabs/gean/internal/types"
 )
 
+// Import stages reported to a StageFunc.
+const (
+	StageSignatures = "signatures"
+	StageSTF        = "state_transition"
+	StagePersist    = "persist"
+)
+
+// StageFunc receives the start and duration of each import stage that ran.
+type StageFunc func(stage string, start time.Time, elapsed time.Duration)
+
 func OnBlock(s *store.ConsensusStore, signedBlock *types.SignedBlock) error {
-	return onBlockCore(s, signedBlock, true)
+	return onBlockCore(s, signedBlock, true, nil)
+}
+
+// OnBlockObserved is OnBlock with per-stage timings reported to observe.
+func OnBlockObserved(s *store.ConsensusStore, signedBlock *types.SignedBlock, observe StageFunc) error {
+	return onBlockCore(s, signedBlock, true, observe)
 }
 
 func OnBlockWithoutVerification(s *store.ConsensusStore, signedBlock *types.SignedBlock) error {
-	return onBlockCore(s, signedBlock, false)
+	return onBlockCore(s, signedBlock, false, nil)
 }
 
-func onBlockCore(s *store.ConsensusStore, signedBlock *types.SignedBlock, verify bool) error {
+func onBlockCore(s *store.ConsensusStore, signedBlock *types.SignedBlock, verify bool, observe StageFunc) error {
+	if observe == nil {
+		observe = func(string, time.Time, time.Duration) {}
+	}
 	start := time.Now()
 	if err := validateStore(s); err != nil {
 		return err
@@ -45,7 +63,7 @@ func onBlockCore(s *store.ConsensusStore, signedBlock *types.SignedBlock, verify
 		}
 	}
 
-	if err := validateBlockAttestations(block); err != nil {
+	if err := validateBlockAttestations(block, s.Spec().MaxAttestationsData); err != nil {
 		return err
 	}
 
@@ -53,6 +71,7 @@ func onBlockCore(s *store.ConsensusStore, signedBlock *types.SignedBlock, verify
 		verifyStart := time.Now()
 		err := verifyBlockSignatures(s, signedBlock, parentState)
 		metrics.ObserveBlockSignatureVerificationTime(time.Since(verifyStart).Seconds())
+		observe(StageSignatures, verifyStart, time.Since(verifyStart))
 		if err != nil {
 			return err
 		}
@@ -60,13 +79,16 @@ func onBlockCore(s *store.ConsensusStore, signedBlock *types.SignedBlock, verify
 
 	stfStart := time.Now()
 	postState, err := transitionState(parentState, block)
+	observe(StageSTF, stfStart, time.Since(stfStart))
 	if err != nil {
 		return &store.StoreError{Kind: store.ErrStateTransitionFailed, Message: fmt.Sprintf("state transition: %v", err)}
 	}
 	metrics.ObserveSTFTime(time.Since(stfStart).Seconds())
 
 	postState.LatestBlockHeader.StateRoot = block.StateRoot
+	persistStart := time.Now()
 	finalizedAdvanced, err := persistBlock(s, blockRoot, signedBlock, postState)
+	observe(StagePersist, persistStart, time.Since(persistStart))
 	if err != nil {
 		return err
 	}
@@ -36,7 +36,7 @@ func validateSignedBlock(signedBlock *types.SignedBlock, verify bool) (*types.Bl
 	return signedBlock.Block, nil
 }
 
-func validateBlockAttestations(block *types.Block) error {
+func validateBlockAttestations(block *types.Block, maxData uint64) error {
 	seen := make(map[[32]byte]bool)
 	for _, att := range block.Body.Attestations {
 		if !validAttestationShape(att) {
@@ -56,10 +56,10 @@ func validateBlockAttestations(block *types.Block) error {
 		seen[dataRoot] = true
 	}
 
-	if len(seen) > int(types.MaxAttestationsData) {
+	if uint64(len(seen)) > maxData {
 		return &store.StoreError{
 			Kind:    store.ErrTooManyAttestationData,
-			Message: fmt.Sprintf("block has %d distinct AttestationData (max %d)", len(seen), types.MaxAttestationsData),
+			Message: fmt.Sprintf("block has %d distinct AttestationData (max %d)", len(seen), maxData),
 		}
 	}
 	return nil
@@ -55,7 +55,7 @@ func TestValidateSignedBlockRejectsMalformedEnvelope(t *testing.T) {
 }
 
 func TestValidateBlockAttestationsRejectsMalformedAttestation(t *testing.T) {
-	err := validateBlockAttestations(processorBlock(&types.AggregatedAttestation{}))
+	err := validateBlockAttestations(processorBlock(&types.AggregatedAttestation{}), types.MaxAttestationsData)
 	if err == nil {
 		t.Fatal("expected malformed attestation error")
 	}
@@ -68,7 +68,7 @@ func TestValidateBlockAttestationsRejectsDuplicateData(t *testing.T) {
 		&types.AggregatedAttestation{Data: data},
 	)
 
-	err := validateBlockAttestations(block)
+	err := validateBlockAttestations(block, types.MaxAttestationsData)
 	se, ok := err.(*store.StoreError)
 	if !ok || se.Kind != store.ErrDuplicateAttestationData {
 		t.Fatalf("expected duplicate attestation error, got %v", err)
@@ -21,6 +21,29 @@ func verifyBlockSignatures(
 		return &store.StoreError{Kind: store.ErrMissingParentState, Message: "parent state missing"}
 	}
 
+	if err := verifyProposerSignature(block, signedBlock.Signature, state); err != nil {
+		return err
+	}
+
+	jobs, err := buildVerifyJobs(s, block, signedBlock.Signature)
+	if err != nil {
+		return err
+	}
+	return runVerifyJobs(jobs)
+}
+
+func VerifyProposerSignature(signedBlock *types.SignedBlock, state *types.State) error {
+	block, err := validateSignedBlock(signedBlock, true)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return &store.StoreError{Kind: store.ErrMissingParentState, Message: "parent state missing"}
+	}
+	return verifyProposerSignature(block, signedBlock.Signature, state)
+}
+
+func verifyProposerSignature(block *types.Block, sigs *types.BlockSignatures, state *types.State) error {
 	proposer, err := validatorAt(state, block.ProposerIndex)
 	if err != nil {
 		return err
@@ -35,17 +58,12 @@ func verifyBlockSignatures(
 		return &store.StoreError{Kind: store.ErrProposerSignatureDecodingFailed, Message: fmt.Sprintf("proposer slot: %v", err)}
 	}
 
-	valid, err := xmss.VerifySignatureSSZ(proposer.ProposalPubkey, slot, blockRoot, signedBlock.Signature.ProposerSignature)
+	valid, err := xmss.VerifySignatureSSZ(proposer.ProposalPubkey, slot, blockRoot, sigs.ProposerSignature)
 	if err != nil {
 		return &store.StoreError{Kind: store.ErrProposerSignatureDecodingFailed, Message: fmt.Sprintf("proposer sig decode: %v", err)}
 	}
 	if !valid {
 		return &store.StoreError{Kind: store.ErrProposerSignatureVerificationFailed, Message: "proposer signature invalid"}
 	}
-
-	jobs, err := buildVerifyJobs(s, block, signedBlock.Signature)
-	if err != nil {
-		return err
-	}
-	return runVerifyJobs(jobs)
+	return nil
 }
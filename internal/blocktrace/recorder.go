@@ -0,0 +1,251 @@
+package blocktrace
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/geanlabs/gean/internal/tracing"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// Stages recorded by the node itself; import stages come from blockprocessor.
+const (
+	StageQueue         = "queue"
+	StagePendingParent = "pending_parent"
+	StageHeadUpdate    = "head_update"
+)
+
+const (
+	OutcomePending  = "pending"
+	OutcomeImported = "imported"
+	OutcomeFailed   = "failed"
+)
+
+const DefaultCapacity = 256
+
+type Stage struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Timeline is the import history of one block. ReceivedAt is zero for blocks
+// that did not arrive through the block channel, such as our own proposals.
+type Timeline struct {
+	Root       [32]byte
+	Slot       uint64
+	Proposer   uint64
+	Local      bool
+	SlotStart  time.Time
+	ReceivedAt time.Time
+	Stages     []Stage
+	Outcome    string
+	Error      string
+
+	pendingSince time.Time
+}
+
+// Recorder keeps the timelines of the most recent blocks. All methods are safe
+// for concurrent use and are no-ops on a nil Recorder.
+type Recorder struct {
+	mu        sync.Mutex
+	capacity  int
+	slotStart func(slot uint64) time.Time
+	now       func() time.Time
+
+	arrivals  map[*types.SignedBlock]time.Time
+	timelines map[[32]byte]*Timeline
+	order     [][32]byte
+}
+
+func New(capacity int, slotStart func(slot uint64) time.Time) *Recorder {
+	return &Recorder{
+		capacity:  capacity,
+		slotStart: slotStart,
+		now:       time.Now,
+		arrivals:  make(map[*types.SignedBlock]time.Time),
+		timelines: make(map[[32]byte]*Timeline),
+	}
+}
+
+// Received notes when a block was handed to the node, before it is queued.
+func (r *Recorder) Received(block *types.SignedBlock) {
+	if r == nil || block == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.arrivals) < r.capacity {
+		r.arrivals[block] = r.now()
+	}
+}
+
+// Discard drops the arrival of a block that will not be imported.
+func (r *Recorder) Discard(block *types.SignedBlock) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.arrivals, block)
+}
+
+// Begin starts or resumes the timeline for root, closing the queue and
+// pending-parent waits that led here.
+func (r *Recorder) Begin(block *types.SignedBlock, root [32]byte, local bool) {
+	if r == nil || block == nil || block.Block == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	tl := r.timelineLocked(root, block.Block)
+	tl.Local = tl.Local || local
+	tl.Outcome = ""
+	tl.Error = ""
+	if arrival, ok := r.arrivals[block]; ok {
+		delete(r.arrivals, block)
+		if tl.ReceivedAt.IsZero() {
+			tl.ReceivedAt = arrival
+		}
+		tl.Stages = append(tl.Stages, Stage{Name: StageQueue, Start: arrival, Duration: now.Sub(arrival)})
+	}
+	if !tl.pendingSince.IsZero() {
+		tl.Stages = append(tl.Stages, Stage{Name: StagePendingParent, Start: tl.pendingSince, Duration: now.Sub(tl.pendingSince)})
+		tl.pendingSince = time.Time{}
+	}
+}
+
+func (r *Recorder) Stage(root [32]byte, name string, start time.Time, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tl := r.timelines[root]; tl != nil {
+		tl.Stages = append(tl.Stages, Stage{Name: name, Start: start, Duration: elapsed})
+	}
+}
+
+// Pending marks the block as waiting for its parent.
+func (r *Recorder) Pending(root [32]byte) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tl := r.timelines[root]; tl != nil {
+		tl.Outcome = OutcomePending
+		tl.pendingSince = r.now()
+	}
+}
+
+// Finish records the import result and emits the timeline as spans when
+// tracing is enabled.
+func (r *Recorder) Finish(root [32]byte, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	tl := r.timelines[root]
+	if tl == nil {
+		r.mu.Unlock()
+		return
+	}
+	tl.Outcome = OutcomeImported
+	if err != nil {
+		tl.Outcome = OutcomeFailed
+		tl.Error = err.Error()
+	}
+	snapshot := tl.clone()
+	end := r.now()
+	r.mu.Unlock()
+
+	if tracing.Enabled() {
+		tracing.Emit(snapshot.Spans(end)...)
+	}
+}
+
+func (r *Recorder) Timeline(root [32]byte) (Timeline, bool) {
+	if r == nil {
+		return Timeline{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tl := r.timelines[root]
+	if tl == nil {
+		return Timeline{}, false
+	}
+	return tl.clone(), true
+}
+
+func (r *Recorder) timelineLocked(root [32]byte, block *types.Block) *Timeline {
+	if tl := r.timelines[root]; tl != nil {
+		return tl
+	}
+	if len(r.order) >= r.capacity {
+		delete(r.timelines, r.order[0])
+		r.order = r.order[1:]
+	}
+	tl := &Timeline{Root: root, Slot: block.Slot, Proposer: block.ProposerIndex}
+	if r.slotStart != nil {
+		tl.SlotStart = r.slotStart(block.Slot)
+	}
+	r.timelines[root] = tl
+	r.order = append(r.order, root)
+	return tl
+}
+
+func (tl *Timeline) clone() Timeline {
+	out := *tl
+	out.Stages = append([]Stage(nil), tl.Stages...)
+	return out
+}
+
+// Spans converts the timeline into a root "block" span ending at end, with
+// one child span per stage.
+func (tl Timeline) Spans(end time.Time) []tracing.Span {
+	start := tl.ReceivedAt
+	for _, st := range tl.Stages {
+		if start.IsZero() || st.Start.Before(start) {
+			start = st.Start
+		}
+	}
+	if start.IsZero() {
+		start = end
+	}
+
+	root := tracing.Span{
+		Trace: tracing.TraceIDFromRoot(tl.Root),
+		ID:    tracing.NewSpanID(),
+		Name:  "block",
+		Start: start,
+		End:   end,
+		Attrs: []tracing.Attr{
+			tracing.String("block.root", fmt.Sprintf("0x%x", tl.Root)),
+			tracing.Uint("block.slot", tl.Slot),
+			tracing.Uint("block.proposer", tl.Proposer),
+			tracing.Bool("block.local", tl.Local),
+			tracing.String("block.outcome", tl.Outcome),
+		},
+		Error: tl.Error,
+	}
+	if !tl.ReceivedAt.IsZero() && !tl.SlotStart.IsZero() {
+		root.Attrs = append(root.Attrs, tracing.Int("block.receive_delay_ms", tl.ReceivedAt.Sub(tl.SlotStart).Milliseconds()))
+	}
+
+	spans := []tracing.Span{root}
+	for _, st := range tl.Stages {
+		spans = append(spans, tracing.Span{
+			Trace:  root.Trace,
+			ID:     tracing.NewSpanID(),
+			Parent: root.ID,
+			Name:   "block." + st.Name,
+			Start:  st.Start,
+			End:    st.Start.Add(st.Duration),
+		})
+	}
+	return spans
+}
@@ -0,0 +1,90 @@
+package blocktrace
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func testRecorder(capacity int) (*Recorder, *time.Time) {
+	now := time.Unix(1000, 0)
+	r := New(capacity, func(slot uint64) time.Time { return time.Unix(int64(996+slot*4), 0) })
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func signedBlock(slot uint64) *types.SignedBlock {
+	return &types.SignedBlock{Block: &types.Block{Slot: slot, ProposerIndex: 3}}
+}
+
+func TestRecorderTimeline(t *testing.T) {
+	r, now := testRecorder(8)
+	block, root := signedBlock(1), [32]byte{1}
+
+	r.Received(block)
+	*now = now.Add(20 * time.Millisecond)
+	r.Begin(block, root, false)
+	r.Pending(root)
+
+	*now = now.Add(time.Second)
+	reread := signedBlock(1)
+	r.Begin(reread, root, false)
+	r.Stage(root, "state_transition", *now, 5*time.Millisecond)
+	r.Finish(root, nil)
+
+	tl, ok := r.Timeline(root)
+	if !ok || tl.Outcome != OutcomeImported || tl.Slot != 1 || tl.Proposer != 3 {
+		t.Fatalf("timeline=%+v ok=%v", tl, ok)
+	}
+	if got := tl.ReceivedAt.Sub(tl.SlotStart); got != 0 {
+		t.Fatalf("receive delay=%s, want 0 (received at slot start)", got)
+	}
+	want := []struct {
+		name string
+		dur  time.Duration
+	}{{StageQueue, 20 * time.Millisecond}, {StagePendingParent, time.Second}, {"state_transition", 5 * time.Millisecond}}
+	if len(tl.Stages) != len(want) {
+		t.Fatalf("stages=%+v", tl.Stages)
+	}
+	for i, w := range want {
+		if tl.Stages[i].Name != w.name || tl.Stages[i].Duration != w.dur {
+			t.Fatalf("stage %d=%+v, want %s %s", i, tl.Stages[i], w.name, w.dur)
+		}
+	}
+
+	spans := tl.Spans(*now)
+	if len(spans) != 4 || spans[0].Name != "block" || spans[1].Parent != spans[0].ID || spans[0].Trace != spans[3].Trace {
+		t.Fatalf("spans=%+v", spans)
+	}
+	if spans[0].Trace[0] != 1 {
+		t.Fatalf("trace id should derive from the block root: %x", spans[0].Trace)
+	}
+}
+
+func TestRecorderFailureDiscardAndEviction(t *testing.T) {
+	r, _ := testRecorder(2)
+
+	dropped := signedBlock(1)
+	r.Received(dropped)
+	r.Discard(dropped)
+	if len(r.arrivals) != 0 {
+		t.Fatal("discarded arrival kept")
+	}
+
+	r.Begin(signedBlock(1), [32]byte{1}, true)
+	r.Finish([32]byte{1}, errors.New("bad signature"))
+	if tl, _ := r.Timeline([32]byte{1}); tl.Outcome != OutcomeFailed || tl.Error != "bad signature" || !tl.Local {
+		t.Fatalf("timeline=%+v", tl)
+	}
+
+	r.Begin(signedBlock(2), [32]byte{2}, false)
+	r.Begin(signedBlock(3), [32]byte{3}, false)
+	if _, ok := r.Timeline([32]byte{1}); ok {
+		t.Fatal("oldest timeline should be evicted at capacity")
+	}
+	if _, ok := r.Timeline([32]byte{3}); !ok {
+		t.Fatal("newest timeline missing")
+	}
+}
@@ -0,0 +1,96 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Virtual is a clock that only moves when Advance is called. Timers and
+// tickers fire in deadline order as time passes them; like time.Ticker, a
+// ticker whose reader is behind drops ticks rather than blocking.
+type Virtual struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*virtualWaiter
+}
+
+type virtualWaiter struct {
+	clock  *Virtual
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+func NewVirtual(start time.Time) *Virtual {
+	return &Virtual{now: start}
+}
+
+func (v *Virtual) Now() time.Time {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.now
+}
+
+func (v *Virtual) After(d time.Duration) <-chan time.Time {
+	return v.add(d, 0).ch
+}
+
+func (v *Virtual) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return v.add(d, d)
+}
+
+func (v *Virtual) add(d, period time.Duration) *virtualWaiter {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	w := &virtualWaiter{clock: v, at: v.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	if d <= 0 && period == 0 {
+		w.ch <- v.now
+		return w
+	}
+	v.waiters = append(v.waiters, w)
+	return w
+}
+
+// Advance moves the clock forward by d, firing every timer and ticker due
+// on the way.
+func (v *Virtual) Advance(d time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	end := v.now.Add(d)
+	for {
+		sort.SliceStable(v.waiters, func(i, j int) bool { return v.waiters[i].at.Before(v.waiters[j].at) })
+		if len(v.waiters) == 0 || v.waiters[0].at.After(end) {
+			break
+		}
+		w := v.waiters[0]
+		v.now = w.at
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			v.waiters = v.waiters[1:]
+		}
+	}
+	v.now = end
+}
+
+func (w *virtualWaiter) C() <-chan time.Time { return w.ch }
+
+func (w *virtualWaiter) Stop() {
+	v := w.clock
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for i, other := range v.waiters {
+		if other == w {
+			v.waiters = append(v.waiters[:i], v.waiters[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVirtualAdvanceFiresTimersInOrder(t *testing.T) {
+	start := time.Unix(1000, 0)
+	v := NewVirtual(start)
+	late := v.After(2 * time.Second)
+	early := v.After(time.Second)
+
+	v.Advance(500 * time.Millisecond)
+	select {
+	case <-early:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	v.Advance(2 * time.Second)
+	if got := <-early; !got.Equal(start.Add(time.Second)) {
+		t.Fatalf("early fired at %v, want %v", got, start.Add(time.Second))
+	}
+	if got := <-late; !got.Equal(start.Add(2 * time.Second)) {
+		t.Fatalf("late fired at %v, want %v", got, start.Add(2*time.Second))
+	}
+	if got := v.Now(); !got.Equal(start.Add(2500 * time.Millisecond)) {
+		t.Fatalf("now=%v, want %v", got, start.Add(2500*time.Millisecond))
+	}
+}
+
+func TestVirtualTickerDropsMissedTicksAndStops(t *testing.T) {
+	v := NewVirtual(time.Unix(0, 0))
+	ticker := v.NewTicker(time.Second)
+
+	v.Advance(3 * time.Second)
+	if got := <-ticker.C(); !got.Equal(time.Unix(1, 0)) {
+		t.Fatalf("first tick at %v, want 1s", got)
+	}
+	select {
+	case got := <-ticker.C():
+		t.Fatalf("unexpected buffered tick at %v", got)
+	default:
+	}
+
+	v.Advance(time.Second)
+	if got := <-ticker.C(); !got.Equal(time.Unix(4, 0)) {
+		t.Fatalf("tick at %v, want 4s", got)
+	}
+
+	ticker.Stop()
+	v.Advance(5 * time.Second)
+	select {
+	case got := <-ticker.C():
+		t.Fatalf("tick after Stop at %v", got)
+	default:
+	}
+}
+
+func TestVirtualAfterZeroFiresImmediately(t *testing.T) {
+	v := NewVirtual(time.Unix(5, 0))
+	select {
+	case got := <-v.After(0):
+		if !got.Equal(time.Unix(5, 0)) {
+			t.Fatalf("fired at %v, want 5s", got)
+		}
+	default:
+		t.Fatal("After(0) did not fire")
+	}
+}
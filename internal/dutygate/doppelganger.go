@@ -0,0 +1,115 @@
+package dutygate
+
+import "slices"
+
+type doppelgangerWatch struct {
+	fromSlot uint64
+	detected bool
+	cleared  bool
+}
+
+// EnableDoppelganger turns on doppelganger protection: validators passed to
+// WatchValidators stay disabled for slots slots while the node watches the
+// network for anyone else signing with their keys. Zero disables it.
+func (g *Gate) EnableDoppelganger(slots uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.doppelgangerSlots = slots
+	if g.watched == nil {
+		g.watched = make(map[uint64]*doppelgangerWatch)
+	}
+}
+
+// WatchValidators starts the observation window for validators at fromSlot.
+// Messages for earlier slots are ignored, since they may be our own from
+// before a restart.
+func (g *Gate) WatchValidators(fromSlot uint64, validatorIDs ...uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.doppelgangerSlots == 0 {
+		return
+	}
+	for _, vid := range validatorIDs {
+		g.watched[vid] = &doppelgangerWatch{fromSlot: fromSlot}
+	}
+}
+
+// WatchNewValidators is WatchValidators for validators that are not watched
+// yet; validators already watched keep their window and verdict.
+func (g *Gate) WatchNewValidators(fromSlot uint64, validatorIDs ...uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.doppelgangerSlots == 0 {
+		return
+	}
+	for _, vid := range validatorIDs {
+		if _, ok := g.watched[vid]; !ok {
+			g.watched[vid] = &doppelgangerWatch{fromSlot: fromSlot}
+		}
+	}
+}
+
+func (g *Gate) ForgetValidator(validatorID uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.watched, validatorID)
+}
+
+// Watching reports whether a message from validatorID at slot would count as
+// doppelganger evidence, letting callers skip verifying everything else.
+func (g *Gate) Watching(validatorID, slot uint64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	w, ok := g.watched[validatorID]
+	return ok && !w.detected && !w.cleared && slot >= w.fromSlot
+}
+
+// ObserveValidator records a verified message signed by validatorID that did
+// not come from this node. It returns true the first time the validator is
+// flagged; its duties then stay disabled until the node restarts.
+func (g *Gate) ObserveValidator(validatorID, slot uint64) bool {
+	g.mu.Lock()
+	w, ok := g.watched[validatorID]
+	if !ok || w.detected || w.cleared || slot < w.fromSlot {
+		g.mu.Unlock()
+		return false
+	}
+	w.detected = true
+	g.mu.Unlock()
+
+	g.emit(&Event{Reason: ReasonDoppelganger, Slot: slot, ValidatorIndex: validatorID})
+	return true
+}
+
+// AllowValidator gates a single validator's duty on doppelganger protection.
+// Validators that were never watched are always allowed.
+func (g *Gate) AllowValidator(duty string, validatorID, wallSlot uint64) bool {
+	g.mu.Lock()
+	w, ok := g.watched[validatorID]
+	if !ok || w.cleared {
+		g.mu.Unlock()
+		return true
+	}
+	if w.detected || wallSlot < w.fromSlot+g.doppelgangerSlots {
+		g.mu.Unlock()
+		return false
+	}
+	w.cleared = true
+	g.mu.Unlock()
+
+	g.emit(&Event{Reason: ReasonDoppelgangerCleared, Duty: duty, Slot: wallSlot, ValidatorIndex: validatorID})
+	return true
+}
+
+func (g *Gate) Doppelgangers() []uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var ids []uint64
+	for vid, w := range g.watched {
+		if w.detected {
+			ids = append(ids, vid)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,91 @@
+package dutygate
+
+import "testing"
+
+func TestDoppelgangerDisabledAllowsEveryValidator(t *testing.T) {
+	g := New()
+	g.WatchValidators(10, 1)
+	if !g.AllowValidator("attestation", 1, 10) {
+		t.Fatal("validators should be allowed without doppelganger protection")
+	}
+	if g.Watching(1, 10) {
+		t.Fatal("nothing should be watched when protection is off")
+	}
+}
+
+func TestDoppelgangerWindowThenClear(t *testing.T) {
+	var events []Event
+	g := New(func(event Event) { events = append(events, event) })
+	g.EnableDoppelganger(4)
+	g.WatchValidators(10, 1)
+
+	if g.AllowValidator("attestation", 1, 13) {
+		t.Fatal("duties should be disabled inside the observation window")
+	}
+	if !g.AllowValidator("attestation", 2, 13) {
+		t.Fatal("unwatched validator should be allowed")
+	}
+	if !g.AllowValidator("attestation", 1, 14) {
+		t.Fatal("duties should resume after the window")
+	}
+	if len(events) != 1 || events[0].Reason != ReasonDoppelgangerCleared || events[0].ValidatorIndex != 1 {
+		t.Fatalf("events=%v, want one cleared event for validator 1", events)
+	}
+	if g.ObserveValidator(1, 15) {
+		t.Fatal("observations after clearing should not flag the validator")
+	}
+}
+
+func TestDoppelgangerDetectionKeepsDutiesDisabled(t *testing.T) {
+	var events []Event
+	g := New(func(event Event) { events = append(events, event) })
+	g.EnableDoppelganger(4)
+	g.WatchValidators(10, 1, 2)
+
+	if g.ObserveValidator(1, 9) {
+		t.Fatal("messages before the window start must be ignored")
+	}
+	if !g.Watching(1, 11) {
+		t.Fatal("validator 1 should be watched at slot 11")
+	}
+	if !g.ObserveValidator(1, 11) {
+		t.Fatal("expected detection")
+	}
+	if g.ObserveValidator(1, 12) {
+		t.Fatal("detection should only be reported once")
+	}
+	if g.AllowValidator("block", 1, 100) {
+		t.Fatal("detected validator must stay disabled")
+	}
+	if !g.AllowValidator("block", 2, 100) {
+		t.Fatal("other validators should clear normally")
+	}
+	if got := g.Doppelgangers(); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("doppelgangers=%v, want [1]", got)
+	}
+	if len(events) != 2 || events[0].Reason != ReasonDoppelganger {
+		t.Fatalf("events=%v, want detection then clear", events)
+	}
+
+	g.ForgetValidator(1)
+	if !g.AllowValidator("block", 1, 100) {
+		t.Fatal("forgotten validator should no longer be gated")
+	}
+}
+
+func TestWatchNewValidatorsKeepsExistingWindows(t *testing.T) {
+	g := New()
+	g.EnableDoppelganger(4)
+	g.WatchValidators(10, 1)
+	g.WatchNewValidators(20, 1, 2)
+
+	if !g.AllowValidator("attestation", 1, 14) {
+		t.Fatal("validator 1 should keep its original window")
+	}
+	if g.AllowValidator("attestation", 2, 23) {
+		t.Fatal("validator 2 should wait for a window starting at slot 20")
+	}
+	if !g.AllowValidator("attestation", 2, 24) {
+		t.Fatal("validator 2 should be allowed after its window")
+	}
+}
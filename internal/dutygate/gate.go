@@ -14,6 +14,9 @@ const (
 	ReasonLocalLag     = "local_lag"
 	ReasonCaughtUp     = "caught_up"
 	ReasonNetworkStall = "network_stall"
+
+	ReasonDoppelganger        = "doppelganger"
+	ReasonDoppelgangerCleared = "doppelganger_cleared"
 )
 
 type Event struct {
@@ -24,12 +27,18 @@ type Event struct {
 	MaxStoredSlot uint64
 	Lag           uint64
 	NetworkLag    uint64
+
+	// ValidatorIndex is set for per-validator reasons (doppelganger).
+	ValidatorIndex uint64
 }
 
 type Gate struct {
 	mu           sync.Mutex
 	closed       bool
 	onTransition func(Event)
+
+	doppelgangerSlots uint64
+	watched           map[uint64]*doppelgangerWatch
 }
 
 func New(onTransition ...func(Event)) *Gate {
@@ -0,0 +1,138 @@
+package forkchoice
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// DebugNode is a proto-array node with both views of its weight: head weight
+// from known votes and safe-target weight from new votes, each with the best
+// child/descendant that view selects.
+type DebugNode struct {
+	Index              int
+	Slot               uint64
+	Root               [32]byte
+	ParentRoot         [32]byte
+	Parent             int
+	Weight             int64
+	BestChild          int
+	BestDescendant     int
+	SafeWeight         int64
+	SafeBestChild      int
+	SafeBestDescendant int
+	KnownVotes         int
+	NewVotes           int
+}
+
+type DebugVote struct {
+	ValidatorID uint64
+	Known       *VoteTarget
+	New         *VoteTarget
+}
+
+// Debug is a snapshot of the fork choice and the inputs behind its head and
+// safe-target decisions. Weights are recomputed from the vote store, so they
+// do not depend on which update ran last.
+type Debug struct {
+	Justified     types.Checkpoint
+	Finalized     types.Checkpoint
+	Head          [32]byte
+	SafeTarget    [32]byte
+	NumValidators uint64
+	QuorumScore   int64
+	Nodes         []DebugNode
+	Votes         []DebugVote
+}
+
+func (fc *ForkChoice) Debug(justified, finalized types.Checkpoint, numValidators uint64) Debug {
+	d := Debug{
+		Justified:     justified,
+		Finalized:     finalized,
+		Head:          justified.Root,
+		SafeTarget:    justified.Root,
+		NumValidators: numValidators,
+	}
+	if fc == nil || fc.array == nil {
+		return d
+	}
+
+	headView := fc.array.recomputed(fc.votes, true, 0)
+	d.Head = headView.FindHead(justified.Root)
+
+	safeView := headView
+	if numValidators > 0 {
+		d.QuorumScore = quorumScore(numValidators)
+		safeView = fc.array.recomputed(fc.votes, false, d.QuorumScore)
+		d.SafeTarget = safeView.FindHead(justified.Root)
+	}
+
+	d.Nodes = make([]DebugNode, len(fc.array.nodes))
+	for i, node := range headView.nodes {
+		safe := safeView.nodes[i]
+		d.Nodes[i] = DebugNode{
+			Index:              i,
+			Slot:               node.Slot,
+			Root:               node.Root,
+			ParentRoot:         node.ParentRoot,
+			Parent:             node.Parent,
+			Weight:             node.Weight,
+			BestChild:          node.BestChild,
+			BestDescendant:     node.BestDescendant,
+			SafeWeight:         safe.Weight,
+			SafeBestChild:      safe.BestChild,
+			SafeBestDescendant: safe.BestDescendant,
+		}
+	}
+
+	if fc.votes != nil {
+		for vid, tracker := range fc.votes.Votes {
+			if tracker == nil {
+				continue
+			}
+			vote := DebugVote{
+				ValidatorID: vid,
+				Known:       copyVoteTarget(tracker.LatestKnown),
+				New:         copyVoteTarget(tracker.LatestNew),
+			}
+			if t := vote.Known; t != nil && t.Index >= 0 && t.Index < len(d.Nodes) {
+				d.Nodes[t.Index].KnownVotes++
+			}
+			if t := vote.New; t != nil && t.Index >= 0 && t.Index < len(d.Nodes) {
+				d.Nodes[t.Index].NewVotes++
+			}
+			d.Votes = append(d.Votes, vote)
+		}
+	}
+	slices.SortFunc(d.Votes, func(a, b DebugVote) int { return cmp.Compare(a.ValidatorID, b.ValidatorID) })
+	return d
+}
+
+// recomputed returns a copy of the array with weights rebuilt from scratch
+// from one side of the vote store, leaving the live array and vote trackers
+// untouched.
+func (pa *ProtoArray) recomputed(votes *VoteStore, fromKnown bool, cutoffWeight int64) *ProtoArray {
+	out := &ProtoArray{nodes: pa.Nodes(), indices: pa.indices}
+	for i := range out.nodes {
+		out.nodes[i].Weight = 0
+	}
+
+	deltas := make([]int64, len(out.nodes))
+	if votes != nil {
+		for _, tracker := range votes.Votes {
+			if tracker == nil {
+				continue
+			}
+			target := tracker.LatestNew
+			if fromKnown {
+				target = tracker.LatestKnown
+			}
+			if target != nil && target.Index >= 0 && target.Index < len(deltas) {
+				deltas[target.Index]++
+			}
+		}
+	}
+	out.ApplyScoreChanges(deltas, cutoffWeight)
+	return out
+}
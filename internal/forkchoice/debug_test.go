@@ -0,0 +1,71 @@
+package forkchoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestDebugMatchesLiveDecisions(t *testing.T) {
+	rootA, rootB, rootC := root(1), root(2), root(3)
+	fc := New(0, rootA, [32]byte{})
+	fc.OnBlock(1, rootB, rootA)
+	fc.OnBlock(1, rootC, rootA)
+
+	fc.SetKnownVote(0, rootB, 1, makeAttData(rootB, 1))
+	fc.SetKnownVote(1, rootC, 1, makeAttData(rootC, 1))
+	fc.SetKnownVote(2, rootC, 1, makeAttData(rootC, 1))
+	for vid := uint64(0); vid < 3; vid++ {
+		fc.SetNewVote(vid, rootB, 2, makeAttData(rootB, 2))
+	}
+
+	head := fc.UpdateHead(rootA)
+	safe := fc.UpdateSafeTarget(rootA, 4)
+	before := fc.Nodes()
+
+	d := fc.Debug(types.Checkpoint{Root: rootA}, types.Checkpoint{Root: rootA}, 4)
+	if d.Head != head || d.SafeTarget != safe {
+		t.Fatalf("debug head=%x safe=%x, live head=%x safe=%x", d.Head[:1], d.SafeTarget[:1], head[:1], safe[:1])
+	}
+	if d.QuorumScore != quorumScore(4) || len(d.Votes) != 3 || d.Votes[0].ValidatorID != 0 {
+		t.Fatalf("quorum=%d votes=%+v", d.QuorumScore, d.Votes)
+	}
+
+	b, c := d.Nodes[fc.NodeIndex(rootB)], d.Nodes[fc.NodeIndex(rootC)]
+	if b.Weight != 1 || c.Weight != 2 || b.SafeWeight != 3 || c.SafeWeight != 0 {
+		t.Fatalf("weights b=%+v c=%+v", b, c)
+	}
+	if b.KnownVotes != 1 || b.NewVotes != 3 || c.KnownVotes != 2 {
+		t.Fatalf("vote counts b=%+v c=%+v", b, c)
+	}
+	if a := d.Nodes[0]; a.BestChild != c.Index || a.SafeBestChild != b.Index {
+		t.Fatalf("best children: %+v", a)
+	}
+
+	after := fc.Nodes()
+	for i := range before {
+		if before[i] != after[i] {
+			t.Fatalf("debug mutated node %d: %+v -> %+v", i, before[i], after[i])
+		}
+	}
+}
+
+func TestDebugWriteDOT(t *testing.T) {
+	rootA, rootB := root(1), root(2)
+	fc := New(0, rootA, [32]byte{})
+	fc.OnBlock(1, rootB, rootA)
+	fc.SetKnownVote(0, rootB, 1, makeAttData(rootB, 1))
+
+	var sb strings.Builder
+	d := fc.Debug(types.Checkpoint{Root: rootA}, types.Checkpoint{Root: rootA}, 3)
+	if err := d.WriteDOT(&sb); err != nil {
+		t.Fatalf("write dot: %v", err)
+	}
+	out := sb.String()
+	for _, want := range []string{"digraph forkchoice {", "n0 -> n1 [style=bold];", "[head]", "[safe,justified,finalized]"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("dot output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package forkchoice
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT renders the snapshot as a Graphviz digraph. Edges point from parent
+// to child; the edge to the head view's best child is bold and the safe-target
+// view's best child is dashed when it differs.
+func (d Debug) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph forkchoice {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [shape=box, fontname="monospace"];`)
+	fmt.Fprintf(bw, "  label=\"quorum=%d validators=%d\";\n", d.QuorumScore, d.NumValidators)
+
+	for _, n := range d.Nodes {
+		var marks []string
+		if n.Root == d.Head {
+			marks = append(marks, "head")
+		}
+		if n.Root == d.SafeTarget {
+			marks = append(marks, "safe")
+		}
+		if n.Root == d.Justified.Root {
+			marks = append(marks, "justified")
+		}
+		if n.Root == d.Finalized.Root {
+			marks = append(marks, "finalized")
+		}
+
+		label := fmt.Sprintf("slot %d\\n0x%x\\nweight %d (safe %d)\\nvotes %d known / %d new",
+			n.Slot, n.Root[:4], n.Weight, n.SafeWeight, n.KnownVotes, n.NewVotes)
+		if len(marks) > 0 {
+			label += "\\n[" + strings.Join(marks, ",") + "]"
+		}
+		style := ""
+		switch {
+		case n.Root == d.Head:
+			style = `, style=filled, fillcolor="palegreen"`
+		case n.Root == d.Finalized.Root:
+			style = `, style=filled, fillcolor="lightgrey"`
+		case n.Root == d.Justified.Root:
+			style = `, style=filled, fillcolor="lightblue"`
+		}
+		fmt.Fprintf(bw, "  n%d [label=\"%s\"%s];\n", n.Index, label, style)
+	}
+
+	for _, n := range d.Nodes {
+		if n.Parent < 0 || n.Parent >= len(d.Nodes) {
+			continue
+		}
+		parent := d.Nodes[n.Parent]
+		attrs := ""
+		switch {
+		case parent.BestChild == n.Index:
+			attrs = " [style=bold]"
+		case parent.SafeBestChild == n.Index:
+			attrs = " [style=dashed]"
+		}
+		fmt.Fprintf(bw, "  n%d -> n%d%s;\n", n.Parent, n.Index, attrs)
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
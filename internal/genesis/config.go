@@ -1,5 +1,11 @@
 package genesis
 
+import (
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
 type GenesisValidatorEntry struct {
 	AttestationPubkey string `yaml:"attestation_pubkey"`
 	ProposalPubkey    string `yaml:"proposal_pubkey"`
@@ -11,4 +17,49 @@ type GenesisConfig struct {
 	ActiveEpoch               uint64                  `yaml:"ACTIVE_EPOCH,omitempty"`
 	ValidatorCount            *uint64                 `yaml:"VALIDATOR_COUNT,omitempty"`
 	GenesisValidators         []GenesisValidatorEntry `yaml:"GENESIS_VALIDATORS"`
+
+	// Chain spec overrides applied on top of PRESET (devnet when unset).
+	Preset                     string  `yaml:"PRESET,omitempty"`
+	SecondsPerSlot             *uint64 `yaml:"SECONDS_PER_SLOT,omitempty"`
+	IntervalsPerSlot           *uint64 `yaml:"INTERVALS_PER_SLOT,omitempty"`
+	HistoricalRootsLimit       *uint64 `yaml:"HISTORICAL_ROOTS_LIMIT,omitempty"`
+	ValidatorRegistryLimit     *uint64 `yaml:"VALIDATOR_REGISTRY_LIMIT,omitempty"`
+	JustificationLookbackSlots *uint64 `yaml:"JUSTIFICATION_LOOKBACK_SLOTS,omitempty"`
+	MaxAttestationsData        *uint64 `yaml:"MAX_ATTESTATIONS_DATA,omitempty"`
+}
+
+// Spec resolves the chain spec: the named preset with any explicit keys from
+// config.yaml layered on top.
+func (gc *GenesisConfig) Spec() (types.ChainSpec, error) {
+	if gc == nil {
+		return types.ChainSpec{}, fmt.Errorf("genesis config is nil")
+	}
+	preset := gc.Preset
+	if preset == "" {
+		preset = types.PresetDevnet
+	}
+	spec, err := types.PresetSpec(preset)
+	if err != nil {
+		return types.ChainSpec{}, fmt.Errorf("PRESET: %w", err)
+	}
+	for _, o := range []struct {
+		value *uint64
+		dst   *uint64
+	}{
+		{gc.SecondsPerSlot, &spec.SecondsPerSlot},
+		{gc.IntervalsPerSlot, &spec.IntervalsPerSlot},
+		{gc.HistoricalRootsLimit, &spec.HistoricalRootsLimit},
+		{gc.ValidatorRegistryLimit, &spec.ValidatorRegistryLimit},
+		{gc.AttestationCommitteeCount, &spec.AttestationCommitteeCount},
+		{gc.JustificationLookbackSlots, &spec.JustificationLookbackSlots},
+		{gc.MaxAttestationsData, &spec.MaxAttestationsData},
+	} {
+		if o.value != nil {
+			*o.dst = *o.value
+		}
+	}
+	if err := spec.Validate(); err != nil {
+		return types.ChainSpec{}, err
+	}
+	return spec, nil
 }
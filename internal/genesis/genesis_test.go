@@ -169,12 +169,39 @@ func TestLoadGenesisConfigAcceptsLeanchainFields(t *testing.T) {
 	}
 }
 
-func TestLoadGenesisConfigRejectsWrongCommitteeCount(t *testing.T) {
+func TestLoadGenesisConfigSpecOverrides(t *testing.T) {
 	tmpFile := t.TempDir() + "/config.yaml"
-	writeGenesisConfig(t, tmpFile, "ATTESTATION_COMMITTEE_COUNT: 2\n"+testConfigYAML)
+	writeGenesisConfig(t, tmpFile, "PRESET: minimal\nATTESTATION_COMMITTEE_COUNT: 2\nSECONDS_PER_SLOT: 3\n"+testConfigYAML)
 
-	if _, err := LoadGenesisConfig(tmpFile); err == nil {
-		t.Fatal("expected error when ATTESTATION_COMMITTEE_COUNT disagrees with gean's const")
+	config, err := LoadGenesisConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	spec, err := config.Spec()
+	if err != nil {
+		t.Fatalf("spec: %v", err)
+	}
+	minimal, _ := types.PresetSpec(types.PresetMinimal)
+	want := minimal
+	want.AttestationCommitteeCount = 2
+	want.SecondsPerSlot = 3
+	if spec != want {
+		t.Fatalf("spec=%+v, want %+v", spec, want)
+	}
+}
+
+func TestLoadGenesisConfigRejectsInvalidSpec(t *testing.T) {
+	for _, prefix := range []string{
+		"ATTESTATION_COMMITTEE_COUNT: 0\n",
+		"PRESET: mainnet\n",
+		"INTERVALS_PER_SLOT: 4\n",
+		"HISTORICAL_ROOTS_LIMIT: 1000000\n",
+	} {
+		tmpFile := t.TempDir() + "/config.yaml"
+		writeGenesisConfig(t, tmpFile, prefix+testConfigYAML)
+		if _, err := LoadGenesisConfig(tmpFile); err == nil {
+			t.Fatalf("expected %q to be rejected", prefix)
+		}
 	}
 }
 
@@ -383,3 +410,32 @@ func writeGenesisConfig(t *testing.T, path, data string) {
 		t.Fatalf("write genesis config: %v", err)
 	}
 }
+
+func TestRootsLeavesStateUnchanged(t *testing.T) {
+	tmpFile := t.TempDir() + "/config.yaml"
+	writeGenesisConfig(t, tmpFile, testConfigYAML)
+	config, err := LoadGenesisConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	state, err := config.GenesisState()
+	if err != nil {
+		t.Fatalf("genesis state: %v", err)
+	}
+
+	stateRoot, blockRoot, err := Roots(state)
+	if err != nil {
+		t.Fatalf("roots: %v", err)
+	}
+	if !types.IsZeroRoot(state.LatestBlockHeader.StateRoot) {
+		t.Fatal("Roots filled in the state's header")
+	}
+	if want, _ := state.HashTreeRoot(); stateRoot != want {
+		t.Fatalf("state root %x, want %x", stateRoot, want)
+	}
+	header := *state.LatestBlockHeader
+	header.StateRoot = stateRoot
+	if want, _ := header.HashTreeRoot(); blockRoot != want {
+		t.Fatalf("block root %x, want %x", blockRoot, want)
+	}
+}
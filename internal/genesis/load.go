@@ -49,18 +49,18 @@ func (gc *GenesisConfig) validate() error {
 	if len(gc.GenesisValidators) == 0 {
 		return fmt.Errorf("GENESIS_VALIDATORS is empty")
 	}
-	if len(gc.GenesisValidators) > types.ValidatorRegistryLimit {
+	spec, err := gc.Spec()
+	if err != nil {
+		return err
+	}
+	if uint64(len(gc.GenesisValidators)) > spec.ValidatorRegistryLimit {
 		return fmt.Errorf("GENESIS_VALIDATORS has %d validators (max %d)",
-			len(gc.GenesisValidators), types.ValidatorRegistryLimit)
+			len(gc.GenesisValidators), spec.ValidatorRegistryLimit)
 	}
 	if gc.ValidatorCount != nil && *gc.ValidatorCount != uint64(len(gc.GenesisValidators)) {
 		return fmt.Errorf("VALIDATOR_COUNT=%d disagrees with len(GENESIS_VALIDATORS)=%d",
 			*gc.ValidatorCount, len(gc.GenesisValidators))
 	}
-	if gc.AttestationCommitteeCount != nil && *gc.AttestationCommitteeCount != types.AttestationCommitteeCount {
-		return fmt.Errorf("ATTESTATION_COMMITTEE_COUNT=%d disagrees with gean's %d",
-			*gc.AttestationCommitteeCount, types.AttestationCommitteeCount)
-	}
 
 	attestationPubkeys := make(map[[types.PubkeySize]byte]int, len(gc.GenesisValidators))
 	proposalPubkeys := make(map[[types.PubkeySize]byte]int, len(gc.GenesisValidators))
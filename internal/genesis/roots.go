@@ -0,0 +1,29 @@
+package genesis
+
+import (
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// Roots returns the state root and the root of the block whose header the
+// state carries, with the state root filled in as the store does at anchor
+// time. state is not modified.
+func Roots(state *types.State) (stateRoot, blockRoot [32]byte, err error) {
+	if state == nil || state.LatestBlockHeader == nil {
+		return types.ZeroRoot, types.ZeroRoot, fmt.Errorf("state has no latest block header")
+	}
+	stateRoot, err = state.HashTreeRoot()
+	if err != nil {
+		return types.ZeroRoot, types.ZeroRoot, fmt.Errorf("state root: %w", err)
+	}
+	header := *state.LatestBlockHeader
+	if header.StateRoot == types.ZeroRoot {
+		header.StateRoot = stateRoot
+	}
+	blockRoot, err = header.HashTreeRoot()
+	if err != nil {
+		return types.ZeroRoot, types.ZeroRoot, fmt.Errorf("block root: %w", err)
+	}
+	return stateRoot, blockRoot, nil
+}
@@ -11,7 +11,12 @@ func (gc *GenesisConfig) GenesisState() (*types.State, error) {
 	if err != nil {
 		return nil, fmt.Errorf("build genesis validators: %w", err)
 	}
+	return StateFromValidators(gc.GenesisTime, validators)
+}
 
+// StateFromValidators builds the slot-0 state for an already validated
+// registry.
+func StateFromValidators(genesisTime uint64, validators []*types.Validator) (*types.State, error) {
 	emptyBody := &types.BlockBody{}
 	bodyRoot, err := emptyBody.HashTreeRoot()
 	if err != nil {
@@ -19,7 +24,7 @@ func (gc *GenesisConfig) GenesisState() (*types.State, error) {
 	}
 
 	return &types.State{
-		Config: &types.ChainConfig{GenesisTime: gc.GenesisTime},
+		Config: &types.ChainConfig{GenesisTime: genesisTime},
 		Slot:   0,
 		LatestBlockHeader: &types.BlockHeader{
 			Slot:          0,
@@ -0,0 +1,302 @@
+// Package keystore implements the EIP-2335 keystore format for hash-sig
+// secret keys. The layout matches EIP-2335 version 4; only the secret is an
+// SSZ-encoded XMSS key instead of a BLS scalar.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	Version = 4
+
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	cipherAES128CTR = "aes-128-ctr"
+	checksumSHA256  = "sha256"
+	prfHMACSHA256   = "hmac-sha256"
+	derivedKeyLen   = 32
+
+	// Upper bounds on KDF parameters read from a keystore, so an imported
+	// file cannot demand unbounded memory or CPU. n, p and the PBKDF2 rounds
+	// allow four times the EIP-2335 defaults and r is held at its default of
+	// 8; scrypt memory is 128*n*r bytes, 1 GiB at the cap.
+	maxDerivedKeyLen = 64
+	maxScryptN       = 1 << 20
+	maxScryptR       = 8
+	maxScryptP       = 4
+	maxPBKDF2Rounds  = 1 << 20
+)
+
+var (
+	ErrInvalidPassword = errors.New("keystore checksum mismatch (wrong password?)")
+	ErrUnsupported     = errors.New("unsupported keystore")
+)
+
+// Params selects the key derivation function. The defaults follow EIP-2335.
+type Params struct {
+	KDF        string
+	ScryptN    int
+	Iterations int
+}
+
+var (
+	DefaultScrypt = Params{KDF: KDFScrypt, ScryptN: 1 << 18}
+	DefaultPBKDF2 = Params{KDF: KDFPBKDF2, Iterations: 1 << 18}
+)
+
+type Keystore struct {
+	Crypto      Crypto `json:"crypto"`
+	Description string `json:"description"`
+	Pubkey      string `json:"pubkey"`
+	Path        string `json:"path"`
+	UUID        string `json:"uuid"`
+	Version     int    `json:"version"`
+}
+
+type Crypto struct {
+	KDF      Module `json:"kdf"`
+	Checksum Module `json:"checksum"`
+	Cipher   Module `json:"cipher"`
+}
+
+type Module struct {
+	Function string         `json:"function"`
+	Params   map[string]any `json:"params"`
+	Message  string         `json:"message"`
+}
+
+// Encrypt seals secret under password. pubkey and path are stored in the
+// clear so a keystore can be matched to a validator without decrypting it.
+func Encrypt(secret []byte, password string, pubkey []byte, path, description string, params Params) (*Keystore, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("keystore salt: %w", err)
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("keystore iv: %w", err)
+	}
+
+	kdf := Module{Function: params.KDF}
+	switch params.KDF {
+	case KDFScrypt:
+		kdf.Params = map[string]any{"dklen": derivedKeyLen, "n": params.ScryptN, "r": 8, "p": 1, "salt": hex.EncodeToString(salt)}
+	case KDFPBKDF2:
+		kdf.Params = map[string]any{"dklen": derivedKeyLen, "c": params.Iterations, "prf": prfHMACSHA256, "salt": hex.EncodeToString(salt)}
+	default:
+		return nil, fmt.Errorf("%w: kdf %q", ErrUnsupported, params.KDF)
+	}
+
+	dk, err := deriveKey(kdf, normalizePassword(password))
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTR(dk[:16], iv, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keystore{
+		Crypto: Crypto{
+			KDF:      kdf,
+			Checksum: Module{Function: checksumSHA256, Params: map[string]any{}, Message: hex.EncodeToString(checksum(dk, cipherText))},
+			Cipher:   Module{Function: cipherAES128CTR, Params: map[string]any{"iv": hex.EncodeToString(iv)}, Message: hex.EncodeToString(cipherText)},
+		},
+		Description: description,
+		Pubkey:      hex.EncodeToString(pubkey),
+		Path:        path,
+		UUID:        newUUID(),
+		Version:     Version,
+	}, nil
+}
+
+func (ks *Keystore) Decrypt(password string) ([]byte, error) {
+	if ks.Version != Version {
+		return nil, fmt.Errorf("%w: version %d", ErrUnsupported, ks.Version)
+	}
+	if ks.Crypto.Cipher.Function != cipherAES128CTR || ks.Crypto.Checksum.Function != checksumSHA256 {
+		return nil, fmt.Errorf("%w: cipher %q checksum %q", ErrUnsupported, ks.Crypto.Cipher.Function, ks.Crypto.Checksum.Function)
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("decode cipher message: %w", err)
+	}
+	wantSum, err := hex.DecodeString(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("decode checksum: %w", err)
+	}
+	iv, err := hexParam(ks.Crypto.Cipher.Params, "iv")
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("cipher iv has %d bytes, expected %d", len(iv), aes.BlockSize)
+	}
+
+	dk, err := deriveKey(ks.Crypto.KDF, normalizePassword(password))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checksum(dk, cipherText), wantSum) {
+		return nil, ErrInvalidPassword
+	}
+	return aesCTR(dk[:16], iv, cipherText)
+}
+
+func (ks *Keystore) PubkeyBytes() ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(ks.Pubkey, "0x"))
+}
+
+func (ks *Keystore) Marshal() ([]byte, error) {
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+func Parse(data []byte) (*Keystore, error) {
+	var ks Keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("parse keystore: %w", err)
+	}
+	return &ks, nil
+}
+
+// IsKeystore reports whether data looks like a JSON keystore rather than a
+// raw SSZ secret key.
+func IsKeystore(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed)
+}
+
+func deriveKey(kdf Module, password []byte) ([]byte, error) {
+	salt, err := hexParam(kdf.Params, "salt")
+	if err != nil {
+		return nil, err
+	}
+	dklen, err := intParam(kdf.Params, "dklen")
+	if err != nil {
+		return nil, err
+	}
+	if dklen < derivedKeyLen || dklen > maxDerivedKeyLen {
+		return nil, fmt.Errorf("%w: dklen %d", ErrUnsupported, dklen)
+	}
+
+	switch kdf.Function {
+	case KDFScrypt:
+		n, err := intParam(kdf.Params, "n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := intParam(kdf.Params, "r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := intParam(kdf.Params, "p")
+		if err != nil {
+			return nil, err
+		}
+		if n > maxScryptN || r > maxScryptR || p > maxScryptP {
+			return nil, fmt.Errorf("%w: scrypt n=%d r=%d p=%d exceeds n=%d r=%d p=%d", ErrUnsupported, n, r, p, maxScryptN, maxScryptR, maxScryptP)
+		}
+		dk, err := scrypt.Key(password, salt, n, r, p, dklen)
+		if err != nil {
+			return nil, fmt.Errorf("scrypt: %w", err)
+		}
+		return dk, nil
+	case KDFPBKDF2:
+		if prf, _ := kdf.Params["prf"].(string); prf != prfHMACSHA256 {
+			return nil, fmt.Errorf("%w: pbkdf2 prf %q", ErrUnsupported, prf)
+		}
+		c, err := intParam(kdf.Params, "c")
+		if err != nil {
+			return nil, err
+		}
+		if c > maxPBKDF2Rounds {
+			return nil, fmt.Errorf("%w: pbkdf2 c=%d exceeds %d", ErrUnsupported, c, maxPBKDF2Rounds)
+		}
+		dk, err := pbkdf2.Key(sha256.New, string(password), salt, c, dklen)
+		if err != nil {
+			return nil, fmt.Errorf("pbkdf2: %w", err)
+		}
+		return dk, nil
+	default:
+		return nil, fmt.Errorf("%w: kdf %q", ErrUnsupported, kdf.Function)
+	}
+}
+
+func checksum(dk, cipherText []byte) []byte {
+	h := sha256.New()
+	h.Write(dk[16:32])
+	h.Write(cipherText)
+	return h.Sum(nil)
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+// normalizePassword applies the EIP-2335 rules: NFKD normalisation, then
+// dropping C0, C1 and Delete control codes.
+func normalizePassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+	return []byte(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, normalized))
+}
+
+func hexParam(params map[string]any, name string) ([]byte, error) {
+	s, ok := params[name].(string)
+	if !ok {
+		return nil, fmt.Errorf("keystore param %q missing", name)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("keystore param %q: %w", name, err)
+	}
+	return b, nil
+}
+
+func intParam(params map[string]any, name string) (int, error) {
+	switch v := params[name].(type) {
+	case float64:
+		if v < 1 || v != float64(int(v)) {
+			return 0, fmt.Errorf("keystore param %q: invalid value %v", name, v)
+		}
+		return int(v), nil
+	case int:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("keystore param %q missing", name)
+	}
+}
+
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
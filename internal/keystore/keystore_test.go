@@ -0,0 +1,141 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// EIP-2335 pbkdf2 test vector.
+const eip2335PBKDF2Vector = `{
+	"crypto": {
+		"kdf": {"function": "pbkdf2", "params": {"dklen": 32, "c": 262144, "prf": "hmac-sha256", "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"}, "message": ""},
+		"checksum": {"function": "sha256", "params": {}, "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"},
+		"cipher": {"function": "aes-128-ctr", "params": {"iv": "264daa3f303d7259501c93d997d84fe6"}, "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"}
+	},
+	"description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+	"pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+	"path": "m/12381/60/0/0",
+	"uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+	"version": 4
+}`
+
+const eip2335Password = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+
+var testParams = Params{KDF: KDFScrypt, ScryptN: 1 << 4}
+
+func TestDecryptEIP2335Vector(t *testing.T) {
+	ks, err := Parse([]byte(eip2335PBKDF2Vector))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	secret, err := ks.Decrypt(eip2335Password)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	want := "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	if got := hex.EncodeToString(secret); got != want {
+		t.Fatalf("secret=%s, want %s", got, want)
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x5a, 0x01, 0xff}, 1000)
+	pubkey := []byte{0x01, 0x02, 0x03}
+	for _, params := range []Params{testParams, {KDF: KDFPBKDF2, Iterations: 16}} {
+		t.Run(params.KDF, func(t *testing.T) {
+			ks, err := Encrypt(secret, "correct horse", pubkey, "m/0/1", "validator 1 attestation", params)
+			if err != nil {
+				t.Fatalf("encrypt: %v", err)
+			}
+			data, err := ks.Marshal()
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if !IsKeystore(data) {
+				t.Fatal("encoded keystore not recognised")
+			}
+			parsed, err := Parse(data)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if parsed.Path != "m/0/1" || parsed.Pubkey != "010203" {
+				t.Fatalf("metadata path=%q pubkey=%q", parsed.Path, parsed.Pubkey)
+			}
+			got, err := parsed.Decrypt("correct horse")
+			if err != nil {
+				t.Fatalf("decrypt: %v", err)
+			}
+			if !bytes.Equal(got, secret) {
+				t.Fatal("decrypted secret differs")
+			}
+			if _, err := parsed.Decrypt("wrong"); !errors.Is(err, ErrInvalidPassword) {
+				t.Fatalf("wrong password err=%v, want ErrInvalidPassword", err)
+			}
+		})
+	}
+}
+
+func TestDecryptRejectsExcessiveKDFParams(t *testing.T) {
+	ks, err := Encrypt([]byte{0x01}, "pw", nil, "", "", testParams)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	for name, params := range map[string]map[string]any{
+		"scrypt n": {"n": float64(1 << 30)},
+		"scrypt r": {"r": float64(1024)},
+		"scrypt p": {"p": float64(1 << 20)},
+		"dklen":    {"dklen": float64(1 << 30)},
+	} {
+		mutated := *ks
+		mutated.Crypto.KDF.Params = map[string]any{}
+		for k, v := range ks.Crypto.KDF.Params {
+			mutated.Crypto.KDF.Params[k] = v
+		}
+		for k, v := range params {
+			mutated.Crypto.KDF.Params[k] = v
+		}
+		if _, err := mutated.Decrypt("pw"); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%s: err=%v, want ErrUnsupported", name, err)
+		}
+	}
+
+	pbkdf, err := Parse([]byte(strings.Replace(eip2335PBKDF2Vector, `"c": 262144`, `"c": 4294967295`, 1)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if _, err := pbkdf.Decrypt(eip2335Password); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("pbkdf2 err=%v, want ErrUnsupported", err)
+	}
+}
+
+func TestPasswordNormalisation(t *testing.T) {
+	ks, err := Encrypt([]byte{0x01}, "pass\x7fword", nil, "", "", testParams)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if _, err := ks.Decrypt("password"); err != nil {
+		t.Fatalf("control codes should be stripped: %v", err)
+	}
+}
+
+func TestIsKeystoreRejectsRawKeys(t *testing.T) {
+	if IsKeystore([]byte{0x7b, 0x00, 0x01}) {
+		t.Fatal("raw bytes starting with '{' should not be a keystore")
+	}
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pw.txt")
+	if err := os.WriteFile(path, []byte("secret\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	pw, err := FilePassword(path)("any.json")
+	if err != nil || pw != "secret" {
+		t.Fatalf("password=%q err=%v", pw, err)
+	}
+}
@@ -0,0 +1,66 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+var ErrNoPassword = errors.New("keystore password required: pass a password file or run on a terminal")
+
+// PasswordSource returns the password for a keystore file.
+type PasswordSource func(keystorePath string) (string, error)
+
+// ReadPasswordFile reads a password file, dropping one trailing newline.
+func ReadPasswordFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read password file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func FilePassword(path string) PasswordSource {
+	return func(string) (string, error) {
+		return ReadPasswordFile(path)
+	}
+}
+
+// PromptPassword asks once on the controlling terminal and reuses the answer
+// for every keystore. It fails when stdin is not a terminal.
+func PromptPassword(out io.Writer) PasswordSource {
+	var (
+		once     sync.Once
+		password string
+		err      error
+	)
+	return func(keystorePath string) (string, error) {
+		once.Do(func() {
+			fd := int(os.Stdin.Fd())
+			if !term.IsTerminal(fd) {
+				err = ErrNoPassword
+				return
+			}
+			fmt.Fprintf(out, "Keystore password: ")
+			var b []byte
+			b, err = term.ReadPassword(fd)
+			fmt.Fprintln(out)
+			password = strings.TrimRight(string(b), "\r")
+		})
+		return password, err
+	}
+}
+
+// PasswordFlag picks the file source when path is set and the terminal
+// prompt otherwise.
+func PasswordFlag(path string, out io.Writer) PasswordSource {
+	if path != "" {
+		return FilePassword(path)
+	}
+	return PromptPassword(out)
+}
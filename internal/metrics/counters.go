@@ -61,6 +61,15 @@ var (
 	metricPeerDisconnectionEvents = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "lean_peer_disconnection_events_total", Help: "Total peer disconnection events",
 	}, []string{"direction", "reason"})
+	metricValidatorMonitorAttestations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "lean_validator_monitor_attestations_total", Help: "Monitored validator attestations by outcome (seen, aggregated, included, missed)",
+	}, []string{"validator", "outcome"})
+	metricValidatorMonitorVotes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "lean_validator_monitor_votes_total", Help: "Monitored validator head/target/source votes checked against the canonical chain",
+	}, []string{"validator", "vote", "result"})
+	metricValidatorMonitorBlocks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "lean_validator_monitor_blocks_proposed_total", Help: "Blocks imported that were proposed by a monitored validator",
+	}, []string{"validator"})
 	metricBlockBuildingSuccess = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "lean_block_building_success_total", Help: "Successful block builds",
 	})
@@ -63,6 +63,12 @@ var (
 	metricGossipMeshPeers = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "lean_gossip_mesh_peers", Help: "Number of peers in the gossipsub mesh",
 	})
+	metricValidatorKeyRemainingEpochs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lean_validator_key_remaining_epochs", Help: "One-time signing epochs left before a validator key is exhausted",
+	}, []string{"validator", "role"})
+	metricValidatorDoppelganger = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lean_validator_doppelganger_detected", Help: "Set to 1 when another node was seen signing with this validator's keys",
+	}, []string{"validator"})
 	metricNodeSyncStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "lean_node_sync_status", Help: "Node sync status",
 	}, []string{"status"})
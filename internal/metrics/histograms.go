@@ -121,4 +121,9 @@ var (
 		Help:    "Elapsed time between clock ticks in seconds",
 		Buckets: []float64{0.4, 0.6, 0.75, 0.8, 0.805, 0.81, 0.815, 0.82, 0.825, 0.85, 0.9, 1.0, 1.2, 1.6},
 	})
+	metricValidatorMonitorInclusionDelay = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "lean_validator_monitor_inclusion_delay_slots",
+		Help:    "Slots between a monitored validator's attestation and its first inclusion in a block",
+		Buckets: []float64{1, 2, 3, 4, 6, 8, 16, 32},
+	}, []string{"validator"})
 )
@@ -1,5 +1,7 @@
 package metrics
 
+import "strconv"
+
 func IncAttestationsValid(n uint64)          { addUint(metricAttestationsValid, n) }
 func IncAttestationsInvalid()                { metricAttestationsInvalid.Inc() }
 func IncAttestationsBufferEvicted(n int)     { addCount(metricAttestationsBufferEvicted, n) }
@@ -32,3 +34,16 @@ func IncPeerConnection(direction, result string) {
 func IncPeerDisconnection(direction, reason string) {
 	metricPeerDisconnectionEvents.WithLabelValues(labelOrUnknown(direction), labelOrUnknown(reason)).Inc()
 }
+func IncValidatorMonitorAttestation(validator uint64, outcome string) {
+	metricValidatorMonitorAttestations.WithLabelValues(strconv.FormatUint(validator, 10), labelOrUnknown(outcome)).Inc()
+}
+func IncValidatorMonitorVote(validator uint64, vote string, correct bool) {
+	result := "incorrect"
+	if correct {
+		result = "correct"
+	}
+	metricValidatorMonitorVotes.WithLabelValues(strconv.FormatUint(validator, 10), labelOrUnknown(vote), result).Inc()
+}
+func IncValidatorMonitorBlock(validator uint64) {
+	metricValidatorMonitorBlocks.WithLabelValues(strconv.FormatUint(validator, 10)).Inc()
+}
@@ -1,5 +1,7 @@
 package metrics
 
+import "strconv"
+
 func ObserveBlockProcessingTime(seconds float64) {
 	observeNonNegative(metricBlockProcessingTime, seconds)
 }
@@ -61,3 +63,6 @@ func ObserveAggregationWorkerTotalTime(seconds float64) {
 func ObserveBlockSignatureVerificationTime(seconds float64) {
 	observeNonNegative(metricBlockSignatureVerificationTime, seconds)
 }
+func ObserveValidatorMonitorInclusionDelay(validator, slots uint64) {
+	metricValidatorMonitorInclusionDelay.WithLabelValues(strconv.FormatUint(validator, 10)).Observe(float64(slots))
+}
@@ -1,5 +1,11 @@
 package metrics
 
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
 func SetNodeInfo(name, version string) {
 	metricNodeInfo.WithLabelValues(labelOrUnknown(name), labelOrUnknown(version)).Set(1)
 }
@@ -29,6 +35,19 @@ func SetConnectedPeers(client string, n int) {
 	metricConnectedPeers.WithLabelValues(labelOrUnknown(client)).Set(countValue(n))
 }
 
+func SetValidatorKeyRemainingEpochs(validator uint64, role string, n uint64) {
+	metricValidatorKeyRemainingEpochs.
+		WithLabelValues(strconv.FormatUint(validator, 10), labelOrUnknown(role)).Set(float64(n))
+}
+
+func SetValidatorDoppelganger(validator uint64) {
+	metricValidatorDoppelganger.WithLabelValues(strconv.FormatUint(validator, 10)).Set(1)
+}
+
+func DeleteValidatorKeyRemainingEpochs(validator uint64) {
+	metricValidatorKeyRemainingEpochs.DeletePartialMatch(prometheus.Labels{"validator": strconv.FormatUint(validator, 10)})
+}
+
 func SetSyncStatus(status string) {
 	active := syncStatusLabel(status)
 	for _, s := range syncStatusLabels {
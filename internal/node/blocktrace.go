@@ -0,0 +1,28 @@
+package node
+
+import (
+	"time"
+
+	"github.com/geanlabs/gean/internal/blockprocessor"
+	"github.com/geanlabs/gean/internal/blocktrace"
+)
+
+func (e *Engine) traceStages(blockRoot [32]byte) blockprocessor.StageFunc {
+	return func(stage string, start time.Time, elapsed time.Duration) {
+		e.Trace.Stage(blockRoot, stage, start, elapsed)
+	}
+}
+
+func (e *Engine) tracedUpdateHead(blockRoot [32]byte) {
+	start := time.Now()
+	e.updateHead()
+	e.Trace.Stage(blockRoot, blocktrace.StageHeadUpdate, start, time.Since(start))
+}
+
+func (e *Engine) slotStartTime(slot uint64) time.Time {
+	cfg := e.Store.Config()
+	if cfg == nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(cfg.GenesisTime*1000 + slot*e.spec().MillisecondsPerSlot()))
+}
@@ -0,0 +1,62 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/geanlabs/gean/internal/blocktrace"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestBlockTimelineTracksQueueAndPendingParent(t *testing.T) {
+	e := makeTestEngine()
+	signedBlock := &types.SignedBlock{
+		Block:     &types.Block{Slot: 3, ParentRoot: [32]byte{0xBB}, Body: &types.BlockBody{}},
+		Signature: &types.BlockSignatures{},
+	}
+	blockRoot, err := signedBlock.Block.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("block root: %v", err)
+	}
+
+	e.OnBlock(signedBlock)
+	var queue []*types.SignedBlock
+	e.processOneBlock(<-e.BlockCh, &queue)
+
+	tl, ok := e.Trace.Timeline(blockRoot)
+	if !ok {
+		t.Fatal("no timeline recorded")
+	}
+	if tl.Outcome != blocktrace.OutcomePending || tl.ReceivedAt.IsZero() || tl.Slot != 3 {
+		t.Fatalf("timeline=%+v", tl)
+	}
+	if len(tl.Stages) != 1 || tl.Stages[0].Name != blocktrace.StageQueue {
+		t.Fatalf("stages=%+v, want queue only", tl.Stages)
+	}
+	if want := e.slotStartTime(3); !tl.SlotStart.Equal(want) {
+		t.Fatalf("slot start=%s, want %s", tl.SlotStart, want)
+	}
+}
+
+func TestMalformedBlocksDoNotFillArrivals(t *testing.T) {
+	e := makeTestEngine()
+	e.Trace = blocktrace.New(1, e.slotStartTime)
+
+	e.OnBlock(&types.SignedBlock{})
+	e.onBlock(<-e.BlockCh)
+
+	signedBlock := &types.SignedBlock{
+		Block:     &types.Block{Slot: 3, ParentRoot: [32]byte{0xBB}, Body: &types.BlockBody{}},
+		Signature: &types.BlockSignatures{},
+	}
+	blockRoot, err := signedBlock.Block.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("block root: %v", err)
+	}
+	e.OnBlock(signedBlock)
+	e.onBlock(<-e.BlockCh)
+
+	tl, ok := e.Trace.Timeline(blockRoot)
+	if !ok || tl.ReceivedAt.IsZero() {
+		t.Fatalf("timeline=%+v ok=%v, want the arrival recorded", tl, ok)
+	}
+}
@@ -1,17 +1,37 @@
 package node
 
-import "github.com/geanlabs/gean/internal/types"
+import (
+	"time"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func (e *Engine) now() time.Time {
+	if e == nil {
+		return time.Now()
+	}
+	return clock.Or(e.Clock).Now()
+}
+
+// spec is the chain spec of the engine's store.
+func (e *Engine) spec() types.ChainSpec {
+	if e == nil {
+		return types.Spec()
+	}
+	return e.Store.Spec()
+}
 
 func (e *Engine) currentSlot(timestampMs uint64) uint64 {
 	if e == nil || e.Store == nil {
 		return 0
 	}
-	return types.CurrentSlot(e.Store.Config().GenesisTime, timestampMs)
+	return e.Store.Spec().CurrentSlot(e.Store.Config().GenesisTime, timestampMs)
 }
 
 func (e *Engine) currentInterval(timestampMs uint64) uint64 {
 	if e == nil || e.Store == nil {
 		return 0
 	}
-	return types.CurrentInterval(e.Store.Config().GenesisTime, timestampMs)
+	return e.Store.Spec().CurrentInterval(e.Store.Config().GenesisTime, timestampMs)
 }
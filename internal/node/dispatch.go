@@ -25,6 +25,9 @@ func (e *Engine) dispatch(ctx context.Context, ticks <-chan time.Time) {
 
 		case root := <-e.FailedRootCh:
 			e.onFailedRoot(root)
+
+		case fn := <-e.CallCh:
+			fn()
 		}
 	}
 }
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/attestation"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// EnableDoppelgangerDetection keeps every local validator's duties disabled
+// for slots slots while gossip is watched for the same keys signing
+// elsewhere. Zero leaves detection off.
+func (e *Engine) EnableDoppelgangerDetection(slots uint64) {
+	if slots == 0 || e.DutyGate == nil {
+		return
+	}
+	e.DutyGate.EnableDoppelganger(slots)
+	fromSlot := e.doppelgangerStartSlot()
+	var local []uint64
+	if e.Keys != nil {
+		local = e.Keys.ValidatorIDs()
+	}
+	e.DutyGate.WatchValidators(fromSlot, local...)
+	logger.Info(logger.Validator, "doppelganger detection: duties disabled until slot=%d validators=%d",
+		fromSlot+slots, len(local))
+}
+
+// allowSubmittedDuty applies doppelganger protection to the validator API.
+// Validators of a remote validator client are not known in advance, so each
+// one is watched from its first request on.
+func (e *Engine) allowSubmittedDuty(duty string, validatorID uint64) error {
+	if e.DutyGate == nil {
+		return nil
+	}
+	e.DutyGate.WatchNewValidators(e.doppelgangerStartSlot(), validatorID)
+	wallSlot := e.currentSlot(uint64(e.now().UnixMilli()))
+	if e.DutyGate.AllowValidator(duty, validatorID, wallSlot) {
+		return nil
+	}
+	return &store.StoreError{
+		Kind:    store.ErrValidatorDutyDisabled,
+		Message: fmt.Sprintf("%s duty for validator %d disabled by doppelganger protection at slot %d", duty, validatorID, wallSlot),
+	}
+}
+
+// doppelgangerStartSlot skips the current slot, which may hold our own
+// messages from before a restart.
+func (e *Engine) doppelgangerStartSlot() uint64 {
+	return e.currentSlot(uint64(e.now().UnixMilli())) + 1
+}
+
+func (e *Engine) observeDoppelgangerAttestation(att *types.SignedAttestation) {
+	if e.DutyGate == nil || att == nil || att.Data == nil || !e.DutyGate.Watching(att.ValidatorID, att.Data.Slot) {
+		return
+	}
+	dataRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		return
+	}
+	if err := attestation.VerifyGossipAttestation(e.Store, att.ValidatorID, att.Data, dataRoot, att.Signature[:]); err != nil {
+		logger.Warn(logger.Validator, "ignoring unverifiable attestation for watched validator=%d slot=%d: %v",
+			att.ValidatorID, att.Data.Slot, err)
+		return
+	}
+	e.DutyGate.ObserveValidator(att.ValidatorID, att.Data.Slot)
+}
+
+// observeDoppelgangerBlock is called after the block's proposer signature
+// has been verified by import.
+func (e *Engine) observeDoppelgangerBlock(block *types.Block) {
+	if e.DutyGate == nil || block == nil {
+		return
+	}
+	e.DutyGate.ObserveValidator(block.ProposerIndex, block.Slot)
+}
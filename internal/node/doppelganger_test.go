@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+func TestDoppelgangerBlockDisablesValidator(t *testing.T) {
+	e := makeTestEngine()
+	e.Keys = xmss.NewSignerKeyManager(
+		map[uint64]xmss.Signer{0: rangeSigner{end: 1 << 20}, 1: rangeSigner{end: 1 << 20}},
+		map[uint64]xmss.Signer{0: rangeSigner{end: 1 << 20}, 1: rangeSigner{end: 1 << 20}},
+	)
+	e.EnableDoppelgangerDetection(4)
+	from := e.doppelgangerStartSlot()
+
+	if e.DutyGate.AllowValidator("attestation", 0, from) {
+		t.Fatal("duties should wait for the observation window")
+	}
+	e.observeDoppelgangerBlock(&types.Block{Slot: from - 1, ProposerIndex: 0})
+	if len(e.DutyGate.Doppelgangers()) != 0 {
+		t.Fatal("blocks before the window must not count")
+	}
+	e.observeDoppelgangerBlock(&types.Block{Slot: from + 1, ProposerIndex: 1})
+
+	if !e.DutyGate.AllowValidator("attestation", 0, from+4) {
+		t.Fatal("validator 0 should be enabled after the window")
+	}
+	if e.DutyGate.AllowValidator("attestation", 1, from+4) {
+		t.Fatal("validator 1 was seen proposing elsewhere and must stay disabled")
+	}
+}
+
+func TestDoppelgangerGatesValidatorAPI(t *testing.T) {
+	e := makeTestEngine()
+	e.CallCh = make(chan func())
+	slotDuration := time.Duration(types.Spec().MillisecondsPerSlot()) * time.Millisecond
+	vc := clock.NewVirtual(time.Unix(int64(e.Store.Config().GenesisTime), 0).Add(10 * slotDuration))
+	e.Clock = vc
+	e.EnableDoppelgangerDetection(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	wantKind := func(err error, kind store.StoreErrorKind) {
+		t.Helper()
+		var storeErr *store.StoreError
+		if !errors.As(err, &storeErr) || storeErr.Kind != kind {
+			t.Fatalf("err=%v, want kind %d", err, kind)
+		}
+	}
+
+	wantKind(e.SubmitAttestation(ctx, genesisAttestation(3)), store.ErrValidatorDutyDisabled)
+	_, err := e.ProduceBlock(ctx, 10, 3)
+	wantKind(err, store.ErrValidatorDutyDisabled)
+	signedBlock := &types.SignedBlock{Block: &types.Block{Slot: 10, ProposerIndex: 3, Body: &types.BlockBody{}}, Signature: &types.BlockSignatures{}}
+	wantKind(e.ImportBlock(ctx, signedBlock), store.ErrValidatorDutyDisabled)
+	wantKind(e.CheckBlockForBroadcast(ctx, signedBlock), store.ErrValidatorDutyDisabled)
+
+	vc.Advance(3 * slotDuration)
+	wantKind(e.SubmitAttestation(ctx, genesisAttestation(3)), store.ErrSignatureVerificationFailed)
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/geanlabs/gean/internal/attestation"
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/metrics"
+	"github.com/geanlabs/gean/internal/tracing"
 	"github.com/geanlabs/gean/internal/types"
-	"github.com/geanlabs/gean/xmss"
 )
 
 func (e *Engine) produceAttestations(slot uint64) {
@@ -25,15 +25,27 @@ func (e *Engine) produceAttestations(slot uint64) {
 	if attData == nil {
 		return
 	}
+	trace, traced := tracing.TraceFor(attData)
 
 	for _, vid := range e.Keys.ValidatorIDs() {
+		if e.DutyGate != nil && !e.DutyGate.AllowValidator("attestation", vid, slot) {
+			continue
+		}
 		prodStart := time.Now()
+		var span *tracing.Active
+		if traced {
+			span = tracing.Start(trace, "attestation.produce",
+				tracing.Uint("attestation.slot", slot), tracing.Uint("validator.index", vid))
+		}
 
 		sStart := time.Now()
+		signSpan := span.Child("attestation.sign")
 		sig, err := e.Keys.SignAttestation(vid, attData)
+		signSpan.End(err)
 		metrics.ObservePqSigSigningTime(time.Since(sStart).Seconds())
 		if err != nil {
-			logger.Error(logger.Validator, "sign attestation failed validator=%d: %v", vid, err)
+			logger.Error(logger.Validator, "sign attestation failed validator=%d: %v%s", vid, err, span.LogFields())
+			span.End(err)
 			continue
 		}
 
@@ -43,27 +55,30 @@ func (e *Engine) produceAttestations(slot uint64) {
 			Signature:   sig,
 		}
 
-		logger.Info(logger.Validator, "produced attestation slot=%d validator=%d", slot, vid)
+		logger.Info(logger.Validator, "produced attestation slot=%d validator=%d%s", slot, vid, span.LogFields())
+		e.Monitor.OnAttestation(vid, attData)
 
 		if e.AggCtl != nil && e.AggCtl.Get() {
 			dataRoot, err := attData.HashTreeRoot()
 			if err != nil {
 				logger.Error(logger.Validator, "attestation root failed validator=%d: %v", vid, err)
+				span.End(err)
 				continue
 			}
-			sigHandle, parseErr := xmss.ParseSignature(sig[:])
-			e.Store.AttestationSignatures.InsertWithHandle(dataRoot, attData, vid, sig, sigHandle, parseErr)
+			e.insertAttestationSignature(dataRoot, signedAtt)
 		}
 
 		if e.P2P != nil {
-			if err := e.P2P.PublishAttestation(context.Background(), signedAtt, e.CommitteeCount); err != nil {
-				logger.Error(logger.Network, "publish attestation failed validator=%d: %v", vid, err)
+			ctx := tracing.ContextWith(context.Background(), span)
+			if err := e.P2P.PublishAttestation(ctx, signedAtt, e.CommitteeCount); err != nil {
+				logger.Error(logger.Network, "publish attestation failed validator=%d: %v%s", vid, err, span.LogFields())
 			} else {
-				logger.Info(logger.Network, "published attestation to network slot=%d validator=%d", slot, vid)
+				logger.Info(logger.Network, "published attestation to network slot=%d validator=%d%s", slot, vid, span.LogFields())
 			}
 		}
 
 		metrics.ObserveAttestationsProductionTime(time.Since(prodStart).Seconds())
+		span.End(nil)
 	}
 }
 
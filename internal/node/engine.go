@@ -2,9 +2,12 @@ package node
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/geanlabs/gean/internal/aggregation"
+	"github.com/geanlabs/gean/internal/blocktrace"
+	"github.com/geanlabs/gean/internal/clock"
 	"github.com/geanlabs/gean/internal/dutygate"
 	"github.com/geanlabs/gean/internal/forkchoice"
 	"github.com/geanlabs/gean/internal/logger"
@@ -13,6 +16,7 @@ import (
 	"github.com/geanlabs/gean/internal/role"
 	"github.com/geanlabs/gean/internal/store"
 	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/internal/validatormonitor"
 	"github.com/geanlabs/gean/xmss"
 )
 
@@ -31,23 +35,44 @@ const (
 type Engine struct {
 	Store               *store.ConsensusStore
 	FC                  *forkchoice.ForkChoice
-	P2P                 *p2p.Host
+	P2P                 Network
+	Clock               clock.Clock
 	Keys                *xmss.KeyManager
 	AggCtl              *role.Controller
 	DutyGate            *dutygate.Gate
+	Monitor             *validatormonitor.Monitor
+	Trace               *blocktrace.Recorder
 	CommitteeCount      uint64
 	Pending             *pending.BlockBuffer
 	PendingAttestations *pending.AttestationBuffer
 
+	// KeyExpiryWarnEpochs warns when a key has this many epochs or fewer
+	// left. Zero disables the warning.
+	KeyExpiryWarnEpochs uint64
+
+	// MaxSyncDistance is how many slots the head may trail the wall clock
+	// while the node still counts as synced. Zero means SyncLagSlots.
+	MaxSyncDistance uint64
+
 	BlockCh       chan *types.SignedBlock
 	AttestationCh chan *types.SignedAttestation
 	AggregationCh chan *types.SignedAggregatedAttestation
 	FailedRootCh  chan [32]byte
 	FetchRootCh   chan [32]byte
+	CallCh        chan func()
 
 	AggregationDispatchCh chan aggregation.Dispatch
 
 	lastTick time.Time
+
+	// workers tracks the goroutines Run starts so it returns only once
+	// in-flight aggregation and attestation handling has finished.
+	workers sync.WaitGroup
+	stepped bool
+
+	keyWarned            bool
+	lastKeyWarnSlot      uint64
+	keyLifetimeErrLogged bool
 }
 
 func New(
@@ -62,7 +87,7 @@ func New(
 	e := &Engine{
 		Store:                 s,
 		FC:                    fc,
-		P2P:                   p2pHost,
+		Clock:                 clock.System,
 		Keys:                  keys,
 		AggCtl:                aggCtl,
 		DutyGate:              dutygate.New(logDutyGateEvent),
@@ -74,8 +99,14 @@ func New(
 		AggregationCh:         make(chan *types.SignedAggregatedAttestation, 64),
 		FailedRootCh:          make(chan [32]byte, 64),
 		FetchRootCh:           make(chan [32]byte, 256),
+		CallCh:                make(chan func(), 16),
 		AggregationDispatchCh: make(chan aggregation.Dispatch, 1),
 	}
+	if p2pHost != nil {
+		e.P2P = p2pHost
+	}
+	e.Monitor = validatormonitor.New(func() []uint64 { return e.Keys.ValidatorIDs() })
+	e.Trace = blocktrace.New(blocktrace.DefaultCapacity, e.slotStartTime)
 	e.configureP2PHooks()
 	return e
 }
@@ -83,12 +114,14 @@ func New(
 func (e *Engine) Run(ctx context.Context) {
 	e.initMetrics()
 
-	ticker := time.NewTicker(types.MillisecondsPerInterval * time.Millisecond)
+	ticker := clock.Or(e.Clock).NewTicker(time.Duration(e.spec().MillisecondsPerInterval()) * time.Millisecond)
 	defer ticker.Stop()
 
 	e.startWorkers(ctx)
 
 	logger.Info(logger.Node, "started")
 	e.onTick()
-	e.dispatch(ctx, ticker.C)
+	e.dispatch(ctx, ticker.C())
+	e.workers.Wait()
+	logger.Info(logger.Node, "stopped")
 }
@@ -6,9 +6,11 @@ import (
 )
 
 func (e *Engine) OnBlock(block *types.SignedBlock) {
+	e.Trace.Received(block)
 	select {
 	case e.BlockCh <- block:
 	default:
+		e.Trace.Discard(block)
 		logger.Warn(logger.Chain, "block channel full, dropping")
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/geanlabs/gean/internal/clock"
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/p2p"
 )
@@ -23,7 +24,7 @@ func (e *Engine) runFetchBatcher(ctx context.Context) {
 			seen[root] = true
 		}
 
-		grace := time.After(fetchBatchGracePeriod)
+		grace := clock.Or(e.Clock).After(fetchBatchGracePeriod)
 	gather:
 		for len(batch) < p2p.MaxBlocksPerRequest {
 			select {
@@ -0,0 +1,21 @@
+package node
+
+import (
+	"context"
+
+	"github.com/geanlabs/gean/internal/forkchoice"
+)
+
+// ForkChoiceDebug snapshots the fork choice on the dispatch loop so the vote
+// store is not read while gossip is updating it.
+func (e *Engine) ForkChoiceDebug(ctx context.Context) (forkchoice.Debug, error) {
+	var d forkchoice.Debug
+	err := e.call(ctx, func() {
+		var numValidators uint64
+		if headState := e.Store.GetState(e.Store.Head()); headState != nil {
+			numValidators = uint64(len(headState.Validators))
+		}
+		d = e.FC.Debug(*e.Store.LatestJustified(), *e.Store.LatestFinalized(), numValidators)
+	})
+	return d, err
+}
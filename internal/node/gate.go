@@ -3,6 +3,7 @@ package node
 import (
 	"github.com/geanlabs/gean/internal/dutygate"
 	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/metrics"
 )
 
 func logDutyGateEvent(event dutygate.Event) {
@@ -16,5 +17,12 @@ func logDutyGateEvent(event dutygate.Event) {
 	case dutygate.ReasonLocalLag:
 		logger.Info(logger.Validator, "duty gate closed: local view is stale. duty=%s slot=%d head_slot=%d lag=%d max_seen_slot=%d network_lag=%d",
 			event.Duty, event.Slot, event.HeadSlot, event.Lag, event.MaxStoredSlot, event.NetworkLag)
+	case dutygate.ReasonDoppelganger:
+		metrics.SetValidatorDoppelganger(event.ValidatorIndex)
+		logger.Error(logger.Validator, "DOPPELGANGER DETECTED: validator=%d is signing on another node at slot=%d. duties stay disabled; stop the other instance before restarting",
+			event.ValidatorIndex, event.Slot)
+	case dutygate.ReasonDoppelgangerCleared:
+		logger.Info(logger.Validator, "doppelganger check passed: enabling duties validator=%d slot=%d",
+			event.ValidatorIndex, event.Slot)
 	}
 }
@@ -7,24 +7,36 @@ import (
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/metrics"
 	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/tracing"
 	"github.com/geanlabs/gean/internal/types"
 	"github.com/geanlabs/gean/xmss"
 )
 
 func (e *Engine) onGossipAttestation(att *types.SignedAttestation) {
+	e.observeDoppelgangerAttestation(att)
 	if e.AggCtl == nil || !e.AggCtl.Get() || att == nil {
 		return
 	}
 
 	start := time.Now()
 	success := false
+	var span *tracing.Active
+	if att.Data != nil {
+		if trace, ok := tracing.TraceFor(att.Data); ok {
+			span = tracing.Start(trace, "attestation.verify",
+				tracing.Uint("attestation.slot", att.Data.Slot), tracing.Uint("validator.index", att.ValidatorID))
+		}
+	}
+	var spanErr error
 	defer func() {
+		span.End(spanErr)
 		if success {
 			metrics.ObserveAttestationValidationTime(time.Since(start).Seconds())
 		}
 	}()
 
 	if err := attestation.ValidateAttestationData(e.Store, att.Data); err != nil {
+		spanErr = err
 		if se, ok := err.(*store.StoreError); ok && se.Kind == store.ErrUnknownHeadBlock && att.Data.Head != nil {
 			added, dropped := e.PendingAttestations.Add(att.Data.Head.Root, att)
 			if added {
@@ -51,6 +63,7 @@ func (e *Engine) onGossipAttestation(att *types.SignedAttestation) {
 	err = attestation.VerifyGossipAttestation(e.Store, att.ValidatorID, att.Data, dataRoot, att.Signature[:])
 	metrics.ObservePqSigVerificationTime(time.Since(verifyStart).Seconds())
 	if err != nil {
+		spanErr = err
 		metrics.IncPqSigAttestationSigsInvalid()
 		metrics.IncAttestationsInvalid()
 		return
@@ -58,15 +71,19 @@ func (e *Engine) onGossipAttestation(att *types.SignedAttestation) {
 	metrics.IncPqSigAttestationSigsValid()
 	metrics.IncAttestationsValid(1)
 
-	sigHandle, parseErr := xmss.ParseSignature(att.Signature[:])
+	logger.Info(logger.Gossip, "attestation verified: validator=%d slot=%d dataRoot=%x%s", att.ValidatorID, att.Data.Slot, dataRoot, span.LogFields())
+	e.insertAttestationSignature(dataRoot, att)
+	e.Monitor.OnAttestation(att.ValidatorID, att.Data)
+	success = true
+}
 
-	logger.Info(logger.Gossip, "attestation verified: validator=%d slot=%d dataRoot=%x", att.ValidatorID, att.Data.Slot, dataRoot)
+func (e *Engine) insertAttestationSignature(dataRoot [32]byte, att *types.SignedAttestation) {
+	sigHandle, parseErr := xmss.ParseSignature(att.Signature[:])
 	e.Store.AttestationSignatures.InsertWithHandle(dataRoot, att.Data, att.ValidatorID, att.Signature, sigHandle, parseErr)
-	success = true
 }
 
 func (e *Engine) onGossipAggregatedAttestation(agg *types.SignedAggregatedAttestation) {
-	if agg == nil {
+	if agg == nil || agg.Data == nil {
 		return
 	}
 
@@ -77,15 +94,23 @@ func (e *Engine) onGossipAggregatedAttestation(agg *types.SignedAggregatedAttest
 	if agg.Proof == nil || len(agg.Proof.ProofData) == 0 {
 		return
 	}
+	var span *tracing.Active
+	if trace, ok := tracing.TraceFor(agg.Data); ok {
+		span = tracing.Start(trace, "aggregation.verify",
+			tracing.Uint("attestation.slot", agg.Data.Slot),
+			tracing.Uint("aggregation.participants", types.BitlistCount(agg.Proof.Participants)))
+	}
 	verifyStart := time.Now()
 	err := attestation.VerifyAggregatedGossipAttestation(e.Store, agg.Data, agg.Proof.Participants, agg.Proof.ProofData)
 	metrics.ObservePqSigAggVerificationTime(time.Since(verifyStart).Seconds())
+	span.End(err)
 	if err != nil {
 		metrics.IncPqSigAggregatedInvalid()
-		logger.Error(logger.Signature, "aggregated attestation verification failed: %v", err)
+		logger.Error(logger.Signature, "aggregated attestation verification failed: %v%s", err, span.LogFields())
 		return
 	}
 	metrics.IncPqSigAggregatedValid()
+	e.Monitor.OnAggregate(agg.Data, agg.Proof.Participants)
 
 	dataRoot, err := agg.Data.HashTreeRoot()
 	if err != nil {
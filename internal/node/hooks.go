@@ -1,21 +1,25 @@
 package node
 
-import "github.com/geanlabs/gean/internal/metrics"
+import (
+	"github.com/geanlabs/gean/internal/metrics"
+	"github.com/geanlabs/gean/internal/p2p"
+)
 
 func (e *Engine) configureP2PHooks() {
-	if e.P2P == nil {
+	host, ok := e.P2P.(*p2p.Host)
+	if !ok {
 		return
 	}
-	e.P2P.Hooks.GossipBlockSize = metrics.ObserveGossipBlockSize
-	e.P2P.Hooks.GossipAttestationSize = metrics.ObserveGossipAttestationSize
-	e.P2P.Hooks.GossipAggregationSize = metrics.ObserveGossipAggregationSize
-	e.P2P.Hooks.PeerConnected = func(direction string) {
+	host.Hooks.GossipBlockSize = metrics.ObserveGossipBlockSize
+	host.Hooks.GossipAttestationSize = metrics.ObserveGossipAttestationSize
+	host.Hooks.GossipAggregationSize = metrics.ObserveGossipAggregationSize
+	host.Hooks.PeerConnected = func(direction string) {
 		metrics.IncPeerConnection(direction, "success")
 	}
-	e.P2P.Hooks.PeerDisconnected = func(direction, reason string) {
+	host.Hooks.PeerDisconnected = func(direction, reason string) {
 		metrics.IncPeerDisconnection(direction, reason)
 	}
-	e.P2P.Hooks.PeerCount = func(count int) {
+	host.Hooks.PeerCount = func(count int) {
 		metrics.SetConnectedPeers("unknown", count)
 	}
 }
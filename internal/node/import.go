@@ -9,6 +9,7 @@ import (
 
 func (e *Engine) onBlock(signedBlock *types.SignedBlock) {
 	if signedBlock == nil || signedBlock.Block == nil {
+		e.Trace.Discard(signedBlock)
 		return
 	}
 
@@ -30,6 +31,7 @@ func (e *Engine) onBlock(signedBlock *types.SignedBlock) {
 
 func (e *Engine) processOneBlock(signedBlock *types.SignedBlock, queue *[]*types.SignedBlock) {
 	if signedBlock == nil || signedBlock.Block == nil {
+		e.Trace.Discard(signedBlock)
 		return
 	}
 
@@ -37,11 +39,13 @@ func (e *Engine) processOneBlock(signedBlock *types.SignedBlock, queue *[]*types
 	blockRoot, err := block.HashTreeRoot()
 	if err != nil {
 		logger.Error(logger.Chain, "block root failed slot=%d: %v", block.Slot, err)
+		e.Trace.Discard(signedBlock)
 		return
 	}
 	parentRoot := block.ParentRoot
 
 	if e.Store.HasState(blockRoot) {
+		e.Trace.Discard(signedBlock)
 		return
 	}
 
@@ -49,8 +53,10 @@ func (e *Engine) processOneBlock(signedBlock *types.SignedBlock, queue *[]*types
 	if block.Slot < finalizedSlot {
 		logger.Warn(logger.Chain, "rejecting pre-finalized block slot=%d block_root=0x%x finalized_slot=%d",
 			block.Slot, blockRoot, finalizedSlot)
+		e.Trace.Discard(signedBlock)
 		return
 	}
+	e.Trace.Begin(signedBlock, blockRoot, false)
 
 	hasParent := e.Store.HasState(parentRoot)
 	logger.Info(logger.Chain, "processing block slot=%d block_root=0x%x has_parent=%t", block.Slot, blockRoot, hasParent)
@@ -70,20 +76,24 @@ func (e *Engine) importKnownParentBlock(
 	queue *[]*types.SignedBlock,
 ) {
 	block := signedBlock.Block
-	err := blockprocessor.OnBlock(e.Store, signedBlock)
+	err := blockprocessor.OnBlockObserved(e.Store, signedBlock, e.traceStages(blockRoot))
 	if err != nil {
 		logger.Error(logger.Chain, "block processing failed slot=%d block_root=0x%x: %v", block.Slot, blockRoot, err)
+		e.Trace.Finish(blockRoot, err)
 		return
 	}
 
 	e.FC.OnBlock(block.Slot, blockRoot, parentRoot)
+	e.observeDoppelgangerBlock(block)
+	e.Monitor.OnBlock(block)
 
 	finalized := e.Store.LatestFinalized()
 	if finalized.Slot > 0 {
 		e.FC.Prune(finalized.Root)
 	}
 
-	e.updateHead()
+	e.tracedUpdateHead(blockRoot)
+	e.Trace.Finish(blockRoot, nil)
 	e.Pending.ClearDepth(blockRoot)
 	e.replayPendingAttestations(blockRoot)
 	e.collectPendingChildren(blockRoot, queue)
@@ -0,0 +1,104 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/metrics"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/xmss"
+)
+
+func (e *Engine) ValidatorIDs() []uint64 {
+	return e.Keys.ValidatorIDs()
+}
+
+// ImportValidatorKeys starts duties for a validator on the running node. The
+// signing history is merged before the keys become usable so a key moved from
+// another node cannot sign below a slot it already signed there.
+func (e *Engine) ImportValidatorKeys(ctx context.Context, keys *xmss.ValidatorKeys, history []xmss.SigningRecord) error {
+	if e.Keys == nil {
+		return fmt.Errorf("import validator keys: node has no key manager")
+	}
+	var importErr error
+	if err := e.call(ctx, func() { importErr = e.importValidatorKeys(keys, history) }); err != nil {
+		return err
+	}
+	return importErr
+}
+
+func (e *Engine) importValidatorKeys(keys *xmss.ValidatorKeys, history []xmss.SigningRecord) error {
+	headState := e.Store.GetState(e.Store.Head())
+	if headState == nil {
+		return fmt.Errorf("head state missing")
+	}
+	vid := keys.ValidatorIndex
+	if vid >= headState.NumValidators() {
+		return &store.StoreError{
+			Kind:    store.ErrInvalidValidatorIndex,
+			Message: fmt.Sprintf("validator %d not in head state (%d validators)", vid, headState.NumValidators()),
+		}
+	}
+	validator := headState.Validators[vid]
+	if !bytes.Equal(keys.AttestationPubkey, validator.AttestationPubkey[:]) ||
+		!bytes.Equal(keys.ProposalPubkey, validator.ProposalPubkey[:]) {
+		return &store.StoreError{
+			Kind:    store.ErrPubkeyDecodingFailed,
+			Message: fmt.Sprintf("validator %d pubkeys do not match head state", vid),
+		}
+	}
+
+	if err := e.Keys.SigningHistory().Import(history); err != nil {
+		return fmt.Errorf("import signing history: %w", err)
+	}
+	if err := e.Keys.AddValidator(keys); err != nil {
+		return err
+	}
+	if e.DutyGate != nil {
+		e.DutyGate.WatchValidators(e.doppelgangerStartSlot(), vid)
+	}
+	logger.Info(logger.Validator, "imported validator keys validator=%d history_records=%d", vid, len(history))
+	e.validatorSetChanged()
+	return nil
+}
+
+// RemoveValidatorKeys stops duties for a validator and returns its signing
+// history so the keys can be imported elsewhere without double signing.
+func (e *Engine) RemoveValidatorKeys(ctx context.Context, validatorIndex uint64) ([]xmss.SigningRecord, bool, error) {
+	if e.Keys == nil {
+		return nil, false, nil
+	}
+	var (
+		records []xmss.SigningRecord
+		removed bool
+	)
+	err := e.call(ctx, func() {
+		removed = e.Keys.RemoveValidator(validatorIndex)
+		if !removed {
+			return
+		}
+		records = e.Keys.SigningHistory().ExportValidator(validatorIndex)
+		metrics.DeleteValidatorKeyRemainingEpochs(validatorIndex)
+		if e.DutyGate != nil {
+			e.DutyGate.ForgetValidator(validatorIndex)
+		}
+		logger.Info(logger.Validator, "removed validator keys validator=%d", validatorIndex)
+		e.validatorSetChanged()
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return records, removed, nil
+}
+
+func (e *Engine) validatorSetChanged() {
+	vids := e.Keys.ValidatorIDs()
+	if e.P2P != nil {
+		if err := e.P2P.UpdateValidatorSubnets(vids); err != nil {
+			logger.Error(logger.Network, "update attestation subnets: %v", err)
+		}
+	}
+	e.setValidatorMetrics(vids)
+}
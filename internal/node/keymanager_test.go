@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+func runCalls(ctx context.Context, e *Engine) {
+	for {
+		select {
+		case fn := <-e.CallCh:
+			fn()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func TestImportAndRemoveValidatorKeys(t *testing.T) {
+	e := makeTestEngine()
+	e.Keys = xmss.NewSignerKeyManager(map[uint64]xmss.Signer{0: rangeSigner{}}, map[uint64]xmss.Signer{0: rangeSigner{}})
+	head := e.Store.Head()
+	state := e.Store.GetState(head)
+	state.Validators = []*types.Validator{
+		{Index: 0},
+		{Index: 1, AttestationPubkey: [types.PubkeySize]byte{0xaa}, ProposalPubkey: [types.PubkeySize]byte{0xbb}},
+	}
+	e.Store.InsertState(head, state)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go runCalls(ctx, e)
+
+	keys := func(vid uint64, att byte) *xmss.ValidatorKeys {
+		attPub, propPub := [types.PubkeySize]byte{att}, [types.PubkeySize]byte{0xbb}
+		return &xmss.ValidatorKeys{
+			ValidatorIndex:    vid,
+			AttestationPubkey: attPub[:],
+			ProposalPubkey:    propPub[:],
+			Attestation:       rangeSigner{end: 1000},
+			Proposal:          rangeSigner{end: 1000},
+		}
+	}
+	var storeErr *store.StoreError
+	if err := e.ImportValidatorKeys(ctx, keys(5, 0xaa), nil); !errors.As(err, &storeErr) || storeErr.Kind != store.ErrInvalidValidatorIndex {
+		t.Fatalf("unknown index err=%v", err)
+	}
+	if err := e.ImportValidatorKeys(ctx, keys(1, 0xcc), nil); !errors.As(err, &storeErr) {
+		t.Fatalf("pubkey mismatch err=%v", err)
+	}
+
+	history := []xmss.SigningRecord{{ValidatorIndex: 1, Role: xmss.RoleAttestation, Slot: 40, MessageRoot: fmt.Sprintf("0x%x", [32]byte{})}}
+	if err := e.ImportValidatorKeys(ctx, keys(1, 0xaa), history); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if ids := e.ValidatorIDs(); len(ids) != 2 {
+		t.Fatalf("ids=%v, want 2 validators", ids)
+	}
+	if _, err := e.Keys.SignBlock(1, 10, [32]byte{}); err != nil {
+		t.Fatalf("sign with imported key: %v", err)
+	}
+
+	records, removed, err := e.RemoveValidatorKeys(ctx, 1)
+	if err != nil || !removed {
+		t.Fatalf("remove removed=%v err=%v", removed, err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("exported records=%+v, want attestation and proposal", records)
+	}
+	if _, removed, _ := e.RemoveValidatorKeys(ctx, 1); removed {
+		t.Fatal("second remove reported removed")
+	}
+}
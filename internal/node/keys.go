@@ -0,0 +1,39 @@
+package node
+
+import (
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/metrics"
+	"github.com/geanlabs/gean/internal/validatorkeys"
+)
+
+func (e *Engine) trackKeyLifetimes(slot uint64) {
+	if e.Keys == nil {
+		return
+	}
+	lifetimes, err := e.Keys.Lifetimes()
+	if err != nil && !e.keyLifetimeErrLogged {
+		logger.Warn(logger.Validator, "cannot read key lifetimes: %v", err)
+		e.keyLifetimeErrLogged = true
+	}
+	for _, lt := range lifetimes {
+		metrics.SetValidatorKeyRemainingEpochs(lt.ValidatorIndex, string(lt.Role), lt.Remaining(slot))
+	}
+
+	if e.KeyExpiryWarnEpochs == 0 || (e.keyWarned && slot < e.lastKeyWarnSlot+validatorkeys.WarnIntervalSlots) {
+		return
+	}
+	warned := false
+	for _, lt := range lifetimes {
+		remaining := lt.Remaining(slot)
+		if remaining > e.KeyExpiryWarnEpochs {
+			continue
+		}
+		logger.Warn(logger.Validator, "validator key nearly exhausted validator=%d role=%s remaining_epochs=%d end_slot=%d - rotate keys",
+			lt.ValidatorIndex, lt.Role, remaining, lt.End)
+		warned = true
+	}
+	if warned {
+		e.keyWarned = true
+		e.lastKeyWarnSlot = slot
+	}
+}
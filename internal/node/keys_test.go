@@ -0,0 +1,45 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/internal/validatorkeys"
+	"github.com/geanlabs/gean/xmss"
+)
+
+type rangeSigner struct{ end uint64 }
+
+func (s rangeSigner) Sign(uint32, [32]byte) ([types.SignatureSize]byte, error) {
+	return [types.SignatureSize]byte{}, nil
+}
+
+func (s rangeSigner) ActiveRange() (xmss.ActiveRange, error) {
+	return xmss.ActiveRange{End: s.end}, nil
+}
+
+func (s rangeSigner) Close() {}
+
+func TestTrackKeyLifetimesRateLimitsWarnings(t *testing.T) {
+	e := &Engine{
+		Keys:                xmss.NewSignerKeyManager(map[uint64]xmss.Signer{0: rangeSigner{end: 5000}}, nil),
+		KeyExpiryWarnEpochs: 1000,
+	}
+
+	e.trackKeyLifetimes(100)
+	if e.keyWarned {
+		t.Fatal("should not warn with 4900 epochs left")
+	}
+	e.trackKeyLifetimes(4500)
+	if !e.keyWarned || e.lastKeyWarnSlot != 4500 {
+		t.Fatalf("warned=%v last=%d, want warning at 4500", e.keyWarned, e.lastKeyWarnSlot)
+	}
+	e.trackKeyLifetimes(4600)
+	if e.lastKeyWarnSlot != 4500 {
+		t.Fatalf("last=%d, want repeated warning suppressed", e.lastKeyWarnSlot)
+	}
+	e.trackKeyLifetimes(4500 + validatorkeys.WarnIntervalSlots)
+	if e.lastKeyWarnSlot != 4500+validatorkeys.WarnIntervalSlots {
+		t.Fatalf("last=%d, want warning repeated after interval", e.lastKeyWarnSlot)
+	}
+}
@@ -15,7 +15,10 @@ func (e *Engine) initMetrics() {
 	if e.Keys == nil {
 		return
 	}
-	vids := e.Keys.ValidatorIDs()
+	e.setValidatorMetrics(e.Keys.ValidatorIDs())
+}
+
+func (e *Engine) setValidatorMetrics(vids []uint64) {
 	metrics.SetValidatorsCount(len(vids))
 	if len(vids) > 0 && e.CommitteeCount > 0 {
 		metrics.SetAttestationCommitteeSubnet(vids[0] % e.CommitteeCount)
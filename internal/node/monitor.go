@@ -0,0 +1,62 @@
+package node
+
+import (
+	"sort"
+
+	"github.com/geanlabs/gean/internal/store"
+)
+
+// maxMonitorChainWalk bounds how far back vote correctness is checked.
+const maxMonitorChainWalk = 1024
+
+type chainBlock struct {
+	slot uint64
+	root [32]byte
+}
+
+// canonicalChain lazily walks block headers back from the head so the
+// validator monitor can resolve the canonical block at a slot.
+type canonicalChain struct {
+	store  *store.ConsensusStore
+	blocks []chainBlock
+	next   [32]byte
+	done   bool
+}
+
+func newCanonicalChain(s *store.ConsensusStore) *canonicalChain {
+	return &canonicalChain{store: s, next: s.Head()}
+}
+
+func (c *canonicalChain) BlockRootAt(slot uint64) ([32]byte, bool) {
+	for {
+		if n := len(c.blocks); n > 0 && c.blocks[n-1].slot <= slot {
+			i := sort.Search(n, func(i int) bool { return c.blocks[i].slot <= slot })
+			return c.blocks[i].root, true
+		}
+		if c.done {
+			return [32]byte{}, false
+		}
+		c.loadNext()
+	}
+}
+
+func (c *canonicalChain) loadNext() {
+	header := c.store.GetBlockHeader(c.next)
+	if header == nil || len(c.blocks) >= maxMonitorChainWalk {
+		c.done = true
+		return
+	}
+	c.blocks = append(c.blocks, chainBlock{slot: header.Slot, root: c.next})
+	if header.Slot == 0 {
+		c.done = true
+		return
+	}
+	c.next = header.ParentRoot
+}
+
+func (e *Engine) monitorSlot(slot uint64) {
+	if e.Monitor == nil {
+		return
+	}
+	e.Monitor.OnSlot(slot, newCanonicalChain(e.Store))
+}
@@ -0,0 +1,30 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestCanonicalChainBlockRootAt(t *testing.T) {
+	s := makeTestStore()
+	genesis, b3, b7 := [32]byte{0x01}, [32]byte{0x03}, [32]byte{0x07}
+	s.InsertBlockHeader(genesis, &types.BlockHeader{Slot: 0})
+	s.InsertBlockHeader(b3, &types.BlockHeader{Slot: 3, ParentRoot: genesis})
+	s.InsertBlockHeader(b7, &types.BlockHeader{Slot: 7, ParentRoot: b3})
+	s.SetHead(b7)
+
+	chain := newCanonicalChain(s)
+	cases := map[uint64][32]byte{9: b7, 7: b7, 6: b3, 3: b3, 2: genesis, 0: genesis}
+	for slot, want := range cases {
+		got, ok := chain.BlockRootAt(slot)
+		if !ok || got != want {
+			t.Fatalf("BlockRootAt(%d)=0x%x ok=%v, want 0x%x", slot, got, ok, want)
+		}
+	}
+
+	s.SetHead([32]byte{0xee})
+	if _, ok := newCanonicalChain(s).BlockRootAt(5); ok {
+		t.Fatal("unknown head should not resolve any slot")
+	}
+}
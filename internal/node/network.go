@@ -0,0 +1,21 @@
+package node
+
+import (
+	"context"
+
+	"github.com/geanlabs/gean/internal/aggregation"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// Network is the gossip and req/resp surface the engine uses. *p2p.Host
+// implements it; simulations substitute an in-memory transport.
+type Network interface {
+	aggregation.Publisher
+	PublishBlock(ctx context.Context, block *types.SignedBlock) error
+	PublishAttestation(ctx context.Context, att *types.SignedAttestation, committeeCount uint64) error
+	FetchBlocksByRootBatchWithRetry(ctx context.Context, roots [][32]byte) ([]*types.SignedBlock, [][32]byte, error)
+	UpdateValidatorSubnets(validatorIDs []uint64) error
+	ConnectedPeers() int
+	TopicMeshSizes() map[string]int
+	MeshPeerCount() int
+}
@@ -376,6 +376,18 @@ func TestComputeSyncStatus_FreshNodePastWallClock(t *testing.T) {
 	}
 }
 
+func TestComputeSyncStatusUsesMaxSyncDistance(t *testing.T) {
+	e := makeTestEngine()
+	e.MaxSyncDistance = 8
+
+	if status := e.computeSyncStatus(8); status != syncer.SyncSynced {
+		t.Errorf("head=0 currentSlot=8 max distance 8 should be synced, got %s", status)
+	}
+	if status := e.computeSyncStatus(9); status != syncer.SyncSyncing {
+		t.Errorf("head=0 currentSlot=9 max distance 8 should be syncing, got %s", status)
+	}
+}
+
 func TestComputeSyncStatusAvoidsHeadSlotOverflow(t *testing.T) {
 	e := makeTestEngine()
 	head := e.Store.Head()
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/types"
 )
@@ -15,6 +17,7 @@ func (e *Engine) bufferMissingParentBlock(
 	if e.Pending.Count() >= MaxPendingBlocks {
 		logger.Warn(logger.Chain, "pending block cache full (%d), rejecting block slot=%d block_root=0x%x",
 			MaxPendingBlocks, block.Slot, blockRoot)
+		e.Trace.Finish(blockRoot, fmt.Errorf("pending block cache full (%d)", MaxPendingBlocks))
 		return
 	}
 
@@ -25,6 +28,7 @@ func (e *Engine) bufferMissingParentBlock(
 	if depth > MaxBlockFetchDepth {
 		logger.Warn(logger.Chain, "block fetch depth exceeded (%d > %d), discarding block slot=%d block_root=0x%x",
 			depth, MaxBlockFetchDepth, block.Slot, blockRoot)
+		e.Trace.Finish(blockRoot, fmt.Errorf("block fetch depth exceeded (%d > %d)", depth, MaxBlockFetchDepth))
 		return
 	}
 
@@ -32,6 +36,7 @@ func (e *Engine) bufferMissingParentBlock(
 		block.Slot, blockRoot, parentRoot, depth)
 
 	e.Pending.SetDepth(blockRoot, depth)
+	e.Trace.Pending(blockRoot)
 	missingRoot := e.Pending.ResolveAncestor(parentRoot)
 	e.Pending.SetParent(blockRoot, parentRoot)
 	e.Store.StorePendingBlock(blockRoot, signedBlock)
@@ -25,6 +25,9 @@ func (e *Engine) maybePropose(slot, validatorID uint64) {
 		metrics.IncBlocksSkippedLag()
 		return
 	}
+	if e.DutyGate != nil && !e.DutyGate.AllowValidator("block", validatorID, slot) {
+		return
+	}
 
 	logger.Info(logger.Validator, "proposing block slot=%d validator=%d", slot, validatorID)
 
@@ -38,17 +41,12 @@ func (e *Engine) maybePropose(slot, validatorID uint64) {
 	}
 
 	signStart := time.Now()
-	propKey := e.Keys.GetProposalKey(validatorID)
-	if propKey == nil {
-		logger.Error(logger.Validator, "proposal key not found for validator=%d", validatorID)
-		return
-	}
 	blockRoot, err := block.HashTreeRoot()
 	if err != nil {
 		logger.Error(logger.Validator, "block root failed: %v", err)
 		return
 	}
-	blockSig, err := propKey.Sign(uint32(slot), blockRoot)
+	blockSig, err := e.Keys.SignBlock(validatorID, slot, blockRoot)
 	metrics.ObservePqSigSigningTime(time.Since(signStart).Seconds())
 	if err != nil {
 		logger.Error(logger.Validator, "sign block failed: %v", err)
@@ -63,18 +61,13 @@ func (e *Engine) maybePropose(slot, validatorID uint64) {
 		},
 	}
 
-	if err := blockprocessor.OnBlock(e.Store, signedBlock); err != nil {
+	if err := e.importOwnBlock(signedBlock, blockRoot); err != nil {
 		logger.Error(logger.Chain, "local block processing failed: %v", err)
 		return
 	}
 
-	e.FC.OnBlock(slot, blockRoot, block.ParentRoot)
-	e.updateHead()
-
-	if e.P2P != nil {
-		if err := e.P2P.PublishBlock(context.Background(), signedBlock); err != nil {
-			logger.Error(logger.Network, "publish block failed: %v", err)
-		}
+	if err := e.PublishBlock(context.Background(), signedBlock); err != nil {
+		logger.Error(logger.Network, "publish block failed: %v", err)
 	}
 
 	attestationCount := 0
@@ -85,7 +78,35 @@ func (e *Engine) maybePropose(slot, validatorID uint64) {
 		slot, blockRoot, attestationCount)
 }
 
+func (e *Engine) importOwnBlock(signedBlock *types.SignedBlock, blockRoot [32]byte) error {
+	e.Trace.Begin(signedBlock, blockRoot, true)
+	if err := blockprocessor.OnBlockObserved(e.Store, signedBlock, e.traceStages(blockRoot)); err != nil {
+		e.Trace.Finish(blockRoot, err)
+		return err
+	}
+	e.FC.OnBlock(signedBlock.Block.Slot, blockRoot, signedBlock.Block.ParentRoot)
+	e.Monitor.OnBlock(signedBlock.Block)
+	e.tracedUpdateHead(blockRoot)
+	e.Trace.Finish(blockRoot, nil)
+	return nil
+}
+
+func (e *Engine) PublishBlock(ctx context.Context, signedBlock *types.SignedBlock) error {
+	if e.P2P == nil {
+		return nil
+	}
+	return e.P2P.PublishBlock(ctx, signedBlock)
+}
+
 func (e *Engine) produceBlockWithSignatures(slot, validatorIndex uint64) (*types.Block, []*types.AggregatedSignatureProof, error) {
+	result, err := e.buildBlock(slot, validatorIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Block, result.AttestationProofs, nil
+}
+
+func (e *Engine) buildBlock(slot, validatorIndex uint64) (*blockbuilder.Result, error) {
 	buildStart := time.Now()
 	defer func() { metrics.ObserveBlockBuildingTime(time.Since(buildStart).Seconds()) }()
 
@@ -93,19 +114,22 @@ func (e *Engine) produceBlockWithSignatures(slot, validatorIndex uint64) (*types
 	headState := e.Store.GetState(headRoot)
 	if headState == nil {
 		metrics.IncBlockBuildingFailures()
-		return nil, nil, fmt.Errorf("head state missing for slot %d", slot)
+		return nil, fmt.Errorf("head state missing for slot %d", slot)
 	}
 
 	numValidators := headState.NumValidators()
 	if !types.IsProposer(slot, validatorIndex, numValidators) {
 		metrics.IncBlockBuildingFailures()
-		return nil, nil, fmt.Errorf("validator %d not proposer for slot %d", validatorIndex, slot)
+		return nil, &store.StoreError{
+			Kind:    store.ErrNotProposer,
+			Message: fmt.Sprintf("validator %d not proposer for slot %d", validatorIndex, slot),
+		}
 	}
 
 	knownBlockRoots, err := e.Store.BlockRoots()
 	if err != nil {
 		metrics.IncBlockBuildingFailures()
-		return nil, nil, fmt.Errorf("load block roots: %w", err)
+		return nil, fmt.Errorf("load block roots: %w", err)
 	}
 
 	result, err := blockbuilder.Build(blockbuilder.Input{
@@ -117,10 +141,12 @@ func (e *Engine) produceBlockWithSignatures(slot, validatorIndex uint64) (*types
 		Payloads:          payloadsFromEntries(e.Store.KnownPayloads.Entries()),
 		RequiredJustified: e.Store.LatestJustified(),
 		ProofMerger:       attestationproof.NewMerger(e.Store.PubKeyCache),
+
+		MaxAttestationsData: e.spec().MaxAttestationsData,
 	})
 	if err != nil {
 		metrics.IncBlockBuildingFailures()
-		return nil, nil, err
+		return nil, err
 	}
 	for _, payloadErr := range result.PayloadErrors {
 		if blockbuilder.IsExpectedSkip(payloadErr.Err) {
@@ -133,7 +159,7 @@ func (e *Engine) produceBlockWithSignatures(slot, validatorIndex uint64) (*types
 	if result.Block != nil && result.Block.Body != nil {
 		metrics.ObserveBlockAggregatedPayloads(len(result.Block.Body.Attestations))
 	}
-	return result.Block, result.AttestationProofs, nil
+	return result, nil
 }
 
 func payloadsFromEntries(entries map[[32]byte]*store.PayloadEntry) []blockbuilder.AttestationPayload {
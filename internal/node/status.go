@@ -2,8 +2,8 @@ package node
 
 import (
 	"fmt"
-	"time"
 
+	"github.com/geanlabs/gean/internal/dutygate"
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/metrics"
 	"github.com/geanlabs/gean/internal/syncer"
@@ -22,14 +22,42 @@ func (e *Engine) computeSyncStatus(currentSlot uint64) syncer.SyncStatus {
 		return syncer.SyncIdle
 	}
 	headSlot := e.Store.HeadSlot()
-	if currentSlot <= headSlot || currentSlot-headSlot <= SyncLagSlots {
+	if currentSlot <= headSlot || currentSlot-headSlot <= e.maxSyncDistance() {
 		return syncer.SyncSynced
 	}
 	return syncer.SyncSyncing
 }
 
+func (e *Engine) maxSyncDistance() uint64 {
+	if e.MaxSyncDistance == 0 {
+		return SyncLagSlots
+	}
+	return e.MaxSyncDistance
+}
+
 func (e *Engine) GetSyncStatus() syncer.SyncStatus {
-	return e.computeSyncStatus(e.currentSlot(uint64(time.Now().UnixMilli())))
+	return e.computeSyncStatus(e.currentSlot(uint64(e.now().UnixMilli())))
+}
+
+// SyncState is safe to call off the dispatch loop.
+func (e *Engine) SyncState() syncer.State {
+	wallSlot := e.currentSlot(uint64(e.now().UnixMilli()))
+	headSlot := e.Store.HeadSlot()
+	state := syncer.State{
+		Status:       e.computeSyncStatus(wallSlot),
+		HeadSlot:     headSlot,
+		WallSlot:     wallSlot,
+		HasHeadState: e.Store.HasState(e.Store.Head()),
+	}
+	if wallSlot > headSlot {
+		state.Distance = wallSlot - headSlot
+	}
+	if e.P2P != nil {
+		state.Peers = e.P2P.ConnectedPeers()
+	}
+	maxSeen := max(headSlot, e.Store.MaxStoredBlockSlot())
+	state.Stalled = wallSlot > maxSeen && wallSlot-maxSeen > dutygate.NetworkStallThreshold
+	return state
 }
 
 func (e *Engine) logChainStatus(currentSlot uint64) {
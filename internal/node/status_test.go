@@ -0,0 +1,20 @@
+package node
+
+import "testing"
+
+func TestSyncStateReportsDistanceAndStall(t *testing.T) {
+	e := makeTestEngine()
+
+	state := e.SyncState()
+	if !state.HasHeadState || state.HeadSlot != 0 {
+		t.Fatalf("unexpected head: %+v", state)
+	}
+	if state.Distance != state.WallSlot || !state.Stalled {
+		t.Fatalf("genesis head far behind wall clock should be stalled: %+v", state)
+	}
+
+	e.Store.SetHead([32]byte{0xff})
+	if e.SyncState().HasHeadState {
+		t.Fatal("head without state reported as ready")
+	}
+}
@@ -0,0 +1,88 @@
+package node
+
+import (
+	"context"
+
+	"github.com/geanlabs/gean/internal/aggregation"
+	"github.com/geanlabs/gean/internal/p2p"
+)
+
+// Tick runs one interval tick at the engine clock's current time. With
+// RunPending it lets a simulator drive the engine without Run.
+func (e *Engine) Tick() {
+	e.stepped = true
+	e.onTick()
+}
+
+// RunPending handles everything queued for the engine on the calling
+// goroutine, in a fixed order, until the queues are empty. Attestations and
+// aggregation run inline instead of on workers, so the result depends only on
+// what was queued. It must not be called while Run is running.
+func (e *Engine) RunPending(ctx context.Context) {
+	e.stepped = true
+	for e.runOnePending(ctx) {
+	}
+}
+
+func (e *Engine) runOnePending(ctx context.Context) bool {
+	select {
+	case fn := <-e.CallCh:
+		fn()
+		return true
+	default:
+	}
+	select {
+	case block := <-e.BlockCh:
+		e.onBlock(block)
+		return true
+	default:
+	}
+	select {
+	case agg := <-e.AggregationCh:
+		e.onGossipAggregatedAttestation(agg)
+		return true
+	default:
+	}
+	select {
+	case att := <-e.AttestationCh:
+		e.onGossipAttestation(att)
+		return true
+	default:
+	}
+	select {
+	case root := <-e.FailedRootCh:
+		e.onFailedRoot(root)
+		return true
+	default:
+	}
+	select {
+	case root := <-e.FetchRootCh:
+		e.fireBatchFetch(ctx, e.drainFetchRoots(root))
+		return true
+	default:
+	}
+	select {
+	case dispatch := <-e.AggregationDispatchCh:
+		aggregation.Handle(ctx, dispatch, e.Store, e.Store.PubKeyCache, e.P2P)
+		return true
+	default:
+	}
+	return false
+}
+
+func (e *Engine) drainFetchRoots(first [32]byte) [][32]byte {
+	batch := [][32]byte{first}
+	seen := map[[32]byte]bool{first: true}
+	for len(batch) < p2p.MaxBlocksPerRequest {
+		select {
+		case root := <-e.FetchRootCh:
+			if !seen[root] {
+				batch = append(batch, root)
+				seen[root] = true
+			}
+		default:
+			return batch
+		}
+	}
+	return batch
+}
@@ -0,0 +1,42 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestTickUsesEngineClock(t *testing.T) {
+	e := makeTestEngine()
+	interval := time.Duration(types.Spec().MillisecondsPerInterval()) * time.Millisecond
+	clk := clock.NewVirtual(time.Unix(1000, 0).Add(3 * interval))
+	e.Clock = clk
+
+	e.Tick()
+	if got := e.Store.Time(); got != 3 {
+		t.Fatalf("store time=%d after tick at interval 3, want 3", got)
+	}
+	clk.Advance(interval)
+	e.Tick()
+	if got := e.Store.Time(); got != 4 {
+		t.Fatalf("store time=%d after advancing one interval, want 4", got)
+	}
+}
+
+func TestRunPendingDrainsQueuesInline(t *testing.T) {
+	e := makeTestEngine()
+	called := false
+	e.CallCh <- func() { called = true }
+	e.OnGossipAttestation(makeAttForHead(1, [32]byte{0x02}))
+
+	e.RunPending(context.Background())
+	if !called {
+		t.Fatal("queued call did not run")
+	}
+	if n := len(e.AttestationCh); n != 0 {
+		t.Fatalf("%d attestations left queued", n)
+	}
+}
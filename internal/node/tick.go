@@ -1,15 +1,13 @@
 package node
 
 import (
-	"time"
-
 	"github.com/geanlabs/gean/internal/aggregation"
 	"github.com/geanlabs/gean/internal/metrics"
 	"github.com/geanlabs/gean/internal/store"
 )
 
 func (e *Engine) onTick() {
-	now := time.Now()
+	now := e.now()
 	firstTick := e.lastTick.IsZero()
 	if !firstTick {
 		metrics.ObserveTickIntervalDuration(now.Sub(e.lastTick).Seconds())
@@ -39,10 +37,15 @@ func (e *Engine) onTick() {
 		e.dispatchAggregationCycle(currentSlot, isAgg)
 	}
 
-	if currentInterval == 0 || currentInterval == 4 {
+	if currentInterval == 0 || currentInterval == e.spec().LastInterval() {
 		e.updateHead()
 	}
 
+	if currentInterval == 0 {
+		e.trackKeyLifetimes(currentSlot)
+		e.monitorSlot(currentSlot)
+	}
+
 	if hasProposal {
 		e.maybePropose(currentSlot, proposerValidatorID)
 	}
@@ -0,0 +1,209 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geanlabs/gean/internal/attestation"
+	"github.com/geanlabs/gean/internal/blockbuilder"
+	"github.com/geanlabs/gean/internal/blockprocessor"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/metrics"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// call runs fn on the dispatch loop so external callers never race with
+// block import or fork choice updates.
+func (e *Engine) call(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	select {
+	case e.CallCh <- func() {
+		defer close(done)
+		fn()
+	}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *Engine) SubmitAttestation(ctx context.Context, att *types.SignedAttestation) error {
+	if att == nil || att.Data == nil {
+		return fmt.Errorf("submit attestation: nil attestation")
+	}
+
+	if err := e.allowSubmittedDuty("attestation", att.ValidatorID); err != nil {
+		return err
+	}
+
+	// Verification runs on the caller's goroutine, like gossip verification
+	// on spawned workers, so XMSS checks never stall the dispatch loop.
+	dataRoot, err := e.verifySubmittedAttestation(att)
+	if err != nil {
+		return err
+	}
+	if err := e.call(ctx, func() { e.poolSubmittedAttestation(dataRoot, att) }); err != nil {
+		return err
+	}
+
+	if e.P2P != nil {
+		if err := e.P2P.PublishAttestation(ctx, att, e.CommitteeCount); err != nil {
+			return fmt.Errorf("publish attestation: %w", err)
+		}
+	}
+	logger.Info(logger.Validator, "published submitted attestation slot=%d validator=%d", att.Data.Slot, att.ValidatorID)
+	return nil
+}
+
+// verifySubmittedAttestation applies the same checks as an attestation
+// arriving over gossip, but reports failures instead of dropping silently.
+func (e *Engine) verifySubmittedAttestation(att *types.SignedAttestation) ([32]byte, error) {
+	if err := attestation.ValidateAttestationData(e.Store, att.Data); err != nil {
+		return [32]byte{}, err
+	}
+	dataRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("attestation root: %w", err)
+	}
+
+	metrics.IncPqSigAttestationSigsTotal()
+	verifyStart := time.Now()
+	err = attestation.VerifyGossipAttestation(e.Store, att.ValidatorID, att.Data, dataRoot, att.Signature[:])
+	metrics.ObservePqSigVerificationTime(time.Since(verifyStart).Seconds())
+	if err != nil {
+		metrics.IncPqSigAttestationSigsInvalid()
+		metrics.IncAttestationsInvalid()
+		return [32]byte{}, &store.StoreError{Kind: store.ErrSignatureVerificationFailed, Message: err.Error()}
+	}
+	metrics.IncPqSigAttestationSigsValid()
+	metrics.IncAttestationsValid(1)
+	return dataRoot, nil
+}
+
+func (e *Engine) poolSubmittedAttestation(dataRoot [32]byte, att *types.SignedAttestation) {
+	e.Monitor.OnAttestation(att.ValidatorID, att.Data)
+	if e.AggCtl != nil && e.AggCtl.Get() {
+		e.insertAttestationSignature(dataRoot, att)
+	}
+}
+
+func (e *Engine) ProduceBlock(ctx context.Context, slot, proposerIndex uint64) (*blockbuilder.Result, error) {
+	var (
+		result *blockbuilder.Result
+		err    error
+	)
+	// Only the current slot may be built: production promotes new votes and
+	// moves the head, which the tick schedule only does once the slot starts.
+	if current := e.currentSlot(uint64(e.now().UnixMilli())); slot != current {
+		return nil, &store.StoreError{
+			Kind:    store.ErrProposalSlotOutOfRange,
+			Message: fmt.Sprintf("slot %d is not the current slot %d", slot, current),
+		}
+	}
+	if err := e.allowSubmittedDuty("block", proposerIndex); err != nil {
+		return nil, err
+	}
+	callErr := e.call(ctx, func() {
+		if headSlot := e.Store.HeadSlot(); headSlot >= slot {
+			err = fmt.Errorf("slot %d is not after head slot %d", slot, headSlot)
+			return
+		}
+		headState := e.Store.GetState(e.Store.Head())
+		if headState == nil {
+			err = fmt.Errorf("head state missing for slot %d", slot)
+			return
+		}
+		if !types.IsProposer(slot, proposerIndex, headState.NumValidators()) {
+			err = &store.StoreError{
+				Kind:    store.ErrNotProposer,
+				Message: fmt.Sprintf("validator %d not proposer for slot %d", proposerIndex, slot),
+			}
+			return
+		}
+		e.Store.PromoteNewToKnown()
+		e.updateHead()
+		result, err = e.buildBlock(slot, proposerIndex)
+	})
+	if callErr != nil {
+		return nil, callErr
+	}
+	return result, err
+}
+
+// CheckBlockForBroadcast runs the checks a peer would apply before relaying
+// the block, without executing the state transition.
+func (e *Engine) CheckBlockForBroadcast(ctx context.Context, signedBlock *types.SignedBlock) error {
+	if signedBlock == nil || signedBlock.Block == nil {
+		return fmt.Errorf("check block: nil block")
+	}
+	block := signedBlock.Block
+	if err := e.allowSubmittedDuty("block", block.ProposerIndex); err != nil {
+		return err
+	}
+
+	var checkErr error
+	callErr := e.call(ctx, func() {
+		parentState := e.Store.GetState(block.ParentRoot)
+		if parentState == nil {
+			checkErr = errMissingSubmittedParent(block)
+			return
+		}
+		if !types.IsProposer(block.Slot, block.ProposerIndex, parentState.NumValidators()) {
+			checkErr = &store.StoreError{
+				Kind:    store.ErrNotProposer,
+				Message: fmt.Sprintf("validator %d not proposer for slot %d", block.ProposerIndex, block.Slot),
+			}
+			return
+		}
+		checkErr = blockprocessor.VerifyProposerSignature(signedBlock, parentState)
+	})
+	if callErr != nil {
+		return callErr
+	}
+	return checkErr
+}
+
+func (e *Engine) ImportBlock(ctx context.Context, signedBlock *types.SignedBlock) error {
+	if signedBlock == nil || signedBlock.Block == nil {
+		return fmt.Errorf("import block: nil block")
+	}
+	if err := e.allowSubmittedDuty("block", signedBlock.Block.ProposerIndex); err != nil {
+		return err
+	}
+	blockRoot, err := signedBlock.Block.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("block root: %w", err)
+	}
+
+	var importErr error
+	callErr := e.call(ctx, func() {
+		if !e.Store.HasState(signedBlock.Block.ParentRoot) {
+			importErr = errMissingSubmittedParent(signedBlock.Block)
+			return
+		}
+		importErr = e.importOwnBlock(signedBlock, blockRoot)
+	})
+	if callErr != nil {
+		return callErr
+	}
+	if importErr != nil {
+		return importErr
+	}
+	logger.Info(logger.Validator, "imported submitted block slot=%d proposer=%d block_root=0x%x",
+		signedBlock.Block.Slot, signedBlock.Block.ProposerIndex, blockRoot)
+	return nil
+}
+
+func errMissingSubmittedParent(block *types.Block) error {
+	return &store.StoreError{
+		Kind:    store.ErrMissingParentState,
+		Message: fmt.Sprintf("parent state not found for submitted block slot %d parent 0x%x", block.Slot, block.ParentRoot),
+	}
+}
@@ -0,0 +1,51 @@
+//go:build xmss_insecure
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+func TestSubmitAttestationPoolsValidSignature(t *testing.T) {
+	kp, err := xmss.GenerateKeyPair("submit-attestation", 0, 8)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+	defer kp.Close()
+	pubkey, err := kp.PublicKeyBytes()
+	if err != nil {
+		t.Fatalf("pubkey: %v", err)
+	}
+
+	e := makeTestEngine()
+	e.AggCtl = role.New(true)
+	genesisRoot := [32]byte{0x01}
+	state := e.Store.GetState(genesisRoot)
+	state.Validators = []*types.Validator{{AttestationPubkey: pubkey}}
+	e.Store.InsertState(genesisRoot, state)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serveCalls(ctx, e)
+
+	att := genesisAttestation(0)
+	dataRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("data root: %v", err)
+	}
+	if att.Signature, err = kp.Sign(uint32(att.Data.Slot), dataRoot); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	if err := e.SubmitAttestation(ctx, att); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	entry := e.Store.AttestationSignatures.Snapshot()[dataRoot]
+	if entry == nil || len(entry.Signatures) != 1 || entry.Signatures[0].ValidatorID != 0 {
+		t.Fatalf("pool entry=%+v, want one signature from validator 0", entry)
+	}
+}
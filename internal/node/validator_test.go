@@ -0,0 +1,179 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func serveCalls(ctx context.Context, e *Engine) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case fn := <-e.CallCh:
+				fn()
+			}
+		}
+	}()
+}
+
+func genesisAttestation(validatorID uint64) *types.SignedAttestation {
+	genesis := &types.Checkpoint{Root: [32]byte{0x01}}
+	return &types.SignedAttestation{
+		ValidatorID: validatorID,
+		Data:        &types.AttestationData{Slot: 0, Head: genesis, Target: genesis, Source: genesis},
+	}
+}
+
+func TestSubmitAttestationRejectsUnknownBlocks(t *testing.T) {
+	e := makeTestEngine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serveCalls(ctx, e)
+
+	att := genesisAttestation(0)
+	att.Data.Head = &types.Checkpoint{Root: [32]byte{0xaa}}
+	err := e.SubmitAttestation(ctx, att)
+	var storeErr *store.StoreError
+	if !errors.As(err, &storeErr) || storeErr.Kind != store.ErrUnknownHeadBlock {
+		t.Fatalf("err=%v, want ErrUnknownHeadBlock", err)
+	}
+}
+
+func TestSubmitAttestationRejectsBadSignature(t *testing.T) {
+	e := makeTestEngine()
+	e.AggCtl = role.New(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serveCalls(ctx, e)
+
+	err := e.SubmitAttestation(ctx, genesisAttestation(7))
+	var storeErr *store.StoreError
+	if !errors.As(err, &storeErr) || storeErr.Kind != store.ErrSignatureVerificationFailed {
+		t.Fatalf("err=%v, want ErrSignatureVerificationFailed", err)
+	}
+	if e.Store.AttestationSignatures.Len() != 0 {
+		t.Fatal("rejected attestation was inserted into the signature pool")
+	}
+}
+
+func TestSubmitAttestationVerifiesOffTheLoop(t *testing.T) {
+	e := makeTestEngine()
+	e.CallCh = make(chan func())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := e.SubmitAttestation(ctx, genesisAttestation(7))
+	var storeErr *store.StoreError
+	if !errors.As(err, &storeErr) || storeErr.Kind != store.ErrSignatureVerificationFailed {
+		t.Fatalf("err=%v, want ErrSignatureVerificationFailed without the loop running", err)
+	}
+}
+
+func TestCallReturnsWhenLoopIsNotRunning(t *testing.T) {
+	e := makeTestEngine()
+	e.CallCh = make(chan func())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := e.call(ctx, func() {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err=%v, want deadline exceeded", err)
+	}
+}
+
+func TestCheckBlockForBroadcastRejectsWrongProposer(t *testing.T) {
+	e := makeTestEngine()
+	state := e.Store.GetState([32]byte{0x01})
+	state.Validators = []*types.Validator{{}, {}}
+	e.Store.InsertState([32]byte{0x01}, state)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serveCalls(ctx, e)
+
+	signedBlock := &types.SignedBlock{
+		Block:     &types.Block{Slot: 1, ProposerIndex: 0, ParentRoot: [32]byte{0x01}, Body: &types.BlockBody{}},
+		Signature: &types.BlockSignatures{},
+	}
+	err := e.CheckBlockForBroadcast(ctx, signedBlock)
+	var storeErr *store.StoreError
+	if !errors.As(err, &storeErr) || storeErr.Kind != store.ErrNotProposer {
+		t.Fatalf("err=%v, want ErrNotProposer", err)
+	}
+
+	signedBlock.Block.ParentRoot = [32]byte{0xee}
+	err = e.ImportBlock(ctx, signedBlock)
+	if !errors.As(err, &storeErr) || storeErr.Kind != store.ErrMissingParentState {
+		t.Fatalf("err=%v, want ErrMissingParentState", err)
+	}
+}
+
+func TestProduceBlockRejectsSlotsOtherThanCurrent(t *testing.T) {
+	e := makeTestEngine()
+	e.CallCh = make(chan func())
+	genesisMs := int64(e.Store.Config().GenesisTime) * 1000
+	e.Clock = clock.NewVirtual(time.UnixMilli(genesisMs + 5*int64(types.Spec().MillisecondsPerSlot())))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, slot := range []uint64{4, 6, 7, 1 << 40} {
+		_, err := e.ProduceBlock(ctx, slot, 0)
+		var storeErr *store.StoreError
+		if !errors.As(err, &storeErr) || storeErr.Kind != store.ErrProposalSlotOutOfRange {
+			t.Fatalf("slot %d: err=%v, want ErrProposalSlotOutOfRange", slot, err)
+		}
+	}
+}
+
+func TestProduceBlockRejectionLeavesStoreUnchanged(t *testing.T) {
+	e := makeTestEngine()
+	genesisRoot := [32]byte{0x01}
+	state := e.Store.GetState(genesisRoot)
+	state.Validators = []*types.Validator{{}, {}}
+	e.Store.InsertState(genesisRoot, state)
+	genesisMs := int64(e.Store.Config().GenesisTime) * 1000
+	e.Clock = clock.NewVirtual(time.UnixMilli(genesisMs + 5*int64(types.Spec().MillisecondsPerSlot())))
+
+	data := genesisAttestation(0).Data
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("data root: %v", err)
+	}
+	e.Store.NewPayloads.Push(dataRoot, data, &types.AggregatedSignatureProof{
+		Participants: types.BitlistFromIndices([]uint64{0}),
+		ProofData:    []byte{0x01},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serveCalls(ctx, e)
+
+	cases := []struct {
+		name     string
+		slot     uint64
+		proposer uint64
+		kind     store.StoreErrorKind
+	}{
+		{"wrong proposer", 5, 0, store.ErrNotProposer},
+		{"next slot", 6, 0, store.ErrProposalSlotOutOfRange},
+	}
+	for _, tc := range cases {
+		_, err := e.ProduceBlock(ctx, tc.slot, tc.proposer)
+		var storeErr *store.StoreError
+		if !errors.As(err, &storeErr) || storeErr.Kind != tc.kind {
+			t.Fatalf("%s: err=%v, want kind %v", tc.name, err, tc.kind)
+		}
+		if e.Store.KnownPayloads.Len() != 0 || e.Store.NewPayloads.Len() != 1 {
+			t.Fatalf("%s: new votes were promoted to known", tc.name)
+		}
+		if e.Store.Head() != genesisRoot {
+			t.Fatalf("%s: head moved to 0x%x", tc.name, e.Store.Head())
+		}
+	}
+}
@@ -7,9 +7,11 @@ import (
 )
 
 func (e *Engine) startWorkers(ctx context.Context) {
-	go e.runFetchBatcher(ctx)
-	go aggregation.RunWorker(ctx, e.AggregationDispatchCh, e.Store, e.Store.PubKeyCache, e.P2P)
-	go e.runAttestationWorker(ctx)
+	e.workers.Go(func() { e.runFetchBatcher(ctx) })
+	e.workers.Go(func() {
+		aggregation.RunWorker(ctx, e.AggregationDispatchCh, e.Store, e.Store.PubKeyCache, e.P2P)
+	})
+	e.workers.Go(func() { e.runAttestationWorker(ctx) })
 }
 
 func (e *Engine) runAttestationWorker(ctx context.Context) {
@@ -18,7 +20,17 @@ func (e *Engine) runAttestationWorker(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case att := <-e.AttestationCh:
-			go e.onGossipAttestation(att)
+			e.spawn(func() { e.onGossipAttestation(att) })
 		}
 	}
 }
+
+// spawn runs fn on a tracked goroutine, or inline once the engine is driven
+// by Tick and RunPending so that stepping stays deterministic.
+func (e *Engine) spawn(fn func()) {
+	if e.stepped {
+		fn()
+		return
+	}
+	e.workers.Go(fn)
+}
@@ -25,3 +25,34 @@ func TestRunAttestationWorkerStopsOnContextCancel(t *testing.T) {
 		t.Fatal("attestation worker did not stop after context cancellation")
 	}
 }
+
+func TestEngineRunWaitsForTrackedWork(t *testing.T) {
+	e := makeTestEngine()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e.Run(ctx)
+		close(done)
+	}()
+
+	release := make(chan struct{})
+	if err := e.call(ctx, func() {
+		e.workers.Go(func() { <-release })
+	}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("Engine.Run returned while tracked work was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Engine.Run did not return after tracked work finished")
+	}
+}
@@ -23,6 +23,8 @@ func (h *Host) Peers() []peer.ID {
 }
 
 func (h *Host) TopicMeshSizes() map[string]int {
+	h.topicsMu.RLock()
+	defer h.topicsMu.RUnlock()
 	sizes := make(map[string]int, len(h.topics))
 	for name, topic := range h.topics {
 		sizes[name] = len(topic.ListPeers())
@@ -31,6 +33,8 @@ func (h *Host) TopicMeshSizes() map[string]int {
 }
 
 func (h *Host) MeshPeerCount() int {
+	h.topicsMu.RLock()
+	defer h.topicsMu.RUnlock()
 	seen := make(map[peer.ID]struct{})
 	for _, topic := range h.topics {
 		for _, p := range topic.ListPeers() {
@@ -46,6 +50,8 @@ func (h *Host) LibP2PHost() libp2phost.Host {
 
 func (h *Host) Close() {
 	h.cancel()
+	h.topicsMu.RLock()
+	defer h.topicsMu.RUnlock()
 	for _, sub := range h.subs {
 		sub.Cancel()
 	}
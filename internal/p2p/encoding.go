@@ -14,6 +14,10 @@ const (
 	MaxPayloadSize           = 10 * 1024 * 1024
 	MaxCompressedPayloadSize = 32 + MaxPayloadSize + MaxPayloadSize/6 + 1024
 	MaxErrorMessageSize      = 256
+
+	// maxSnappyExpansion bounds the snappy block format: a 3-byte copy
+	// element produces at most 64 bytes.
+	maxSnappyExpansion = 22
 )
 
 func SnappyRawEncode(data []byte) []byte {
@@ -28,6 +32,12 @@ func SnappyRawDecode(data []byte) ([]byte, error) {
 	if decodedLen > MaxPayloadSize {
 		return nil, fmt.Errorf("snappy decoded len %d exceeds max %d", decodedLen, MaxPayloadSize)
 	}
+	// snappy.Decode allocates the declared length up front. No snappy element
+	// expands more than maxSnappyExpansion times, so a larger claim is bogus
+	// and must not cost an allocation.
+	if decodedLen > maxSnappyExpansion*len(data) {
+		return nil, fmt.Errorf("snappy decoded len %d impossible for %d input bytes", decodedLen, len(data))
+	}
 	return snappy.Decode(nil, data)
 }
 
@@ -123,10 +133,15 @@ func DecodeResponse(r io.Reader) (byte, []byte, error) {
 		return code, nil, fmt.Errorf("response length %d exceeds max %d", declaredLen, MaxPayloadSize)
 	}
 
-	decoded := make([]byte, declaredLen)
+	// Grow the buffer as data arrives rather than trusting the declared
+	// length, so a short response cannot force a large allocation.
+	payload := bytes.NewBuffer([]byte{})
 	sr := snappy.NewReader(br)
 	if declaredLen > 0 {
-		if _, err := io.ReadFull(sr, decoded); err != nil {
+		if _, err := io.CopyN(payload, sr, int64(declaredLen)); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
 			return code, nil, fmt.Errorf("decode response payload: %w", err)
 		}
 	} else {
@@ -139,6 +154,7 @@ func DecodeResponse(r io.Reader) (byte, []byte, error) {
 		}
 	}
 
+	decoded := payload.Bytes()
 	if code != RespSuccess && len(decoded) > MaxErrorMessageSize {
 		return code, nil, fmt.Errorf("error message %d bytes exceeds MaxErrorMessageSize %d", len(decoded), MaxErrorMessageSize)
 	}
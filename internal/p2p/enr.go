@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -195,3 +198,32 @@ func buildTransportMultiaddr(ip4, ip6 net.IP, udpPort, quicPort, udp6Port, quic6
 		quic6Port: quic6Port,
 	}).transportMultiaddr()
 }
+
+// EncodeENR builds a signed v4 ENR for the node with the given secp256k1
+// private key, advertising its QUIC listener at ip:quicPort.
+func EncodeENR(privKey []byte, ip net.IP, quicPort uint16, seq uint64) (string, error) {
+	key, err := gethcrypto.ToECDSA(privKey)
+	if err != nil {
+		return "", fmt.Errorf("parse node key: %w", err)
+	}
+
+	var r enr.Record
+	r.SetSeq(seq)
+	if ip4 := ip.To4(); ip4 != nil {
+		r.Set(enr.IPv4(ip4))
+		r.Set(enr.QUIC(quicPort))
+	} else if ip != nil {
+		r.Set(enr.IPv6(ip))
+		r.Set(enr.QUIC6(quicPort))
+	} else {
+		return "", fmt.Errorf("ENR needs an IP address")
+	}
+	if err := enode.SignV4(&r, key); err != nil {
+		return "", fmt.Errorf("sign ENR: %w", err)
+	}
+	node, err := enode.New(enode.ValidSchemes, &r)
+	if err != nil {
+		return "", fmt.Errorf("build ENR: %w", err)
+	}
+	return node.String(), nil
+}
@@ -1,9 +1,13 @@
 package p2p
 
 import (
+	"bytes"
 	"net"
 	"strings"
 	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 func TestParseENR(t *testing.T) {
@@ -60,3 +64,29 @@ func TestParseENR_PrefersIPv4(t *testing.T) {
 		t.Errorf("expected /ip4/ preference, got %q", maStr)
 	}
 }
+
+func TestEncodeENRRoundTrip(t *testing.T) {
+	privKey := bytes.Repeat([]byte{0x11}, 32)
+	libp2pKey, err := crypto.UnmarshalSecp256k1PrivateKey(privKey)
+	if err != nil {
+		t.Fatalf("unmarshal key: %v", err)
+	}
+	wantID, err := peer.IDFromPrivateKey(libp2pKey)
+	if err != nil {
+		t.Fatalf("peer id: %v", err)
+	}
+
+	for _, ip := range []string{"10.0.0.7", "2001:db8::7"} {
+		record, err := EncodeENR(privKey, net.ParseIP(ip), 9001, 1)
+		if err != nil {
+			t.Fatalf("encode %s: %v", ip, err)
+		}
+		fields, err := DecodeENR(record)
+		if err != nil {
+			t.Fatalf("decode %s: %v", ip, err)
+		}
+		if fields.PeerID != wantID || fields.Seq != 1 || !strings.Contains(fields.Multiaddr, ip+"/udp/9001/quic-v1") {
+			t.Fatalf("fields=%+v, want peer %s at %s", fields, wantID, ip)
+		}
+	}
+}
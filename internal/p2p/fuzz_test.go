@@ -0,0 +1,175 @@
+package p2p
+
+import (
+	"bytes"
+	"net"
+	"runtime"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/specfixtures"
+)
+
+// maxDecodeAlloc bounds what decoding n wire bytes may allocate: the snappy
+// reader's fixed buffers plus output linear in the input, since no snappy
+// element expands more than maxSnappyExpansion times.
+func maxDecodeAlloc(n int) uint64 {
+	return 1<<20 + 8*maxSnappyExpansion*uint64(n)
+}
+
+func allocatedBytes(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func checkAlloc(t *testing.T, n int, fn func()) {
+	t.Helper()
+	if got := allocatedBytes(fn); got > maxDecodeAlloc(n) {
+		t.Fatalf("decoding %d bytes allocated %d bytes", n, got)
+	}
+}
+
+func sampleStatus() *StatusMessage {
+	return &StatusMessage{FinalizedRoot: [32]byte{1}, FinalizedSlot: 4, HeadRoot: [32]byte{2}, HeadSlot: 9}
+}
+
+func addSSZSeeds(f *testing.F, typeName string) {
+	var roots []string
+	for _, root := range specfixtures.SSZFixtureRoots {
+		roots = append(roots, "../../"+root)
+	}
+	for _, b := range specfixtures.SSZSeeds(typeName, roots...) {
+		f.Add(b)
+	}
+}
+
+func FuzzSnappyRawDecode(f *testing.F) {
+	f.Add(SnappyRawEncode(nil))
+	f.Add(SnappyRawEncode(sampleStatus().MarshalSSZ()))
+	f.Add(SnappyRawEncode(bytes.Repeat([]byte{0xab}, 4096)))
+	f.Add([]byte{0xff, 0xff, 0xff, 0x04})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decoded []byte
+		var err error
+		checkAlloc(t, len(data), func() { decoded, err = SnappyRawDecode(data) })
+		if err != nil {
+			return
+		}
+		if len(decoded) > MaxPayloadSize {
+			t.Fatalf("decoded %d bytes, max %d", len(decoded), MaxPayloadSize)
+		}
+		again, err := SnappyRawDecode(SnappyRawEncode(decoded))
+		if err != nil || !bytes.Equal(again, decoded) {
+			t.Fatalf("re-encoded payload does not decode back: %v", err)
+		}
+	})
+}
+
+func FuzzDecodeReqRespPayload(f *testing.F) {
+	f.Add(EncodeReqRespPayload(nil))
+	f.Add(EncodeReqRespPayload(sampleStatus().MarshalSSZ()))
+	f.Add(EncodeReqRespPayload(EncodeBlocksByRootRequest([][32]byte{{1}, {2}})))
+	f.Add(EncodeVarint(MaxPayloadSize))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decoded []byte
+		var err error
+		checkAlloc(t, len(data), func() { decoded, err = DecodeReqRespPayload(data) })
+		if err != nil {
+			return
+		}
+		if len(decoded) > MaxPayloadSize {
+			t.Fatalf("decoded %d bytes, max %d", len(decoded), MaxPayloadSize)
+		}
+		again, err := DecodeReqRespPayload(EncodeReqRespPayload(decoded))
+		if err != nil || !bytes.Equal(again, decoded) {
+			t.Fatalf("re-encoded payload does not decode back: %v", err)
+		}
+	})
+}
+
+func FuzzDecodeResponse(f *testing.F) {
+	f.Add(EncodeResponse(RespSuccess, sampleStatus().MarshalSSZ()))
+	f.Add(EncodeResponse(RespResourceUnavailable, []byte("block not found")))
+	f.Add(append(EncodeResponse(RespSuccess, []byte{1}), EncodeResponse(RespSuccess, []byte{2})...))
+	f.Add(append([]byte{RespSuccess}, EncodeVarint(MaxPayloadSize)...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var code byte
+		var decoded []byte
+		var err error
+		checkAlloc(t, len(data), func() { code, decoded, err = DecodeResponse(bytes.NewReader(data)) })
+		if err != nil {
+			return
+		}
+		if len(decoded) > MaxPayloadSize || (code != RespSuccess && len(decoded) > MaxErrorMessageSize) {
+			t.Fatalf("code %d decoded %d bytes past its limit", code, len(decoded))
+		}
+		code2, again, err := DecodeResponse(bytes.NewReader(EncodeResponse(code, decoded)))
+		if err != nil || code2 != code || !bytes.Equal(again, decoded) {
+			t.Fatalf("re-encoded response does not decode back: %v", err)
+		}
+	})
+}
+
+func FuzzDecodeBlocksByRootRequest(f *testing.F) {
+	f.Add(EncodeBlocksByRootRequest(nil))
+	f.Add(EncodeBlocksByRootRequest([][32]byte{{1}, {2}, {3}}))
+	addSSZSeeds(f, "BlocksByRootRequest")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var roots [][32]byte
+		var err error
+		checkAlloc(t, len(data), func() { roots, err = DecodeBlocksByRootRequest(data) })
+		if err != nil {
+			return
+		}
+		if enc := EncodeBlocksByRootRequest(roots); !bytes.Equal(enc, data) {
+			t.Fatalf("roots re-encode differently:\n in:  %x\n out: %x", data, enc)
+		}
+	})
+}
+
+func FuzzStatusMessage(f *testing.F) {
+	f.Add(sampleStatus().MarshalSSZ())
+	addSSZSeeds(f, "Status")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var status StatusMessage
+		if err := status.UnmarshalSSZ(data); err != nil {
+			return
+		}
+		if enc := status.MarshalSSZ(); !bytes.Equal(enc, data) {
+			t.Fatalf("status re-encodes differently:\n in:  %x\n out: %x", data, enc)
+		}
+	})
+}
+
+func FuzzParseENRRecord(f *testing.F) {
+	f.Add("enr:-IW4QGGifTt9ypyMtChDISUNX3z4z5iPdiEPOmBoILvnDuWIKbWVmKXxZERPnw0piQyaBNCENFEPoIi-vxsnsrBig9MBgmlkgnY0gmlwhH8AAAGEcXVpY4IjKYlzZWNwMjU2azGhAhMMnGF1rmIPQ9tWgqfkNmvsG-aIyc9EJU5JFo3Tegys")
+	if enr, err := EncodeENR(bytes.Repeat([]byte{0x11}, 32), net.ParseIP("2001:db8::7"), 9001, 3); err == nil {
+		f.Add(enr)
+	}
+	for _, enr := range specfixtures.ENRSeeds("../../" + specfixtures.NetworkingCodecFixtureRoot) {
+		f.Add(enr)
+	}
+
+	f.Fuzz(func(t *testing.T, enr string) {
+		var rec *enrRecord
+		var err error
+		checkAlloc(t, len(enr), func() { rec, err = parseENRRecord(enr) })
+		if err != nil {
+			return
+		}
+		fields, err := DecodeENR(enr)
+		if err != nil || fields.PeerID != rec.peerID || fields.Multiaddr != rec.transportMultiaddr() {
+			t.Fatalf("DecodeENR disagrees with the parsed record: %v", err)
+		}
+		if ma, err := ParseENR(enr); err == nil && ma.String() != fields.Multiaddr+"/p2p/"+rec.peerID.String() {
+			t.Fatalf("ParseENR multiaddr %s, want %s", ma, fields.Multiaddr)
+		}
+	})
+}
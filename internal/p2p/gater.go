@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// banGater refuses dials to and connections from banned peers. Bans last
+// until the process exits or the peer is unbanned.
+type banGater struct {
+	mu     sync.RWMutex
+	banned map[peer.ID]struct{}
+}
+
+func newBanGater() *banGater {
+	return &banGater{banned: make(map[peer.ID]struct{})}
+}
+
+func (g *banGater) ban(id peer.ID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.banned[id] = struct{}{}
+}
+
+func (g *banGater) unban(id peer.ID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.banned[id]
+	delete(g.banned, id)
+	return ok
+}
+
+func (g *banGater) isBanned(id peer.ID) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.banned[id]
+	return ok
+}
+
+func (g *banGater) InterceptPeerDial(p peer.ID) bool {
+	return !g.isBanned(p)
+}
+
+func (g *banGater) InterceptAddrDial(p peer.ID, _ multiaddr.Multiaddr) bool {
+	return !g.isBanned(p)
+}
+
+func (g *banGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+func (g *banGater) InterceptSecured(_ network.Direction, p peer.ID, _ network.ConnMultiaddrs) bool {
+	return !g.isBanned(p)
+}
+
+func (g *banGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
@@ -0,0 +1,111 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/geanlabs/gean/internal/logger"
+)
+
+// Goodbye reason codes, as in the beacon chain req/resp spec.
+const (
+	GoodbyeClientShutdown    uint64 = 1
+	GoodbyeIrrelevantNetwork uint64 = 2
+	GoodbyeFaultOrError      uint64 = 3
+)
+
+const goodbyeMessageSize = 8
+
+func goodbyeReasonString(reason uint64) string {
+	switch reason {
+	case GoodbyeClientShutdown:
+		return "client shutdown"
+	case GoodbyeIrrelevantNetwork:
+		return "irrelevant network"
+	case GoodbyeFaultOrError:
+		return "fault/error"
+	default:
+		return fmt.Sprintf("reason %d", reason)
+	}
+}
+
+// handleGoodbye logs the peer's reason and disconnects it. Goodbye has no
+// response.
+func (h *Host) handleGoodbye(stream network.Stream) {
+	remote := stream.Conn().RemotePeer()
+	reqBuf, err := io.ReadAll(io.LimitReader(stream, int64(MaxCompressedPayloadSize)))
+	stream.Close()
+	if err != nil {
+		logger.Warn(logger.Network, "goodbye: read from %s failed: %v", remote, err)
+	} else if payload, err := DecodeReqRespPayload(reqBuf); err != nil || len(payload) != goodbyeMessageSize {
+		logger.Warn(logger.Network, "goodbye: malformed message from %s", remote)
+	} else {
+		logger.Info(logger.Network, "goodbye: peer %s is leaving (%s)",
+			remote, goodbyeReasonString(binary.LittleEndian.Uint64(payload)))
+	}
+	if err := h.host.Network().ClosePeer(remote); err != nil {
+		logger.Warn(logger.Network, "goodbye: close peer %s: %v", remote, err)
+	}
+}
+
+// SendGoodbye tells one peer why the node is disconnecting.
+func (h *Host) SendGoodbye(ctx context.Context, peerID peer.ID, reason uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, ReqRespTimeout)
+	defer cancel()
+
+	stream, err := h.host.NewStream(ctx, peerID, protocol.ID(GoodbyeProtocol))
+	if err != nil {
+		return fmt.Errorf("open goodbye stream: %w", err)
+	}
+	defer stream.Close()
+
+	payload := make([]byte, goodbyeMessageSize)
+	binary.LittleEndian.PutUint64(payload, reason)
+	if _, err := stream.Write(EncodeReqRespPayload(payload)); err != nil {
+		return fmt.Errorf("write goodbye: %w", err)
+	}
+	return stream.CloseWrite()
+}
+
+// SayGoodbye sends goodbye to every connected peer in parallel and returns
+// how many received it. It returns early when ctx is done.
+func (h *Host) SayGoodbye(ctx context.Context, reason uint64) int {
+	peers := h.host.Network().Peers()
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		sent int
+	)
+	for _, id := range peers {
+		wg.Go(func() {
+			if err := h.SendGoodbye(ctx, id, reason); err != nil {
+				logger.Warn(logger.Network, "goodbye to %s failed: %v", id, err)
+				return
+			}
+			mu.Lock()
+			sent++
+			mu.Unlock()
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	logger.Info(logger.Network, "sent goodbye to %d/%d peers", sent, len(peers))
+	return sent
+}
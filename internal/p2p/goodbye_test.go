@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/multiformats/go-multiaddr"
+)
+
+func connectTestRemote(t *testing.T, h *Host) host.Host {
+	t.Helper()
+	remote, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatalf("remote host: %v", err)
+	}
+	t.Cleanup(func() { remote.Close() })
+	addr, err := multiaddr.NewMultiaddr(remote.Addrs()[0].String() + "/p2p/" + remote.ID().String())
+	if err != nil {
+		t.Fatalf("remote addr: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.ConnectPeer(ctx, addr); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	return remote
+}
+
+func TestSayGoodbyeSendsReasonToPeers(t *testing.T) {
+	h := newTestHost(t, 1)
+	remote := connectTestRemote(t, h)
+
+	reasons := make(chan uint64, 1)
+	remote.SetStreamHandler(protocol.ID(GoodbyeProtocol), func(s network.Stream) {
+		defer s.Close()
+		buf, _ := io.ReadAll(s)
+		payload, err := DecodeReqRespPayload(buf)
+		if err != nil || len(payload) != goodbyeMessageSize {
+			reasons <- 0
+			return
+		}
+		reasons <- binary.LittleEndian.Uint64(payload)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if sent := h.SayGoodbye(ctx, GoodbyeClientShutdown); sent != 1 {
+		t.Fatalf("sent=%d, want 1", sent)
+	}
+	select {
+	case reason := <-reasons:
+		if reason != GoodbyeClientShutdown {
+			t.Fatalf("reason=%d, want %d", reason, GoodbyeClientShutdown)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("remote did not receive goodbye")
+	}
+}
+
+func TestHandleGoodbyeDisconnectsPeer(t *testing.T) {
+	h := newTestHost(t, 1)
+	h.installPeerNotifier()
+	h.host.SetStreamHandler(protocol.ID(GoodbyeProtocol), h.handleGoodbye)
+	remote := connectTestRemote(t, h)
+	waitFor(t, func() bool { return h.ConnectedPeers() == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s, err := remote.NewStream(ctx, h.PeerID(), protocol.ID(GoodbyeProtocol))
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	payload := make([]byte, goodbyeMessageSize)
+	binary.LittleEndian.PutUint64(payload, GoodbyeClientShutdown)
+	if _, err := s.Write(EncodeReqRespPayload(payload)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s.CloseWrite()
+
+	waitFor(t, func() bool { return h.ConnectedPeers() == 0 })
+}
@@ -8,6 +8,7 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 
 	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/tracing"
 	"github.com/geanlabs/gean/internal/types"
 )
 
@@ -21,6 +22,11 @@ func (h *Host) StartGossipListeners(handler MessageHandler) {
 	if handler == nil {
 		return
 	}
+	h.topicsMu.Lock()
+	defer h.topicsMu.Unlock()
+	if h.gossipStopped {
+		return
+	}
 	h.gossipHandler = handler
 	for topic, sub := range h.subs {
 		go h.listenTopic(h.ctx, topic, sub, handler)
@@ -88,7 +94,10 @@ func (h *Host) dispatchMessage(topic string, data []byte, handler MessageHandler
 		if err := att.UnmarshalSSZ(data); err != nil {
 			return fmt.Errorf("unmarshal attestation (%d bytes): %w", len(data), err)
 		}
+		span := startGossipSpan(context.Background(), att.Data, "p2p.receive", topic,
+			tracing.Uint("validator.index", att.ValidatorID), tracing.Int("p2p.size", int64(len(data))))
 		handler.OnGossipAttestation(att)
+		span.End(nil)
 
 	case topic == AggregationTopic():
 		if h.Hooks.GossipAggregationSize != nil {
@@ -98,7 +107,10 @@ func (h *Host) dispatchMessage(topic string, data []byte, handler MessageHandler
 		if err := agg.UnmarshalSSZ(data); err != nil {
 			return fmt.Errorf("unmarshal aggregation (%d bytes): %w", len(data), err)
 		}
+		span := startGossipSpan(context.Background(), agg.Data, "p2p.receive", topic,
+			tracing.Int("p2p.size", int64(len(data))))
 		handler.OnGossipAggregatedAttestation(agg)
+		span.End(nil)
 
 	default:
 		return fmt.Errorf("unknown topic: %s", topic)
@@ -15,7 +15,9 @@ func (h *Host) RegisterReqRespHandlers(
 ) {
 	h.host.SetStreamHandler(protocol.ID(StatusProtocol), func(s network.Stream) {
 		defer s.Close()
-		handleStatusRequest(s, statusFn)
+		if peerStatus := handleStatusRequest(s, statusFn); peerStatus != nil {
+			h.peerStore.SetStatus(s.Conn().RemotePeer(), peerStatus)
+		}
 	})
 
 	h.host.SetStreamHandler(protocol.ID(BlocksByRootProtocol), func(s network.Stream) {
@@ -27,4 +29,6 @@ func (h *Host) RegisterReqRespHandlers(
 		defer s.Close()
 		handleBlocksByRangeRequest(s, currentSlotFn, blocksInRangeFn)
 	})
+
+	h.host.SetStreamHandler(protocol.ID(GoodbyeProtocol), h.handleGoodbye)
 }
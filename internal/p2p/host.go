@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -11,13 +12,20 @@ import (
 type Host struct {
 	host          host.Host
 	pubsub        *pubsub.PubSub
+	topicsMu      sync.RWMutex
 	topics        map[string]*pubsub.Topic
 	subs          map[string]*pubsub.Subscription
 	ctx           context.Context
 	cancel        context.CancelFunc
 	peerStore     *PeerStore
+	gater         *banGater
 	gossipHandler MessageHandler
+	gossipStopped bool
 	Hooks         Hooks
+
+	committeeCount     uint64
+	isAggregator       bool
+	aggregateSubnetIDs []uint64
 }
 
 func NewHost(
@@ -37,7 +45,8 @@ func NewHost(
 		return nil, fmt.Errorf("load node key: %w", err)
 	}
 
-	libp2pHost, err := newLibP2PHost(privKey, listenPort)
+	gater := newBanGater()
+	libp2pHost, err := newLibP2PHost(privKey, listenPort, gater)
 	if err != nil {
 		cancel()
 		return nil, err
@@ -58,6 +67,11 @@ func NewHost(
 		ctx:       ctx,
 		cancel:    cancel,
 		peerStore: NewPeerStore(),
+		gater:     gater,
+
+		committeeCount:     committeeCount,
+		isAggregator:       isAggregator,
+		aggregateSubnetIDs: aggregateSubnetIDs,
 	}
 	p2pHost.installPeerNotifier()
 
@@ -10,8 +10,8 @@ func (h *Host) installPeerNotifier() {
 	h.host.Network().Notify(&network.NotifyBundle{
 		ConnectedF: func(n network.Network, conn network.Conn) {
 			peerID := conn.RemotePeer()
-			isNew := h.peerStore.AddNew(peerID)
 			direction := directionLabel(conn.Stat().Direction)
+			isNew := h.peerStore.AddNew(peerID, direction)
 			count := h.peerStore.Count()
 
 			logger.Info(logger.Network, "peer connected peer_id=%s direction=%s peers=%d",
@@ -0,0 +1,81 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+var ErrPeerNotConnected = errors.New("peer not connected")
+
+// PeerInfo is a snapshot of what the node knows about a connected peer.
+// Status is the last status message exchanged with it, if any.
+type PeerInfo struct {
+	ID          peer.ID
+	Addrs       []multiaddr.Multiaddr
+	Direction   string
+	ConnectedAt time.Time
+	Status      *StatusMessage
+	StatusAt    time.Time
+	Agent       string
+	Score       int
+}
+
+func (h *Host) PeerInfos() []PeerInfo {
+	ids := h.peerStore.AllPeers()
+	infos := make([]PeerInfo, 0, len(ids))
+	for _, id := range ids {
+		if info, ok := h.PeerInfo(id); ok {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+func (h *Host) PeerInfo(id peer.ID) (PeerInfo, bool) {
+	rec, ok := h.peerStore.record(id)
+	if !ok {
+		return PeerInfo{}, false
+	}
+	info := PeerInfo{
+		ID:          id,
+		Direction:   rec.direction,
+		ConnectedAt: rec.connectedAt,
+		Status:      rec.status,
+		StatusAt:    rec.statusAt,
+		Score:       rec.score,
+	}
+	for _, conn := range h.host.Network().ConnsToPeer(id) {
+		info.Addrs = append(info.Addrs, conn.RemoteMultiaddr())
+	}
+	if agent, err := h.host.Peerstore().Get(id, "AgentVersion"); err == nil {
+		info.Agent, _ = agent.(string)
+	}
+	return info, true
+}
+
+func (h *Host) DisconnectPeer(id peer.ID) error {
+	if _, ok := h.peerStore.record(id); !ok {
+		return ErrPeerNotConnected
+	}
+	if err := h.host.Network().ClosePeer(id); err != nil {
+		return fmt.Errorf("close peer %s: %w", id, err)
+	}
+	return nil
+}
+
+// BanPeer disconnects the peer and refuses further connections from it.
+func (h *Host) BanPeer(id peer.ID) error {
+	h.gater.ban(id)
+	if err := h.host.Network().ClosePeer(id); err != nil {
+		return fmt.Errorf("close peer %s: %w", id, err)
+	}
+	return nil
+}
+
+func (h *Host) UnbanPeer(id peer.ID) bool {
+	return h.gater.unban(id)
+}
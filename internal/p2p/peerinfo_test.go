@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/multiformats/go-multiaddr"
+)
+
+func TestPeerStoreStatusAndScore(t *testing.T) {
+	ps := NewPeerStore()
+	if !ps.AddNew("peer1", "outbound") || ps.AddNew("peer1", "inbound") {
+		t.Fatal("AddNew should report only the first insert")
+	}
+	ps.SetStatus("peer1", &StatusMessage{HeadSlot: 7})
+	ps.SetStatus("unknown", &StatusMessage{HeadSlot: 9})
+	for range PeerScoreMax + 5 {
+		ps.AdjustScore("peer1", 1)
+	}
+
+	rec, ok := ps.record("peer1")
+	if !ok || rec.direction != "outbound" || rec.status.HeadSlot != 7 || rec.score != PeerScoreMax {
+		t.Fatalf("record=%+v ok=%v", rec, ok)
+	}
+	if _, ok := ps.record("unknown"); ok {
+		t.Fatal("status for an unknown peer should not create a record")
+	}
+}
+
+func TestPeerInfoDisconnectAndBan(t *testing.T) {
+	h := newTestHost(t, 1)
+	h.installPeerNotifier()
+
+	remote, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatalf("remote host: %v", err)
+	}
+	t.Cleanup(func() { remote.Close() })
+	addr, err := multiaddr.NewMultiaddr(remote.Addrs()[0].String() + "/p2p/" + remote.ID().String())
+	if err != nil {
+		t.Fatalf("remote addr: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.ConnectPeer(ctx, addr); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	waitFor(t, func() bool { _, ok := h.PeerInfo(remote.ID()); return ok })
+
+	info, _ := h.PeerInfo(remote.ID())
+	if info.Direction != "outbound" || len(info.Addrs) == 0 || info.ConnectedAt.IsZero() {
+		t.Fatalf("info=%+v", info)
+	}
+
+	if err := h.BanPeer(remote.ID()); err != nil {
+		t.Fatalf("ban: %v", err)
+	}
+	waitFor(t, func() bool { return h.ConnectedPeers() == 0 })
+	if err := h.ConnectPeer(ctx, addr); err == nil {
+		t.Fatal("dial to a banned peer should fail")
+	}
+	if err := h.DisconnectPeer(remote.ID()); err != ErrPeerNotConnected {
+		t.Fatalf("disconnect err=%v, want ErrPeerNotConnected", err)
+	}
+
+	if !h.UnbanPeer(remote.ID()) {
+		t.Fatal("unban should report the peer was banned")
+	}
+	if err := h.ConnectPeer(ctx, addr); err != nil {
+		t.Fatalf("reconnect after unban: %v", err)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
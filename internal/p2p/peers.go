@@ -3,33 +3,46 @@ package p2p
 import (
 	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// Peer scores move by one per req/resp outcome and are clamped to this range.
+const (
+	PeerScoreMin = -100
+	PeerScoreMax = 100
+)
+
+type peerRecord struct {
+	direction   string
+	connectedAt time.Time
+	status      *StatusMessage
+	statusAt    time.Time
+	score       int
+}
+
 type PeerStore struct {
 	mu    sync.RWMutex
-	peers map[peer.ID]bool
+	peers map[peer.ID]*peerRecord
 }
 
 func NewPeerStore() *PeerStore {
-	return &PeerStore{peers: make(map[peer.ID]bool)}
+	return &PeerStore{peers: make(map[peer.ID]*peerRecord)}
 }
 
 func (ps *PeerStore) Add(id peer.ID) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-	ps.peers[id] = true
+	ps.AddNew(id, "")
 }
 
-func (ps *PeerStore) AddNew(id peer.ID) bool {
+func (ps *PeerStore) AddNew(id peer.ID, direction string) bool {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
-	if ps.peers[id] {
+	if ps.peers[id] != nil {
 		return false
 	}
-	ps.peers[id] = true
+	ps.peers[id] = &peerRecord{direction: direction, connectedAt: time.Now()}
 	return true
 }
 
@@ -45,6 +58,23 @@ func (ps *PeerStore) Count() int {
 	return len(ps.peers)
 }
 
+func (ps *PeerStore) SetStatus(id peer.ID, status *StatusMessage) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if rec := ps.peers[id]; rec != nil {
+		rec.status = status
+		rec.statusAt = time.Now()
+	}
+}
+
+func (ps *PeerStore) AdjustScore(id peer.ID, delta int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if rec := ps.peers[id]; rec != nil {
+		rec.score = min(max(rec.score+delta, PeerScoreMin), PeerScoreMax)
+	}
+}
+
 func (ps *PeerStore) RandomPeer(exclude map[peer.ID]bool) peer.ID {
 	ps.mu.RLock()
 	defer ps.mu.RUnlock()
@@ -71,6 +101,16 @@ func (ps *PeerStore) AllPeers() []peer.ID {
 	return ids
 }
 
+func (ps *PeerStore) record(id peer.ID) (peerRecord, bool) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	rec := ps.peers[id]
+	if rec == nil {
+		return peerRecord{}, false
+	}
+	return *rec, true
+}
+
 func directionLabel(d network.Direction) string {
 	if d == network.DirOutbound {
 		return "outbound"
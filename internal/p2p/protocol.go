@@ -6,6 +6,7 @@ const (
 	StatusProtocol        = "/leanconsensus/req/status/1/ssz_snappy"
 	BlocksByRootProtocol  = "/leanconsensus/req/blocks_by_root/1/ssz_snappy"
 	BlocksByRangeProtocol = "/leanconsensus/req/blocks_by_range/1/ssz_snappy"
+	GoodbyeProtocol       = "/leanconsensus/req/goodbye/1/ssz_snappy"
 )
 
 const ReqRespTimeout = 15 * time.Second
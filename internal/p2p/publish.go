@@ -7,6 +7,7 @@ import (
 	"runtime/debug"
 
 	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/tracing"
 	"github.com/geanlabs/gean/internal/types"
 )
 
@@ -67,7 +68,11 @@ func (h *Host) PublishAttestation(ctx context.Context, att *types.SignedAttestat
 	}
 	subnet := SubnetID(att.ValidatorID, committeeCount)
 	topic := AttestationSubnetTopic(subnet)
-	return h.publishToTopic(ctx, topic, data, info)
+	span := startGossipSpan(ctx, att.Data, "p2p.publish", topic,
+		tracing.Uint("validator.index", att.ValidatorID), tracing.Uint("p2p.subnet", subnet))
+	err = h.publishToTopic(ctx, topic, data, info)
+	span.End(err)
+	return err
 }
 
 func (h *Host) PublishAggregatedAttestation(ctx context.Context, agg *types.SignedAggregatedAttestation) error {
@@ -86,13 +91,18 @@ func (h *Host) PublishAggregatedAttestation(ctx context.Context, agg *types.Sign
 			info.hasBlockRoot = true
 		}
 	}
-	return h.publishToTopic(ctx, AggregationTopic(), data, info)
+	span := startGossipSpan(ctx, agg.Data, "p2p.publish", AggregationTopic())
+	err = h.publishToTopic(ctx, AggregationTopic(), data, info)
+	span.End(err)
+	return err
 }
 
 func (h *Host) publishToTopic(ctx context.Context, topic string, sszData []byte, info publishLogInfo) error {
 	compressed := SnappyRawEncode(sszData)
 	logPublish(topic, sszData, compressed, info)
+	h.topicsMu.RLock()
 	t, ok := h.topics[topic]
+	h.topicsMu.RUnlock()
 	if !ok {
 		return fmt.Errorf("not subscribed to topic: %s", topic)
 	}
@@ -56,10 +56,12 @@ func (h *Host) FetchBlocksByRootBatchWithRetry(ctx context.Context, roots [][32]
 
 		blocks, err := h.FetchBlocksByRoot(ctx, peerID, roots)
 		if err == nil && len(blocks) > 0 {
+			h.peerStore.AdjustScore(peerID, 1)
 			return blocks, computeMissingRoots(roots, blocks), nil
 		}
 
 		excluded[peerID] = true
+		h.peerStore.AdjustScore(peerID, -1)
 		h.logFetchFailure("batch block fetch", attempt, peerID, err, "root_count", len(roots))
 
 		select {
@@ -92,10 +94,12 @@ func (h *Host) FetchBlocksByRangeWithRetry(
 
 		blocks, err := h.FetchBlocksByRange(ctx, peerID, startSlot, count)
 		if err == nil && len(blocks) > 0 {
+			h.peerStore.AdjustScore(peerID, 1)
 			return blocks, nil
 		}
 
 		excluded[peerID] = true
+		h.peerStore.AdjustScore(peerID, -1)
 		h.logFetchFailure("blocks_by_range fetch", attempt, peerID, err, "start_slot", startSlot)
 
 		select {
@@ -121,10 +125,12 @@ func (h *Host) fetchSingleBlockWithRetry(ctx context.Context, root [32]byte) ([]
 
 		blocks, err := h.FetchBlocksByRoot(ctx, peerID, [][32]byte{root})
 		if err == nil && len(blocks) > 0 {
+			h.peerStore.AdjustScore(peerID, 1)
 			return blocks, nil
 		}
 
 		excluded[peerID] = true
+		h.peerStore.AdjustScore(peerID, -1)
 		h.logFetchFailure("block fetch", attempt, peerID, err, "block_root", fmt.Sprintf("0x%x", root))
 
 		select {
@@ -28,7 +28,7 @@ const (
 	GossipMaxMsgPerRPC      = 500
 )
 
-func newLibP2PHost(privKey libp2pcrypto.PrivKey, listenPort int) (libp2phost.Host, error) {
+func newLibP2PHost(privKey libp2pcrypto.PrivKey, listenPort int, gater *banGater) (libp2phost.Host, error) {
 	listenAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/0.0.0.0/udp/%d/quic-v1", listenPort))
 	if err != nil {
 		return nil, fmt.Errorf("build listen addr: %w", err)
@@ -39,6 +39,7 @@ func newLibP2PHost(privKey libp2pcrypto.PrivKey, listenPort int) (libp2phost.Hos
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.Transport(libp2pquic.NewTransport),
 		libp2p.DisableRelay(),
+		libp2p.ConnectionGater(gater),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create libp2p host: %w", err)
@@ -42,29 +42,31 @@ func (s *StatusMessage) UnmarshalSSZ(buf []byte) error {
 	return nil
 }
 
-func handleStatusRequest(stream network.Stream, statusFn func() *StatusMessage) {
+// handleStatusRequest answers a status request and returns the peer's status,
+// or nil when the request was malformed.
+func handleStatusRequest(stream network.Stream, statusFn func() *StatusMessage) *StatusMessage {
 	reqBuf, err := io.ReadAll(io.LimitReader(stream, int64(MaxCompressedPayloadSize)))
 	if err != nil {
 		logger.Warn(logger.Network, "status: read request failed: %v", err)
-		return
+		return nil
 	}
 	if len(reqBuf) == 0 {
 		writeResponse(stream, "status", RespInvalidRequest, []byte("empty status request"))
-		return
+		return nil
 	}
 
 	payload, err := DecodeReqRespPayload(reqBuf)
 	if err != nil {
 		logger.Warn(logger.Network, "status: decode request failed: %v", err)
 		writeResponse(stream, "status", RespInvalidRequest, []byte("decode failed"))
-		return
+		return nil
 	}
 
 	peerStatus := &StatusMessage{}
 	if err := peerStatus.UnmarshalSSZ(payload); err != nil {
 		logger.Warn(logger.Network, "status: ssz unmarshal failed: %v", err)
 		writeResponse(stream, "status", RespInvalidRequest, []byte("ssz unmarshal failed"))
-		return
+		return nil
 	}
 
 	logger.Info(logger.Network, "status: peer at slot %d finalized=%d", peerStatus.HeadSlot, peerStatus.FinalizedSlot)
@@ -72,9 +74,10 @@ func handleStatusRequest(stream network.Stream, statusFn func() *StatusMessage)
 	status := statusFn()
 	if status == nil {
 		writeResponse(stream, "status", RespServerError, []byte("status unavailable"))
-		return
+		return peerStatus
 	}
 	writeResponse(stream, "status", RespSuccess, status.MarshalSSZ())
+	return peerStatus
 }
 
 func (h *Host) SendStatusRequest(ctx context.Context, peerID peer.ID, ourStatus *StatusMessage) (*StatusMessage, error) {
@@ -108,5 +111,6 @@ func (h *Host) SendStatusRequest(ctx context.Context, peerID peer.ID, ourStatus
 	if err := peerStatus.UnmarshalSSZ(respData); err != nil {
 		return nil, fmt.Errorf("unmarshal status: %w", err)
 	}
+	h.peerStore.SetStatus(peerID, peerStatus)
 	return peerStatus, nil
 }
@@ -7,20 +7,38 @@ import (
 )
 
 func (h *Host) JoinTopic(topic string) error {
-	t, err := h.pubsub.Join(topic)
-	if err != nil {
-		return fmt.Errorf("join topic %s: %w", topic, err)
+	h.topicsMu.Lock()
+	defer h.topicsMu.Unlock()
+	return h.joinTopicLocked(topic)
+}
+
+func (h *Host) joinTopicLocked(topic string) error {
+	if _, ok := h.subs[topic]; ok {
+		return nil
+	}
+	t, ok := h.topics[topic]
+	if !ok {
+		var err error
+		t, err = h.pubsub.Join(topic)
+		if err != nil {
+			return fmt.Errorf("join topic %s: %w", topic, err)
+		}
+		h.topics[topic] = t
 	}
 	sub, err := t.Subscribe()
 	if err != nil {
 		return fmt.Errorf("subscribe to %s: %w", topic, err)
 	}
-	h.topics[topic] = t
 	h.subs[topic] = sub
 	return nil
 }
 
 func (h *Host) ReannounceSubscriptions() error {
+	h.topicsMu.Lock()
+	defer h.topicsMu.Unlock()
+	if h.gossipStopped {
+		return nil
+	}
 	if h.gossipHandler == nil {
 		return fmt.Errorf("reannounce: gossip listeners not started yet")
 	}
@@ -61,6 +79,8 @@ func (h *Host) subscribeStartupTopics(
 		}
 	}
 
+	h.topicsMu.RLock()
+	defer h.topicsMu.RUnlock()
 	for topic := range h.topics {
 		logger.Info(logger.Network, "subscribed topic=%s", topic)
 	}
@@ -87,3 +107,53 @@ func initialAttestationSubnets(
 	}
 	return seen
 }
+
+// UpdateValidatorSubnets reconciles attestation subnet subscriptions with a
+// changed set of local validators. Subnets no longer needed are unsubscribed
+// but stay joined so attestations can still be published to them.
+func (h *Host) UpdateValidatorSubnets(validatorIDs []uint64) error {
+	want := initialAttestationSubnets(h.committeeCount, validatorIDs, h.isAggregator, h.aggregateSubnetIDs)
+
+	h.topicsMu.Lock()
+	defer h.topicsMu.Unlock()
+	if h.gossipStopped {
+		return nil
+	}
+	for subnetID := range want {
+		topic := AttestationSubnetTopic(subnetID)
+		if _, ok := h.subs[topic]; ok {
+			continue
+		}
+		if err := h.joinTopicLocked(topic); err != nil {
+			return fmt.Errorf("join attestation subnet %d: %w", subnetID, err)
+		}
+		if h.gossipHandler != nil {
+			go h.listenTopic(h.ctx, topic, h.subs[topic], h.gossipHandler)
+		}
+		logger.Info(logger.Network, "subscribed topic=%s", topic)
+	}
+	for topic, sub := range h.subs {
+		subnetID, ok := attestationSubnetOf(topic)
+		if !ok || want[subnetID] {
+			continue
+		}
+		sub.Cancel()
+		delete(h.subs, topic)
+		logger.Info(logger.Network, "unsubscribed topic=%s", topic)
+	}
+	return nil
+}
+
+// StopGossip unsubscribes from every topic so no further gossip reaches the
+// node. Topics stay joined, so publishing still works until Close.
+func (h *Host) StopGossip() {
+	h.topicsMu.Lock()
+	defer h.topicsMu.Unlock()
+	h.gossipStopped = true
+	h.gossipHandler = nil
+	for topic, sub := range h.subs {
+		sub.Cancel()
+		delete(h.subs, topic)
+	}
+	logger.Info(logger.Network, "stopped accepting gossip")
+}
@@ -0,0 +1,91 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+func newTestHost(t *testing.T, committeeCount uint64) *Host {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	gater := newBanGater()
+	libp2pHost, err := libp2p.New(libp2p.NoListenAddrs, libp2p.ConnectionGater(gater))
+	if err != nil {
+		cancel()
+		t.Fatalf("libp2p host: %v", err)
+	}
+	ps, err := newGossipSub(ctx, libp2pHost)
+	if err != nil {
+		libp2pHost.Close()
+		cancel()
+		t.Fatalf("gossipsub: %v", err)
+	}
+	h := &Host{
+		host:           libp2pHost,
+		pubsub:         ps,
+		topics:         make(map[string]*pubsub.Topic),
+		subs:           make(map[string]*pubsub.Subscription),
+		ctx:            ctx,
+		cancel:         cancel,
+		peerStore:      NewPeerStore(),
+		gater:          gater,
+		committeeCount: committeeCount,
+	}
+	t.Cleanup(h.Close)
+	return h
+}
+
+func TestUpdateValidatorSubnets(t *testing.T) {
+	h := newTestHost(t, 4)
+	if err := h.UpdateValidatorSubnets([]uint64{1, 5}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(h.subs) != 1 || h.subs[AttestationSubnetTopic(1)] == nil {
+		t.Fatalf("subs=%v, want only subnet 1", h.subs)
+	}
+
+	if err := h.UpdateValidatorSubnets([]uint64{2}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(h.subs) != 1 || h.subs[AttestationSubnetTopic(2)] == nil {
+		t.Fatalf("subs=%v, want only subnet 2", h.subs)
+	}
+	if _, ok := h.topics[AttestationSubnetTopic(1)]; !ok {
+		t.Fatal("left subnet should stay joined for publishing")
+	}
+
+	if err := h.UpdateValidatorSubnets([]uint64{1}); err != nil {
+		t.Fatalf("rejoin: %v", err)
+	}
+	if h.subs[AttestationSubnetTopic(1)] == nil {
+		t.Fatal("subnet 1 not resubscribed")
+	}
+}
+
+func TestStopGossipUnsubscribesAndStaysStopped(t *testing.T) {
+	h := newTestHost(t, 4)
+	if err := h.UpdateValidatorSubnets([]uint64{1}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	h.StopGossip()
+	if len(h.subs) != 0 {
+		t.Fatalf("subs=%v, want none after stop", h.subs)
+	}
+	if _, ok := h.topics[AttestationSubnetTopic(1)]; !ok {
+		t.Fatal("topics should stay joined for publishing")
+	}
+
+	if err := h.UpdateValidatorSubnets([]uint64{2}); err != nil {
+		t.Fatalf("update after stop: %v", err)
+	}
+	if err := h.ReannounceSubscriptions(); err != nil {
+		t.Fatalf("reannounce after stop: %v", err)
+	}
+	if len(h.subs) != 0 {
+		t.Fatalf("subs=%v, want none after stop", h.subs)
+	}
+}
@@ -31,14 +31,19 @@ func AttestationSubnetTopic(subnetID uint64) string {
 }
 
 func isAttestationSubnetTopic(topic string) bool {
+	_, ok := attestationSubnetOf(topic)
+	return ok
+}
+
+func attestationSubnetOf(topic string) (uint64, bool) {
 	prefix := fmt.Sprintf("/leanconsensus/%s/%s_", ForkDigest, AttestationTopicKind)
 	suffix := "/ssz_snappy"
 	if !strings.HasPrefix(topic, prefix) || !strings.HasSuffix(topic, suffix) {
-		return false
+		return 0, false
 	}
 	subnet := strings.TrimSuffix(strings.TrimPrefix(topic, prefix), suffix)
-	_, err := strconv.ParseUint(subnet, 10, 64)
-	return err == nil
+	subnetID, err := strconv.ParseUint(subnet, 10, 64)
+	return subnetID, err == nil
 }
 
 func AggregationTopic() string {
@@ -0,0 +1,27 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/geanlabs/gean/internal/tracing"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// startGossipSpan starts a span in the trace of the attestation data a gossip
+// message carries, under the span in ctx when there is one. Nodes derive the
+// same trace from the same data, so publish and receive spans line up across
+// the network without extra fields on the wire.
+func startGossipSpan(ctx context.Context, data *types.AttestationData, name, topic string, attrs ...tracing.Attr) *tracing.Active {
+	if data == nil {
+		return nil
+	}
+	trace, ok := tracing.TraceFor(data)
+	if !ok {
+		return nil
+	}
+	attrs = append([]tracing.Attr{
+		tracing.String("p2p.topic", topic),
+		tracing.Uint("attestation.slot", data.Slot),
+	}, attrs...)
+	return tracing.StartFromContext(ctx, trace, name, attrs...)
+}
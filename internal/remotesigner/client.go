@@ -0,0 +1,194 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+const maxResponseBytes = 1 << 20
+
+type ClientConfig struct {
+	URL      string
+	Timeout  time.Duration
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+type Client struct {
+	baseURL string
+	timeout time.Duration
+	http    *http.Client
+}
+
+func NewClient(cfg ClientConfig) (*Client, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid remote signer URL %q", cfg.URL)
+	}
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("remote signer timeout must be > 0")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	switch u.Scheme {
+	case "https":
+		tlsCfg, err := ClientTLSConfig(cfg.CAFile, cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsCfg
+	case "http":
+		if cfg.CAFile != "" || cfg.CertFile != "" || cfg.KeyFile != "" {
+			return nil, fmt.Errorf("remote signer TLS files given for plain http URL %q", cfg.URL)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported remote signer scheme %q", u.Scheme)
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(cfg.URL, "/"),
+		timeout: cfg.Timeout,
+		http:    &http.Client{Transport: transport},
+	}, nil
+}
+
+func (c *Client) Keys(ctx context.Context) ([]KeyInfo, error) {
+	body, err := c.do(ctx, http.MethodGet, KeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var keys []KeyInfo
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, fmt.Errorf("decode remote signer keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (c *Client) Sign(ctx context.Context, validatorIndex uint64, role xmss.SigningRole, slot uint64, root [32]byte) ([types.SignatureSize]byte, error) {
+	var sig [types.SignatureSize]byte
+	payload, err := json.Marshal(SignRequest{
+		ValidatorIndex: validatorIndex,
+		Role:           role,
+		Slot:           slot,
+		MessageRoot:    fmt.Sprintf("0x%x", root),
+	})
+	if err != nil {
+		return sig, err
+	}
+	body, err := c.do(ctx, http.MethodPost, SignPath, payload)
+	if err != nil {
+		return sig, err
+	}
+	var resp SignResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return sig, fmt.Errorf("decode remote signature: %w", err)
+	}
+	if err := decodeHex("signature", resp.Signature, sig[:]); err != nil {
+		return sig, err
+	}
+	return sig, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer %s: read response: %w", path, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusConflict:
+		return nil, fmt.Errorf("%w: remote signer refused: %s", xmss.ErrSigningHistoryConflict, bytes.TrimSpace(body))
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("remote signer %s: status %d: %s", path, resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+type remoteKey struct {
+	client         *Client
+	validatorIndex uint64
+	role           xmss.SigningRole
+	activeRange    *xmss.ActiveRange
+}
+
+func (k *remoteKey) Sign(slot uint32, message [32]byte) ([types.SignatureSize]byte, error) {
+	return k.client.Sign(context.Background(), k.validatorIndex, k.role, uint64(slot), message)
+}
+
+func (k *remoteKey) ActiveRange() (xmss.ActiveRange, error) {
+	if k.activeRange == nil {
+		return xmss.ActiveRange{}, errors.New("remote signer did not report the key's active range")
+	}
+	return *k.activeRange, nil
+}
+
+func (k *remoteKey) Close() {}
+
+// NewKeyManager builds a KeyManager whose keys live on the remote signer.
+// The KeyManager still applies its own signing history before each request,
+// so a compromised or confused signer is not the only line of defence.
+func NewKeyManager(ctx context.Context, cfg ClientConfig) (*xmss.KeyManager, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := client.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := make(map[uint64]xmss.Signer)
+	proposal := make(map[uint64]xmss.Signer)
+	for _, key := range keys {
+		for _, role := range key.Roles {
+			signer := &remoteKey{client: client, validatorIndex: key.ValidatorIndex, role: role}
+			if r, ok := key.ActiveRanges[role]; ok {
+				signer.activeRange = &r
+			}
+			switch role {
+			case xmss.RoleAttestation:
+				attestation[key.ValidatorIndex] = signer
+			case xmss.RoleProposal:
+				proposal[key.ValidatorIndex] = signer
+			default:
+				return nil, fmt.Errorf("remote signer reported unknown role %q for validator %d", role, key.ValidatorIndex)
+			}
+		}
+	}
+	if len(attestation) == 0 && len(proposal) == 0 {
+		return nil, errors.New("remote signer holds no keys")
+	}
+	return xmss.NewSignerKeyManager(attestation, proposal), nil
+}
@@ -0,0 +1,34 @@
+package remotesigner
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+// RegisterFlags registers the --remote-signer-* flags into cfg.
+func RegisterFlags(fs *flag.FlagSet, cfg *ClientConfig) {
+	fs.StringVar(&cfg.URL, "remote-signer-url", "", "Remote signer URL; when set, validator keys are not read from disk")
+	fs.StringVar(&cfg.CAFile, "remote-signer-ca", "", "PEM CA bundle used to verify the remote signer")
+	fs.StringVar(&cfg.CertFile, "remote-signer-cert", "", "Client certificate presented to the remote signer")
+	fs.StringVar(&cfg.KeyFile, "remote-signer-key", "", "Client certificate key for the remote signer")
+	fs.DurationVar(&cfg.Timeout, "remote-signer-timeout", 2*time.Second, "Per-request timeout against the remote signer")
+}
+
+// Validate reports flag combinations that cannot work, in terms of the
+// flags registered by RegisterFlags. A zero URL means no remote signer.
+func (cfg ClientConfig) Validate() error {
+	if cfg.URL == "" {
+		if cfg.CAFile != "" || cfg.CertFile != "" || cfg.KeyFile != "" {
+			return errors.New("--remote-signer-ca/-cert/-key require --remote-signer-url")
+		}
+		return nil
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return errors.New("--remote-signer-cert and --remote-signer-key must be set together")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("--remote-signer-timeout must be > 0")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package remotesigner
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/geanlabs/gean/xmss"
+)
+
+const (
+	KeysPath = "/xmss/v0/keys"
+	SignPath = "/xmss/v0/sign"
+)
+
+type KeyInfo struct {
+	ValidatorIndex uint64                                `json:"validator_index"`
+	Roles          []xmss.SigningRole                    `json:"roles"`
+	ActiveRanges   map[xmss.SigningRole]xmss.ActiveRange `json:"active_ranges,omitempty"`
+}
+
+type SignRequest struct {
+	ValidatorIndex uint64           `json:"validator_index"`
+	Role           xmss.SigningRole `json:"role"`
+	Slot           uint64           `json:"slot"`
+	MessageRoot    string           `json:"message_root"`
+}
+
+type SignResponse struct {
+	Signature string `json:"signature"`
+}
+
+func decodeHex(field, s string, out []byte) error {
+	if !strings.HasPrefix(s, "0x") {
+		return fmt.Errorf("%s: missing 0x prefix", field)
+	}
+	raw, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	if len(raw) != len(out) {
+		return fmt.Errorf("%s: got %d bytes, want %d", field, len(raw), len(out))
+	}
+	copy(out, raw)
+	return nil
+}
@@ -0,0 +1,190 @@
+package remotesigner
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+// fakeSigner stands in for an XMSS key: the signature is the slot and message
+// repeated, which is enough to check what reached the signer.
+type fakeSigner struct {
+	calls int
+}
+
+func (f *fakeSigner) Sign(slot uint32, message [32]byte) ([types.SignatureSize]byte, error) {
+	f.calls++
+	var sig [types.SignatureSize]byte
+	sig[0] = byte(slot)
+	copy(sig[1:], message[:])
+	return sig, nil
+}
+
+func (f *fakeSigner) ActiveRange() (xmss.ActiveRange, error) {
+	return xmss.ActiveRange{Start: 0, End: 100}, nil
+}
+
+func (f *fakeSigner) Close() {}
+
+func startSigner(t *testing.T, history *xmss.SigningHistory) (*httptest.Server, string, *fakeSigner) {
+	t.Helper()
+	signer := &fakeSigner{}
+	km := xmss.NewSignerKeyManager(
+		map[uint64]xmss.Signer{0: signer, 1: signer},
+		map[uint64]xmss.Signer{0: signer},
+	)
+	srv := httptest.NewTLSServer(NewServer(km, history).Handler())
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("write CA: %v", err)
+	}
+	return srv, caFile, signer
+}
+
+func TestRemoteKeyManagerSigns(t *testing.T) {
+	srv, caFile, signer := startSigner(t, xmss.NewSigningHistory())
+
+	km, err := NewKeyManager(context.Background(), ClientConfig{URL: srv.URL, Timeout: time.Second, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+	if ids := km.ValidatorIDs(); len(ids) != 2 || ids[0] != 0 || ids[1] != 1 {
+		t.Fatalf("validator IDs=%v, want [0 1]", ids)
+	}
+	if km.GetProposalKey(1) != nil {
+		t.Fatal("validator 1 should have no proposal key")
+	}
+	if err := km.CheckExpiry(100); !errors.Is(err, xmss.ErrKeyExpired) {
+		t.Fatalf("CheckExpiry err=%v, want remote active range to be reported", err)
+	}
+
+	root := [32]byte{0xab}
+	sig, err := km.SignBlock(0, 7, root)
+	if err != nil {
+		t.Fatalf("SignBlock: %v", err)
+	}
+	if sig[0] != 7 || sig[1] != 0xab {
+		t.Fatalf("unexpected signature prefix 0x%x", sig[:2])
+	}
+	if signer.calls != 1 {
+		t.Fatalf("signer calls=%d, want 1", signer.calls)
+	}
+}
+
+func TestRemoteSignerRequiresTrustedCA(t *testing.T) {
+	srv, _, _ := startSigner(t, xmss.NewSigningHistory())
+	if _, err := NewKeyManager(context.Background(), ClientConfig{URL: srv.URL, Timeout: time.Second}); err == nil {
+		t.Fatal("expected certificate verification failure without CA")
+	}
+}
+
+func TestRemoteSignerServerHistory(t *testing.T) {
+	srv, caFile, signer := startSigner(t, xmss.NewSigningHistory())
+	cfg := ClientConfig{URL: srv.URL, Timeout: time.Second, CAFile: caFile}
+
+	first, err := NewKeyManager(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+	if _, err := first.SignBlock(0, 9, [32]byte{0x01}); err != nil {
+		t.Fatalf("first SignBlock: %v", err)
+	}
+
+	// A second client has an empty local history, so only the server can
+	// stop it from signing a different root at the same slot.
+	second, err := NewKeyManager(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+	_, err = second.SignBlock(0, 9, [32]byte{0x02})
+	if !errors.Is(err, xmss.ErrSigningHistoryConflict) {
+		t.Fatalf("err=%v, want ErrSigningHistoryConflict", err)
+	}
+	if signer.calls != 1 {
+		t.Fatalf("signer calls=%d, want 1", signer.calls)
+	}
+}
+
+func TestRemoteSignerClientHistory(t *testing.T) {
+	srv, caFile, signer := startSigner(t, xmss.NewSigningHistory())
+	km, err := NewKeyManager(context.Background(), ClientConfig{URL: srv.URL, Timeout: time.Second, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewKeyManager: %v", err)
+	}
+	if _, err := km.SignBlock(0, 9, [32]byte{0x01}); err != nil {
+		t.Fatalf("SignBlock: %v", err)
+	}
+	if _, err := km.SignBlock(0, 8, [32]byte{0x01}); !errors.Is(err, xmss.ErrSigningHistoryConflict) {
+		t.Fatalf("err=%v, want ErrSigningHistoryConflict", err)
+	}
+	if signer.calls != 1 {
+		t.Fatalf("signer calls=%d, want 1 (client should refuse before the request)", signer.calls)
+	}
+}
+
+func TestRemoteSignerTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ClientConfig{URL: srv.URL, Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = client.Sign(context.Background(), 0, xmss.RoleAttestation, 1, [32]byte{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err=%v, want deadline exceeded", err)
+	}
+}
+
+func TestNewClientRejectsBadConfig(t *testing.T) {
+	cases := []ClientConfig{
+		{URL: "", Timeout: time.Second},
+		{URL: "ftp://signer:1", Timeout: time.Second},
+		{URL: "https://signer:1", Timeout: 0},
+		{URL: "http://signer:1", Timeout: time.Second, CAFile: "ca.pem"},
+	}
+	for _, cfg := range cases {
+		if _, err := NewClient(cfg); err == nil {
+			t.Fatalf("NewClient(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestRegisterFlagsAndValidate(t *testing.T) {
+	cases := map[string]struct {
+		args  []string
+		valid bool
+	}{
+		"no signer":        {nil, true},
+		"https with mtls":  {[]string{"--remote-signer-url", "https://s:9100", "--remote-signer-cert", "c.pem", "--remote-signer-key", "k.pem"}, true},
+		"tls without url":  {[]string{"--remote-signer-ca", "ca.pem"}, false},
+		"cert without key": {[]string{"--remote-signer-url", "https://s:9100", "--remote-signer-cert", "c.pem"}, false},
+		"zero timeout":     {[]string{"--remote-signer-url", "http://s:9100", "--remote-signer-timeout", "0s"}, false},
+	}
+	for name, tc := range cases {
+		var cfg ClientConfig
+		fs := flag.NewFlagSet(name, flag.ContinueOnError)
+		RegisterFlags(fs, &cfg)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("%s: parse: %v", name, err)
+		}
+		if err := cfg.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: Validate()=%v, want valid=%v", name, err, tc.valid)
+		}
+	}
+}
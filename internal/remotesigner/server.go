@@ -0,0 +1,128 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/xmss"
+)
+
+const maxRequestBytes = 1 << 16
+
+type Server struct {
+	keys    *xmss.KeyManager
+	history *xmss.SigningHistory
+}
+
+// NewServer serves the keys held by km. history is checked independently of
+// any history kept by clients and should be persisted across restarts.
+func NewServer(km *xmss.KeyManager, history *xmss.SigningHistory) *Server {
+	return &Server{keys: km, history: history}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+KeysPath, s.handleKeys)
+	mux.HandleFunc("POST "+SignPath, s.handleSign)
+	return mux
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, r *http.Request) {
+	seen := make(map[uint64]*KeyInfo)
+	var order []uint64
+	add := func(vid uint64, role xmss.SigningRole, signer xmss.Signer) {
+		info, ok := seen[vid]
+		if !ok {
+			info = &KeyInfo{ValidatorIndex: vid, ActiveRanges: make(map[xmss.SigningRole]xmss.ActiveRange)}
+			seen[vid] = info
+			order = append(order, vid)
+		}
+		info.Roles = append(info.Roles, role)
+		if r, err := signer.ActiveRange(); err == nil {
+			info.ActiveRanges[role] = r
+		}
+	}
+	for _, vid := range s.keys.ValidatorIDs() {
+		add(vid, xmss.RoleAttestation, s.keys.GetAttestationKey(vid))
+		if signer := s.keys.GetProposalKey(vid); signer != nil {
+			add(vid, xmss.RoleProposal, signer)
+		}
+	}
+
+	keys := make([]KeyInfo, 0, len(order))
+	for _, vid := range order {
+		keys = append(keys, *seen[vid])
+	}
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	var req SignRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "invalid json body", http.StatusBadRequest)
+		return
+	}
+	var root [32]byte
+	if err := decodeHex("message_root", req.MessageRoot, root[:]); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	slot := uint32(req.Slot)
+	if uint64(slot) != req.Slot {
+		http.Error(w, fmt.Sprintf("slot %d overflows uint32", req.Slot), http.StatusBadRequest)
+		return
+	}
+
+	var signer xmss.Signer
+	switch req.Role {
+	case xmss.RoleAttestation:
+		signer = s.keys.GetAttestationKey(req.ValidatorIndex)
+	case xmss.RoleProposal:
+		signer = s.keys.GetProposalKey(req.ValidatorIndex)
+	default:
+		http.Error(w, fmt.Sprintf("unknown role %q", req.Role), http.StatusBadRequest)
+		return
+	}
+	if signer == nil {
+		http.Error(w, fmt.Sprintf("no %s key for validator %d", req.Role, req.ValidatorIndex), http.StatusNotFound)
+		return
+	}
+
+	if err := s.history.CheckAndRecord(req.ValidatorIndex, req.Role, req.Slot, root); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, xmss.ErrSigningHistoryConflict) {
+			status = http.StatusConflict
+		}
+		logger.Warn(logger.Signature, "remote signer refused validator=%d role=%s slot=%d: %v", req.ValidatorIndex, req.Role, req.Slot, err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	sig, err := signer.Sign(slot, root)
+	if err != nil {
+		logger.Error(logger.Signature, "remote signer failed validator=%d role=%s slot=%d: %v", req.ValidatorIndex, req.Role, req.Slot, err)
+		http.Error(w, "signing failed", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, SignResponse{Signature: fmt.Sprintf("0x%x", sig)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("marshal response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
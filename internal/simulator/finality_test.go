@@ -0,0 +1,116 @@
+//go:build xmss_insecure
+
+package simulator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/geanlabs/gean/xmss"
+)
+
+func insecureKeys(id uint64) (*xmss.ValidatorKeyPair, *xmss.ValidatorKeyPair, error) {
+	att, err := xmss.GenerateKeyPair(fmt.Sprintf("sim-attestation-%d", id), 0, 1<<12)
+	if err != nil {
+		return nil, nil, err
+	}
+	prop, err := xmss.GenerateKeyPair(fmt.Sprintf("sim-proposal-%d", id), 0, 1<<12)
+	if err != nil {
+		att.Close()
+		return nil, nil, err
+	}
+	return att, prop, nil
+}
+
+func newFinalitySim(t *testing.T, cfg Config) *Simulator {
+	t.Helper()
+	cfg.KeyPair = insecureKeys
+	sim, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(sim.Close)
+	return sim
+}
+
+func finalizedSlots(sim *Simulator) []uint64 {
+	slots := make([]uint64, len(sim.Nodes))
+	for i, n := range sim.Nodes {
+		slots[i] = n.Store.LatestFinalized().Slot
+	}
+	return slots
+}
+
+func allFinalizedPast(sim *Simulator, slot uint64) bool {
+	for _, n := range sim.Nodes {
+		if n.Store.LatestFinalized().Slot <= slot {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSimulatorFinalizes(t *testing.T) {
+	sim := newFinalitySim(t, Config{Nodes: 3, Validators: 6, GenesisTime: 1000})
+
+	if !sim.RunUntil(40, func() bool { return allFinalizedPast(sim, 0) }) {
+		t.Fatalf("no finality after 40 slots: finalized=%v", finalizedSlots(sim))
+	}
+	head := sim.Nodes[0].Store.Head()
+	for _, n := range sim.Nodes[1:] {
+		if n.Store.Head() != head {
+			t.Fatalf("node %d head %x differs from node 0 head %x", n.Index, n.Store.Head(), head)
+		}
+	}
+}
+
+func TestSimulatorIsDeterministic(t *testing.T) {
+	run := func() ([32]byte, []uint64) {
+		sim := newFinalitySim(t, Config{Nodes: 3, Validators: 6, GenesisTime: 1000})
+		sim.SetLatency(0, 2, sim.intervalDuration()/2)
+		sim.RunSlots(12)
+		return sim.Nodes[2].Store.Head(), finalizedSlots(sim)
+	}
+	headA, finA := run()
+	headB, finB := run()
+	if headA != headB || fmt.Sprint(finA) != fmt.Sprint(finB) {
+		t.Fatalf("runs diverged: head %x vs %x, finalized %v vs %v", headA, headB, finA, finB)
+	}
+}
+
+func TestSimulatorMinorityPartitionCatchesUp(t *testing.T) {
+	sim := newFinalitySim(t, Config{Nodes: 4, Validators: 8, GenesisTime: 1000})
+
+	sim.Partition([]int{0, 1, 2}, []int{3})
+	sim.RunSlots(16)
+	isolated := sim.Nodes[3].Store.HeadSlot()
+
+	sim.Heal()
+	majority := sim.Nodes[0].Store
+	ok := sim.RunUntil(20, func() bool {
+		return sim.Nodes[3].Store.LatestFinalized().Slot == majority.LatestFinalized().Slot &&
+			sim.Nodes[3].Store.Head() == majority.Head()
+	})
+	if !ok {
+		t.Fatalf("isolated node did not catch up: head slot %d (was %d), majority head slot %d, finalized=%v",
+			sim.Nodes[3].Store.HeadSlot(), isolated, majority.HeadSlot(), finalizedSlots(sim))
+	}
+}
+
+func TestSimulatorStallsWithoutSupermajority(t *testing.T) {
+	// Half the validators never sign, so no checkpoint can gather 2/3.
+	sim := newFinalitySim(t, Config{
+		Nodes:             2,
+		Validators:        4,
+		GenesisTime:       1000,
+		OfflineValidators: []uint64{1, 3},
+	})
+
+	sim.RunSlots(20)
+	if fin := finalizedSlots(sim); fin[0] != 0 || fin[1] != 0 {
+		t.Fatalf("finalized=%v with half the validators offline, want 0", fin)
+	}
+	if sim.Nodes[0].Store.HeadSlot() == 0 {
+		t.Fatal("chain did not grow with half the validators online")
+	}
+}
@@ -0,0 +1,349 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/p2p"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+var errUnreachable = fmt.Errorf("peer unreachable")
+
+// Network is an in-memory transport between simulated nodes. Gossip is
+// SSZ-encoded on publish and decoded per receiver, and delivered after the
+// link latency unless the nodes are offline or partitioned from each other
+// at send or delivery time. Req/resp is answered immediately from the peer's
+// store.
+type Network struct {
+	mu        sync.Mutex
+	clock     clock.Clock
+	endpoints []*Endpoint
+	latency   time.Duration
+	links     map[[2]int]time.Duration
+	groups    []int
+	queue     []delivery
+	seq       uint64
+}
+
+type delivery struct {
+	at   time.Time
+	seq  uint64
+	from int
+	to   int
+	data []byte
+	kind messageKind
+}
+
+type messageKind int
+
+const (
+	kindBlock messageKind = iota
+	kindAttestation
+	kindAggregation
+)
+
+func newNetwork(clk clock.Clock, latency time.Duration) *Network {
+	return &Network{clock: clk, latency: latency, links: make(map[[2]int]time.Duration)}
+}
+
+// Endpoint is one node's view of the network. It implements node.Network
+// and syncer.SyncDriverP2P.
+type Endpoint struct {
+	net     *Network
+	index   int
+	id      libp2ppeer.ID
+	store   *store.ConsensusStore
+	handler p2p.MessageHandler
+	online  bool
+}
+
+func (n *Network) addEndpoint(s *store.ConsensusStore) *Endpoint {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ep := &Endpoint{
+		net:    n,
+		index:  len(n.endpoints),
+		id:     libp2ppeer.ID(fmt.Sprintf("sim-node-%d", len(n.endpoints))),
+		store:  s,
+		online: true,
+	}
+	n.endpoints = append(n.endpoints, ep)
+	n.groups = append(n.groups, 0)
+	return ep
+}
+
+func (ep *Endpoint) ID() libp2ppeer.ID { return ep.id }
+
+// SetLatency sets the delay from node from to node to.
+func (n *Network) SetLatency(from, to int, d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.links[[2]int{from, to}] = d
+}
+
+// Partition splits the nodes into groups that can only reach nodes in the
+// same group. Nodes not listed form one more group.
+func (n *Network) Partition(groups ...[]int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := range n.groups {
+		n.groups[i] = len(groups) + 1
+	}
+	for g, members := range groups {
+		for _, i := range members {
+			n.groups[i] = g + 1
+		}
+	}
+}
+
+// Heal removes every partition.
+func (n *Network) Heal() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := range n.groups {
+		n.groups[i] = 0
+	}
+}
+
+func (n *Network) setOnline(i int, online bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.endpoints[i].online = online
+}
+
+func (n *Network) reachableLocked(from, to int) bool {
+	return from != to && n.endpoints[from].online && n.endpoints[to].online && n.groups[from] == n.groups[to]
+}
+
+func (n *Network) linkLatencyLocked(from, to int) time.Duration {
+	if d, ok := n.links[[2]int{from, to}]; ok {
+		return d
+	}
+	return n.latency
+}
+
+func (n *Network) broadcast(from int, kind messageKind, data []byte) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.clock.Now()
+	for to := range n.endpoints {
+		if !n.reachableLocked(from, to) {
+			continue
+		}
+		n.seq++
+		n.queue = append(n.queue, delivery{
+			at:   now.Add(n.linkLatencyLocked(from, to)),
+			seq:  n.seq,
+			from: from,
+			to:   to,
+			data: data,
+			kind: kind,
+		})
+	}
+}
+
+// nextDue is the delivery time of the earliest queued message.
+func (n *Network) nextDue() (time.Time, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.queue) == 0 {
+		return time.Time{}, false
+	}
+	n.sortQueueLocked()
+	return n.queue[0].at, true
+}
+
+func (n *Network) sortQueueLocked() {
+	sort.Slice(n.queue, func(i, j int) bool {
+		if !n.queue[i].at.Equal(n.queue[j].at) {
+			return n.queue[i].at.Before(n.queue[j].at)
+		}
+		return n.queue[i].seq < n.queue[j].seq
+	})
+}
+
+// deliverNext hands the earliest message due by now to its receiver and
+// returns the receiver's index. Messages whose link went down while in flight
+// are dropped.
+func (n *Network) deliverNext() (int, bool) {
+	n.mu.Lock()
+	now := n.clock.Now()
+	n.sortQueueLocked()
+	for len(n.queue) > 0 && !n.queue[0].at.After(now) {
+		d := n.queue[0]
+		n.queue = n.queue[1:]
+		if !n.reachableLocked(d.from, d.to) {
+			continue
+		}
+		h := n.endpoints[d.to].handler
+		n.mu.Unlock()
+		if h != nil {
+			dispatch(h, d)
+		}
+		return d.to, true
+	}
+	n.mu.Unlock()
+	return 0, false
+}
+
+func dispatch(h p2p.MessageHandler, d delivery) {
+	switch d.kind {
+	case kindBlock:
+		block := &types.SignedBlock{}
+		if block.UnmarshalSSZ(d.data) != nil {
+			return
+		}
+		h.OnBlock(block)
+	case kindAttestation:
+		att := &types.SignedAttestation{}
+		if att.UnmarshalSSZ(d.data) != nil {
+			return
+		}
+		h.OnGossipAttestation(att)
+	case kindAggregation:
+		agg := &types.SignedAggregatedAttestation{}
+		if agg.UnmarshalSSZ(d.data) != nil {
+			return
+		}
+		h.OnGossipAggregatedAttestation(agg)
+	}
+}
+
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+}
+
+func (ep *Endpoint) publish(kind messageKind, msg sszMarshaler) error {
+	data, err := msg.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	ep.net.broadcast(ep.index, kind, data)
+	return nil
+}
+
+func (ep *Endpoint) PublishBlock(_ context.Context, block *types.SignedBlock) error {
+	if block == nil {
+		return fmt.Errorf("publish block: nil block")
+	}
+	return ep.publish(kindBlock, block)
+}
+
+func (ep *Endpoint) PublishAttestation(_ context.Context, att *types.SignedAttestation, _ uint64) error {
+	if att == nil {
+		return fmt.Errorf("publish attestation: nil attestation")
+	}
+	return ep.publish(kindAttestation, att)
+}
+
+func (ep *Endpoint) PublishAggregatedAttestation(_ context.Context, agg *types.SignedAggregatedAttestation) error {
+	if agg == nil {
+		return fmt.Errorf("publish aggregated attestation: nil aggregate")
+	}
+	return ep.publish(kindAggregation, agg)
+}
+
+func (ep *Endpoint) UpdateValidatorSubnets([]uint64) error { return nil }
+
+func (ep *Endpoint) reachablePeers() []*Endpoint {
+	n := ep.net
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var peers []*Endpoint
+	for i, other := range n.endpoints {
+		if n.reachableLocked(ep.index, i) {
+			peers = append(peers, other)
+		}
+	}
+	return peers
+}
+
+func (ep *Endpoint) peer(id libp2ppeer.ID) (*Endpoint, error) {
+	for _, other := range ep.reachablePeers() {
+		if other.id == id {
+			return other, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", id, errUnreachable)
+}
+
+func (ep *Endpoint) ConnectedPeers() int { return len(ep.reachablePeers()) }
+
+func (ep *Endpoint) MeshPeerCount() int { return len(ep.reachablePeers()) }
+
+func (ep *Endpoint) TopicMeshSizes() map[string]int { return nil }
+
+func (ep *Endpoint) Peers() []libp2ppeer.ID {
+	var ids []libp2ppeer.ID
+	for _, other := range ep.reachablePeers() {
+		ids = append(ids, other.id)
+	}
+	return ids
+}
+
+func (ep *Endpoint) SendStatusRequest(_ context.Context, peerID libp2ppeer.ID, _ *p2p.StatusMessage) (*p2p.StatusMessage, error) {
+	other, err := ep.peer(peerID)
+	if err != nil {
+		return nil, err
+	}
+	finalized := other.store.LatestFinalized()
+	return &p2p.StatusMessage{
+		FinalizedRoot: finalized.Root,
+		FinalizedSlot: finalized.Slot,
+		HeadRoot:      other.store.Head(),
+		HeadSlot:      other.store.HeadSlot(),
+	}, nil
+}
+
+func (ep *Endpoint) FetchBlocksByRange(_ context.Context, peerID libp2ppeer.ID, startSlot, count uint64) ([]*types.SignedBlock, error) {
+	other, err := ep.peer(peerID)
+	if err != nil {
+		return nil, err
+	}
+	return copyBlocks(other.store.GetCanonicalBlocksInRange(startSlot, count))
+}
+
+func (ep *Endpoint) FetchBlocksByRootBatchWithRetry(_ context.Context, roots [][32]byte) ([]*types.SignedBlock, [][32]byte, error) {
+	peers := ep.reachablePeers()
+	var found []*types.SignedBlock
+	var missing [][32]byte
+	for _, root := range roots {
+		var block *types.SignedBlock
+		for _, other := range peers {
+			if block = other.store.GetSignedBlock(root); block != nil {
+				break
+			}
+		}
+		if block == nil {
+			missing = append(missing, root)
+			continue
+		}
+		found = append(found, block)
+	}
+	blocks, err := copyBlocks(found)
+	return blocks, missing, err
+}
+
+func copyBlocks(blocks []*types.SignedBlock) ([]*types.SignedBlock, error) {
+	out := make([]*types.SignedBlock, 0, len(blocks))
+	for _, b := range blocks {
+		data, err := b.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		cp := &types.SignedBlock{}
+		if err := cp.UnmarshalSSZ(data); err != nil {
+			return nil, err
+		}
+		out = append(out, cp)
+	}
+	return out, nil
+}
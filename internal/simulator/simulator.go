@@ -0,0 +1,309 @@
+// Package simulator runs several gean engines in one process under virtual
+// time. Nodes share an in-memory network with scriptable latency, partitions
+// and downtime, and every step is driven from the calling goroutine, so a run
+// with the same configuration always produces the same chains.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/forkchoice"
+	"github.com/geanlabs/gean/internal/genesis"
+	"github.com/geanlabs/gean/internal/node"
+	"github.com/geanlabs/gean/internal/role"
+	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/syncer"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+// KeyPairFunc returns the attestation and proposal keys for a validator.
+type KeyPairFunc func(validatorID uint64) (attestation, proposal *xmss.ValidatorKeyPair, err error)
+
+type Config struct {
+	// Nodes is the number of engines. Validator i runs on node i % Nodes.
+	Nodes      int
+	Validators int
+	// Aggregators lists the nodes that aggregate. Defaults to node 0.
+	Aggregators []int
+	GenesisTime uint64
+
+	// KeyPair generates validator keys. When nil, validators have zero
+	// pubkeys and never sign, which is enough to exercise ticking, sync and
+	// the transport without XMSS.
+	KeyPair KeyPairFunc
+	// OfflineValidators are in the genesis registry but held by no node.
+	OfflineValidators []uint64
+
+	// Latency is the default one-way gossip delay between nodes.
+	Latency time.Duration
+
+	// Spec is the chain spec every node runs with. Nil means the devnet
+	// preset.
+	Spec *types.ChainSpec
+}
+
+type Node struct {
+	Index    int
+	Engine   *node.Engine
+	Store    *store.ConsensusStore
+	Sync     *syncer.SyncDriver
+	Endpoint *Endpoint
+	online   bool
+}
+
+type Simulator struct {
+	Clock *clock.Virtual
+	Net   *Network
+	Nodes []*Node
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	genesis time.Time
+	spec    types.ChainSpec
+	ticks   uint64
+	keys    []*xmss.KeyManager
+}
+
+func New(cfg Config) (*Simulator, error) {
+	if cfg.Nodes <= 0 {
+		return nil, fmt.Errorf("simulator: need at least one node")
+	}
+	if cfg.Validators <= 0 {
+		return nil, fmt.Errorf("simulator: need at least one validator")
+	}
+	spec := types.DevnetSpec()
+	if cfg.Spec != nil {
+		spec = *cfg.Spec
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("simulator: chain spec: %w", err)
+	}
+	aggregators := cfg.Aggregators
+	if aggregators == nil {
+		aggregators = []int{0}
+	}
+
+	attKeys := make([]map[uint64]*xmss.ValidatorKeyPair, cfg.Nodes)
+	propKeys := make([]map[uint64]*xmss.ValidatorKeyPair, cfg.Nodes)
+	for i := range cfg.Nodes {
+		attKeys[i] = make(map[uint64]*xmss.ValidatorKeyPair)
+		propKeys[i] = make(map[uint64]*xmss.ValidatorKeyPair)
+	}
+	// Offline validators' keys are held by no node; a spare key manager
+	// keeps them so Close releases them too.
+	offline := xmss.NewKeyManager(make(map[uint64]*xmss.ValidatorKeyPair), make(map[uint64]*xmss.ValidatorKeyPair))
+	validators := make([]*types.Validator, cfg.Validators)
+	for id := range uint64(cfg.Validators) {
+		v := &types.Validator{Index: id}
+		validators[id] = v
+		if cfg.KeyPair == nil {
+			continue
+		}
+		att, prop, err := cfg.KeyPair(id)
+		if err != nil {
+			offline.Close()
+			return nil, fmt.Errorf("simulator: keys for validator %d: %w", id, err)
+		}
+		if v.AttestationPubkey, err = att.PublicKeyBytes(); err != nil {
+			offline.Close()
+			return nil, fmt.Errorf("simulator: attestation pubkey %d: %w", id, err)
+		}
+		if v.ProposalPubkey, err = prop.PublicKeyBytes(); err != nil {
+			offline.Close()
+			return nil, fmt.Errorf("simulator: proposal pubkey %d: %w", id, err)
+		}
+		if slices.Contains(cfg.OfflineValidators, id) {
+			_ = offline.AddValidator(&xmss.ValidatorKeys{ValidatorIndex: id, Attestation: att, Proposal: prop})
+			continue
+		}
+		holder := int(id % uint64(cfg.Nodes))
+		attKeys[holder][id] = att
+		propKeys[holder][id] = prop
+	}
+
+	start := time.Unix(int64(cfg.GenesisTime), 0)
+	clk := clock.NewVirtual(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	sim := &Simulator{
+		Clock:   clk,
+		Net:     newNetwork(clk, cfg.Latency),
+		ctx:     ctx,
+		cancel:  cancel,
+		genesis: start,
+		spec:    spec,
+		keys:    []*xmss.KeyManager{offline},
+	}
+	for i := range cfg.Nodes {
+		// Each node mutates its own state, so each gets a fresh copy.
+		registry := make([]*types.Validator, len(validators))
+		for j, v := range validators {
+			cp := *v
+			registry[j] = &cp
+		}
+		state, err := genesis.StateFromValidators(cfg.GenesisTime, registry)
+		if err != nil {
+			sim.Close()
+			return nil, fmt.Errorf("simulator: genesis state: %w", err)
+		}
+		n, err := sim.addNode(state, xmss.NewKeyManager(attKeys[i], propKeys[i]), slices.Contains(aggregators, i))
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		sim.Nodes = append(sim.Nodes, n)
+	}
+	return sim, nil
+}
+
+func (sim *Simulator) addNode(state *types.State, keys *xmss.KeyManager, isAggregator bool) (*Node, error) {
+	s := store.NewConsensusStore(storage.NewInMemoryBackend())
+	s.UseSpec(sim.spec)
+	root, err := s.InitFromState(state)
+	if err != nil {
+		return nil, fmt.Errorf("simulator: %w", err)
+	}
+	fc := forkchoice.New(state.LatestBlockHeader.Slot, root, state.LatestBlockHeader.ParentRoot)
+
+	e := node.New(s, fc, nil, keys, role.New(isAggregator), 1)
+	e.Clock = sim.Clock
+	ep := sim.Net.addEndpoint(s)
+	ep.handler = e
+	e.P2P = ep
+	sim.keys = append(sim.keys, keys)
+
+	return &Node{
+		Index:    ep.index,
+		Engine:   e,
+		Store:    s,
+		Sync:     syncer.NewSyncDriverWithClock(sim.ctx, e, s, ep, sim.Clock),
+		Endpoint: ep,
+		online:   true,
+	}, nil
+}
+
+// Close stops the sync drivers and releases validator keys.
+func (sim *Simulator) Close() {
+	sim.cancel()
+	for _, km := range sim.keys {
+		km.Close()
+	}
+}
+
+// Partition splits the nodes into groups that only reach their own group.
+func (sim *Simulator) Partition(groups ...[]int) { sim.Net.Partition(groups...) }
+
+// Heal reconnects every node.
+func (sim *Simulator) Heal() { sim.Net.Heal() }
+
+// SetLatency sets the gossip delay from one node to another.
+func (sim *Simulator) SetLatency(from, to int, d time.Duration) { sim.Net.SetLatency(from, to, d) }
+
+// SetOnline takes a node down or brings it back. An offline node neither
+// ticks nor sends or receives messages.
+func (sim *Simulator) SetOnline(i int, online bool) {
+	sim.Nodes[i].online = online
+	sim.Net.setOnline(i, online)
+}
+
+func (sim *Simulator) intervalDuration() time.Duration {
+	return time.Duration(sim.spec.MillisecondsPerInterval()) * time.Millisecond
+}
+
+// Step moves virtual time to the next interval boundary, delivering gossip
+// that falls due on the way, then ticks every online node in index order and
+// runs all resulting work to completion.
+func (sim *Simulator) Step() {
+	boundary := sim.genesis.Add(time.Duration(sim.ticks) * sim.intervalDuration())
+	for {
+		due, ok := sim.Net.nextDue()
+		if !ok || !due.Before(boundary) {
+			break
+		}
+		sim.advanceTo(due)
+		sim.settle()
+	}
+	sim.advanceTo(boundary)
+
+	for _, n := range sim.Nodes {
+		if n.online {
+			n.Engine.Tick()
+		}
+	}
+	sim.settle()
+
+	if sim.ticks%sim.spec.IntervalsPerSlot == 0 {
+		sim.pollSyncingNodes()
+	}
+	sim.ticks++
+}
+
+// RunSlots steps through n whole slots.
+func (sim *Simulator) RunSlots(n uint64) {
+	for range n * sim.spec.IntervalsPerSlot {
+		sim.Step()
+	}
+}
+
+// RunUntil steps until cond holds or maxSlots slots have passed, and reports
+// whether cond held.
+func (sim *Simulator) RunUntil(maxSlots uint64, cond func() bool) bool {
+	for range maxSlots * sim.spec.IntervalsPerSlot {
+		if cond() {
+			return true
+		}
+		sim.Step()
+	}
+	return cond()
+}
+
+// CurrentSlot is the slot of the most recent tick.
+func (sim *Simulator) CurrentSlot() uint64 {
+	if sim.ticks == 0 {
+		return 0
+	}
+	return (sim.ticks - 1) / sim.spec.IntervalsPerSlot
+}
+
+func (sim *Simulator) advanceTo(t time.Time) {
+	if d := t.Sub(sim.Clock.Now()); d > 0 {
+		sim.Clock.Advance(d)
+	}
+}
+
+// settle runs queued engine work and delivers due gossip one message at a
+// time until the network is quiet.
+func (sim *Simulator) settle() {
+	for _, n := range sim.Nodes {
+		if n.online {
+			n.Engine.RunPending(sim.ctx)
+		}
+	}
+	for {
+		to, ok := sim.Net.deliverNext()
+		if !ok {
+			return
+		}
+		sim.Nodes[to].Engine.RunPending(sim.ctx)
+	}
+}
+
+// pollSyncingNodes stands in for the sync driver's poll loop: nodes that are
+// behind ask each reachable peer for its status and backfill from it.
+func (sim *Simulator) pollSyncingNodes() {
+	for _, n := range sim.Nodes {
+		if !n.online || n.Engine.GetSyncStatus() != syncer.SyncSyncing {
+			continue
+		}
+		for _, id := range n.Endpoint.Peers() {
+			n.Sync.OnPeerConnected(id)
+		}
+		n.Engine.RunPending(sim.ctx)
+	}
+	sim.settle()
+}
@@ -0,0 +1,214 @@
+package simulator
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/geanlabs/gean/internal/clock"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func TestMain(m *testing.M) {
+	logger.SetQuiet(true)
+	os.Exit(m.Run())
+}
+
+type recordingHandler struct {
+	clk    clock.Clock
+	blocks []time.Time
+	atts   int
+	aggs   int
+}
+
+func (h *recordingHandler) OnBlock(*types.SignedBlock) { h.blocks = append(h.blocks, h.clk.Now()) }
+
+func (h *recordingHandler) OnGossipAttestation(*types.SignedAttestation) { h.atts++ }
+
+func (h *recordingHandler) OnGossipAggregatedAttestation(*types.SignedAggregatedAttestation) {
+	h.aggs++
+}
+
+func newTestNetwork(t *testing.T, nodes int) (*Network, *clock.Virtual, []*recordingHandler) {
+	t.Helper()
+	clk := clock.NewVirtual(time.Unix(1000, 0))
+	net := newNetwork(clk, 10*time.Millisecond)
+	handlers := make([]*recordingHandler, nodes)
+	for i := range nodes {
+		handlers[i] = &recordingHandler{clk: clk}
+		net.addEndpoint(nil).handler = handlers[i]
+	}
+	return net, clk, handlers
+}
+
+func testBlock(slot uint64) *types.SignedBlock {
+	return &types.SignedBlock{
+		Block: &types.Block{Slot: slot, Body: &types.BlockBody{}},
+	}
+}
+
+func deliverAll(net *Network) int {
+	n := 0
+	for {
+		if _, ok := net.deliverNext(); !ok {
+			return n
+		}
+		n++
+	}
+}
+
+func TestNetworkDeliversAfterLinkLatency(t *testing.T) {
+	net, clk, handlers := newTestNetwork(t, 3)
+	net.SetLatency(0, 2, 50*time.Millisecond)
+	start := clk.Now()
+
+	if err := net.endpoints[0].PublishBlock(t.Context(), testBlock(1)); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if got := deliverAll(net); got != 0 {
+		t.Fatalf("delivered %d messages before any latency elapsed", got)
+	}
+
+	clk.Advance(10 * time.Millisecond)
+	deliverAll(net)
+	if len(handlers[1].blocks) != 1 || len(handlers[2].blocks) != 0 {
+		t.Fatalf("after 10ms: node1=%d node2=%d blocks", len(handlers[1].blocks), len(handlers[2].blocks))
+	}
+
+	clk.Advance(40 * time.Millisecond)
+	deliverAll(net)
+	if len(handlers[2].blocks) != 1 {
+		t.Fatalf("node2 got %d blocks after its link latency", len(handlers[2].blocks))
+	}
+	if got := handlers[2].blocks[0].Sub(start); got != 50*time.Millisecond {
+		t.Fatalf("node2 received block after %s, want 50ms", got)
+	}
+	if len(handlers[0].blocks) != 0 {
+		t.Fatal("sender received its own block")
+	}
+}
+
+func TestNetworkPartitionAndDowntimeDropMessages(t *testing.T) {
+	net, clk, handlers := newTestNetwork(t, 4)
+	net.Partition([]int{0, 1}, []int{2})
+
+	att := &types.SignedAttestation{Data: &types.AttestationData{
+		Head:   &types.Checkpoint{},
+		Target: &types.Checkpoint{},
+		Source: &types.Checkpoint{},
+	}}
+	if err := net.endpoints[0].PublishAttestation(t.Context(), att, 1); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	clk.Advance(time.Second)
+	deliverAll(net)
+	if handlers[1].atts != 1 || handlers[2].atts != 0 || handlers[3].atts != 0 {
+		t.Fatalf("partitioned delivery: got %d/%d/%d attestations", handlers[1].atts, handlers[2].atts, handlers[3].atts)
+	}
+	if got := net.endpoints[3].ConnectedPeers(); got != 0 {
+		t.Fatalf("node3 alone in its partition sees %d peers", got)
+	}
+
+	// A message in flight when its link goes down is dropped.
+	net.Heal()
+	if err := net.endpoints[0].PublishBlock(t.Context(), testBlock(1)); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	net.setOnline(1, false)
+	clk.Advance(time.Second)
+	deliverAll(net)
+	if len(handlers[1].blocks) != 0 {
+		t.Fatal("offline node received a block")
+	}
+	if len(handlers[2].blocks) != 1 || len(handlers[3].blocks) != 1 {
+		t.Fatalf("healed nodes got %d/%d blocks", len(handlers[2].blocks), len(handlers[3].blocks))
+	}
+	if got := len(net.endpoints[0].Peers()); got != 2 {
+		t.Fatalf("node0 sees %d peers with node1 offline, want 2", got)
+	}
+}
+
+func TestSimulatorTicksNodesInLockstep(t *testing.T) {
+	for _, preset := range types.PresetNames() {
+		t.Run(preset, func(t *testing.T) {
+			spec, err := types.PresetSpec(preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sim, err := New(Config{Nodes: 3, Validators: 6, GenesisTime: 1000, Spec: &spec})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			defer sim.Close()
+
+			sim.RunSlots(4)
+
+			if got := sim.CurrentSlot(); got != 3 {
+				t.Fatalf("current slot = %d, want 3", got)
+			}
+			wantNow := time.Unix(1000, 0).Add(time.Duration(4*spec.IntervalsPerSlot-1) * time.Duration(spec.MillisecondsPerInterval()) * time.Millisecond)
+			if !sim.Clock.Now().Equal(wantNow) {
+				t.Fatalf("clock = %s, want %s", sim.Clock.Now(), wantNow)
+			}
+			want := sim.Nodes[0].Store.Time()
+			if want != spec.IntervalsFromSlot(3)+spec.LastInterval() {
+				t.Fatalf("store time = %d, want the last interval of slot 3", want)
+			}
+			for _, n := range sim.Nodes[1:] {
+				if got := n.Store.Time(); got != want {
+					t.Fatalf("node %d store time = %d, node 0 = %d", n.Index, got, want)
+				}
+				if n.Store.Head() != sim.Nodes[0].Store.Head() {
+					t.Fatalf("node %d head differs from node 0", n.Index)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulatorOfflineNodeStopsTicking(t *testing.T) {
+	sim, err := New(Config{Nodes: 2, Validators: 2, GenesisTime: 1000})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer sim.Close()
+
+	sim.RunSlots(1)
+	sim.SetOnline(1, false)
+	stalled := sim.Nodes[1].Store.Time()
+	sim.RunSlots(2)
+	if got := sim.Nodes[1].Store.Time(); got != stalled {
+		t.Fatalf("offline node store time moved from %d to %d", stalled, got)
+	}
+
+	sim.SetOnline(1, true)
+	sim.Step()
+	if a, b := sim.Nodes[0].Store.Time(), sim.Nodes[1].Store.Time(); a != b {
+		t.Fatalf("after coming back: node0 time %d, node1 time %d", a, b)
+	}
+}
+
+func TestSimulatorRunUntil(t *testing.T) {
+	sim, err := New(Config{Nodes: 1, Validators: 1, GenesisTime: 1000})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer sim.Close()
+
+	if !sim.RunUntil(10, func() bool { return sim.CurrentSlot() == 5 }) {
+		t.Fatal("slot 5 not reached within 10 slots")
+	}
+	if sim.RunUntil(1, func() bool { return false }) {
+		t.Fatal("RunUntil reported success for a condition that never held")
+	}
+}
+
+func TestNewRejectsEmptyConfig(t *testing.T) {
+	if _, err := New(Config{Validators: 1}); err == nil {
+		t.Fatal("expected error for zero nodes")
+	}
+	if _, err := New(Config{Nodes: 1}); err == nil {
+		t.Fatal("expected error for zero validators")
+	}
+}
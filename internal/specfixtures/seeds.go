@@ -0,0 +1,85 @@
+package specfixtures
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixture roots relative to the repository root. Fuzz targets prefix them with
+// the path back to the root from their package directory.
+var (
+	SSZFixtureRoots = []string{
+		"leanSpec/fixtures/consensus/ssz/lstar/ssz/test_consensus_containers",
+		"leanSpec/fixtures/consensus/ssz/lstar/ssz/test_networking_containers",
+		"leanSpec/fixtures/consensus/ssz/lstar/ssz/test_xmss_containers",
+	}
+	NetworkingCodecFixtureRoot = "leanSpec/fixtures/consensus/networking_codec/lstar/networking"
+)
+
+type seedFixture struct {
+	TypeName   string         `json:"typeName"`
+	Serialized string         `json:"serialized"`
+	RawBytes   string         `json:"rawBytes"`
+	CodecName  string         `json:"codecName"`
+	Input      map[string]any `json:"input"`
+}
+
+// SSZSeeds returns the serialized bytes of every SSZ fixture of typeName under
+// the given roots, including the malformed rawBytes of rejection fixtures.
+// Missing fixture directories yield no seeds.
+func SSZSeeds(typeName string, roots ...string) [][]byte {
+	var seeds [][]byte
+	walkFixtures(roots, func(fx seedFixture) {
+		if fx.TypeName != typeName {
+			return
+		}
+		for _, s := range []string{fx.Serialized, fx.RawBytes} {
+			if s == "" {
+				continue
+			}
+			if b, err := ParseHexBytes(s); err == nil {
+				seeds = append(seeds, b)
+			}
+		}
+	})
+	return seeds
+}
+
+// ENRSeeds returns the ENR strings of the networking codec fixtures under
+// root, valid and invalid alike.
+func ENRSeeds(root string) []string {
+	var seeds []string
+	walkFixtures([]string{root}, func(fx seedFixture) {
+		if fx.CodecName != "enr" {
+			return
+		}
+		if s, ok := fx.Input["enrString"].(string); ok {
+			seeds = append(seeds, s)
+		}
+	})
+	return seeds
+}
+
+func walkFixtures(roots []string, fn func(seedFixture)) {
+	for _, root := range roots {
+		_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".json") {
+				return nil
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			var outer map[string]seedFixture
+			if err := json.Unmarshal(raw, &outer); err != nil {
+				return nil
+			}
+			for _, fx := range outer {
+				fn(fx)
+			}
+			return nil
+		})
+	}
+}
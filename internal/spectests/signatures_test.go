@@ -240,7 +240,7 @@ func runSignatureTest(t *testing.T, tt *sigTest) {
 	anchorState := tt.AnchorState.toState()
 
 	// 2. Fill state root in header if zero (genesis case), then compute anchor block root.
-	// Matches initStoreFromState in main.go.
+	// Matches ConsensusStore.InitFromState.
 	stateRoot, _ := anchorState.HashTreeRoot()
 	header := anchorState.LatestBlockHeader
 	if header.StateRoot == types.ZeroRoot {
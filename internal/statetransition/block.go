@@ -71,12 +71,13 @@ func ProcessBlockHeader(state *types.State, block *types.Block) error {
 	numEmptySlots := block.Slot - parentHeader.Slot - 1
 	newEntries := 1 + numEmptySlots
 	currentHistoricalRoots := uint64(len(state.HistoricalBlockHashes))
-	if currentHistoricalRoots > types.HistoricalRootsLimit ||
-		newEntries > types.HistoricalRootsLimit-currentHistoricalRoots {
+	historicalRootsLimit := types.Spec().HistoricalRootsLimit
+	if currentHistoricalRoots > historicalRootsLimit ||
+		newEntries > historicalRootsLimit-currentHistoricalRoots {
 		return &SlotGapTooLargeError{
 			Gap:     newEntries,
 			Current: state.Slot,
-			Max:     types.HistoricalRootsLimit,
+			Max:     historicalRootsLimit,
 		}
 	}
 
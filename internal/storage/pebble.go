@@ -46,6 +46,12 @@ func (p *PebbleBackend) EstimateTableBytes(table Table) uint64 {
 	return total
 }
 
+// Flush writes the memtables to disk. Commits do not sync, so shutdown
+// flushes before Close.
+func (p *PebbleBackend) Flush() error {
+	return p.db.Flush()
+}
+
 func (p *PebbleBackend) Close() error {
 	return p.db.Close()
 }
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// InitFromState makes state the store's anchor: it becomes head, safe
+// target, justified and finalized checkpoint. It returns the anchor block
+// root.
+func (s *ConsensusStore) InitFromState(state *types.State) ([32]byte, error) {
+	if s == nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: store is nil")
+	}
+	if state == nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: state is nil")
+	}
+	header := state.LatestBlockHeader
+	if header == nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: latest block header is nil")
+	}
+
+	stateRoot, err := state.HashTreeRoot()
+	if err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: state root: %w", err)
+	}
+
+	if header.StateRoot == types.ZeroRoot {
+		header.StateRoot = stateRoot
+	}
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: block root: %w", err)
+	}
+
+	anchor := &types.Checkpoint{Root: blockRoot, Slot: header.Slot}
+
+	if err := s.PutConfig(state.Config); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutHead(blockRoot); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutSafeTarget(blockRoot); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutLatestJustified(anchor); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutLatestFinalized(anchor); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutBlockHeader(blockRoot, header); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutState(blockRoot, state); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+	if err := s.PutLiveChainEntry(state.Slot, blockRoot, header.ParentRoot); err != nil {
+		return types.ZeroRoot, fmt.Errorf("initialize store: %w", err)
+	}
+
+	logger.Info(logger.Store, "store initialized from anchor: slot=%d head=%x parent_root=%x state_root=%x",
+		header.Slot, blockRoot, header.ParentRoot, stateRoot)
+	return blockRoot, nil
+}
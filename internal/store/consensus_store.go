@@ -2,6 +2,7 @@ package store
 
 import (
 	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/types"
 	"github.com/geanlabs/gean/xmss"
 )
 
@@ -16,6 +17,8 @@ type ConsensusStore struct {
 	KnownPayloads         *PayloadBuffer
 	AttestationSignatures AttestationSignatureMap
 	PubKeyCache           *xmss.PubKeyCache
+
+	spec *types.ChainSpec
 }
 
 func NewConsensusStore(backend storage.Backend) *ConsensusStore {
@@ -27,3 +30,16 @@ func NewConsensusStore(backend storage.Backend) *ConsensusStore {
 		PubKeyCache:           xmss.NewPubKeyCache(),
 	}
 }
+
+// UseSpec sets the chain spec this store's node runs with.
+func (s *ConsensusStore) UseSpec(spec types.ChainSpec) {
+	s.spec = &spec
+}
+
+// Spec is the chain spec set by UseSpec, or types.Spec() when none was set.
+func (s *ConsensusStore) Spec() types.ChainSpec {
+	if s == nil || s.spec == nil {
+		return types.Spec()
+	}
+	return *s.spec
+}
@@ -36,4 +36,6 @@ const (
 	ErrDuplicateAttestationData
 	ErrTooManyAttestationData
 	ErrJustifiedDivergenceNotClosed
+	ErrProposalSlotOutOfRange
+	ErrValidatorDutyDisabled
 )
@@ -2,7 +2,6 @@ package store
 
 import (
 	"github.com/geanlabs/gean/internal/logger"
-	"github.com/geanlabs/gean/internal/types"
 )
 
 func OnTick(
@@ -18,11 +17,12 @@ func OnTick(
 	if timestampMs > genesisTimeMs {
 		timeDeltaMs = timestampMs - genesisTimeMs
 	}
-	time := timeDeltaMs / types.MillisecondsPerInterval
+	spec := s.Spec()
+	time := timeDeltaMs / spec.MillisecondsPerInterval()
 
 	storeTime := s.Time()
-	if time > storeTime && time-storeTime > types.IntervalsPerSlot {
-		storeTime = time - types.IntervalsPerSlot
+	if time > storeTime && time-storeTime > spec.IntervalsPerSlot {
+		storeTime = time - spec.IntervalsPerSlot
 		if err := s.PutTime(storeTime); err != nil {
 			logger.Error(logger.Store, "tick: advance time failed: %v", err)
 			return
@@ -36,7 +36,7 @@ func OnTick(
 			return
 		}
 
-		interval := storeTime % types.IntervalsPerSlot
+		interval := storeTime % spec.IntervalsPerSlot
 
 		isFinalTick := storeTime == time
 		shouldSignalProposal := hasProposal && isFinalTick
@@ -46,7 +46,7 @@ func OnTick(
 			if shouldSignalProposal {
 				s.PromoteNewToKnown()
 			}
-		case 4:
+		case spec.LastInterval():
 			s.PromoteNewToKnown()
 		}
 	}
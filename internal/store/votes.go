@@ -0,0 +1,149 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+const (
+	voteKindNew   byte = 'n'
+	voteKindKnown byte = 'k'
+
+	voteKeySize = 1 + 32 + 4
+)
+
+func encodeVoteKey(kind byte, dataRoot [32]byte, index uint32) []byte {
+	key := make([]byte, voteKeySize)
+	key[0] = kind
+	copy(key[1:33], dataRoot[:])
+	binary.BigEndian.PutUint32(key[33:], index)
+	return key
+}
+
+// SaveVotes persists the new and known attestation payloads together with
+// the store time, so a restart resumes fork choice with the same votes.
+// Earlier snapshots are replaced. It returns the number of proofs written.
+func (s *ConsensusStore) SaveVotes() (int, error) {
+	staleKeys, err := s.voteKeys()
+	if err != nil {
+		return 0, err
+	}
+	var entries []storage.KV
+	for _, buf := range []struct {
+		kind    byte
+		payload *PayloadBuffer
+	}{{voteKindNew, s.NewPayloads}, {voteKindKnown, s.KnownPayloads}} {
+		for dataRoot, entry := range buf.payload.Entries() {
+			for i, proof := range entry.Proofs {
+				signed := &types.SignedAggregatedAttestation{Data: entry.Data, Proof: proof}
+				value, err := signed.MarshalSSZ()
+				if err != nil {
+					return 0, fmt.Errorf("save votes: marshal: %w", err)
+				}
+				entries = append(entries, storage.KV{Key: encodeVoteKey(buf.kind, dataRoot, uint32(i)), Value: value})
+			}
+		}
+	}
+
+	wb, err := s.beginWrite("save votes")
+	if err != nil {
+		return 0, err
+	}
+	if len(staleKeys) > 0 {
+		if err := wb.DeleteBatch(storage.TableForkChoiceVotes, staleKeys); err != nil {
+			return 0, fmt.Errorf("save votes: delete: %w", err)
+		}
+	}
+	if len(entries) > 0 {
+		if err := wb.PutBatch(storage.TableForkChoiceVotes, entries); err != nil {
+			return 0, fmt.Errorf("save votes: put: %w", err)
+		}
+	}
+	timeBuf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(timeBuf, s.Time())
+	if err := wb.PutBatch(storage.TableMetadata, []storage.KV{{Key: storage.KeyTime, Value: timeBuf}}); err != nil {
+		return 0, fmt.Errorf("save votes: put time: %w", err)
+	}
+	if err := wb.Commit(); err != nil {
+		return 0, fmt.Errorf("save votes: commit: %w", err)
+	}
+	return len(entries), nil
+}
+
+// RestoreVotes loads the payloads written by SaveVotes back into the
+// buffers, drops those below the finalized slot and clears the snapshot so
+// it is not replayed twice. It returns the number of proofs restored.
+func (s *ConsensusStore) RestoreVotes() (int, error) {
+	rv, err := s.beginRead("restore votes")
+	if err != nil {
+		return 0, err
+	}
+	iter, err := rv.PrefixIterator(storage.TableForkChoiceVotes, nil)
+	if err != nil {
+		return 0, fmt.Errorf("restore votes: iterate: %w", err)
+	}
+	var keys [][]byte
+	restored := 0
+	for iter.Next() {
+		key := append([]byte(nil), iter.Key()...)
+		keys = append(keys, key)
+		if len(key) != voteKeySize {
+			continue
+		}
+		signed := &types.SignedAggregatedAttestation{}
+		if err := signed.UnmarshalSSZ(iter.Value()); err != nil {
+			iter.Close()
+			return 0, fmt.Errorf("restore votes: unmarshal: %w", err)
+		}
+		var dataRoot [32]byte
+		copy(dataRoot[:], key[1:33])
+		switch key[0] {
+		case voteKindNew:
+			s.NewPayloads.Push(dataRoot, signed.Data, signed.Proof)
+		case voteKindKnown:
+			s.KnownPayloads.Push(dataRoot, signed.Data, signed.Proof)
+		default:
+			continue
+		}
+		restored++
+	}
+	iter.Close()
+
+	finalizedSlot := s.LatestFinalized().Slot
+	s.NewPayloads.PruneBelow(finalizedSlot)
+	s.KnownPayloads.PruneBelow(finalizedSlot)
+
+	if len(keys) > 0 {
+		wb, err := s.beginWrite("restore votes")
+		if err != nil {
+			return restored, err
+		}
+		if err := wb.DeleteBatch(storage.TableForkChoiceVotes, keys); err != nil {
+			return restored, fmt.Errorf("restore votes: delete: %w", err)
+		}
+		if err := wb.Commit(); err != nil {
+			return restored, fmt.Errorf("restore votes: commit: %w", err)
+		}
+	}
+	return restored, nil
+}
+
+func (s *ConsensusStore) voteKeys() ([][]byte, error) {
+	rv, err := s.beginRead("save votes")
+	if err != nil {
+		return nil, err
+	}
+	iter, err := rv.PrefixIterator(storage.TableForkChoiceVotes, nil)
+	if err != nil {
+		return nil, fmt.Errorf("save votes: iterate: %w", err)
+	}
+	defer iter.Close()
+	var keys [][]byte
+	for iter.Next() {
+		keys = append(keys, append([]byte(nil), iter.Key()...))
+	}
+	return keys, nil
+}
@@ -0,0 +1,77 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/geanlabs/gean/internal/storage"
+	"github.com/geanlabs/gean/internal/store"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func makeVote(slot uint64, participant uint64) (*types.AttestationData, *types.AggregatedSignatureProof) {
+	data := &types.AttestationData{
+		Slot:   slot,
+		Head:   &types.Checkpoint{Slot: slot},
+		Target: &types.Checkpoint{Slot: slot},
+		Source: &types.Checkpoint{},
+	}
+	participants := types.NewBitlistSSZ(participant + 1)
+	types.BitlistSet(participants, participant)
+	return data, &types.AggregatedSignatureProof{Participants: participants, ProofData: []byte{0x01}}
+}
+
+func TestSaveAndRestoreVotes(t *testing.T) {
+	backend := storage.NewInMemoryBackend()
+	s := store.NewConsensusStore(backend)
+	s.SetLatestFinalized(makeCheckpoint(0x01, 2))
+	s.SetTime(77)
+
+	newData, newProof := makeVote(5, 1)
+	s.NewPayloads.Push([32]byte{0xaa}, newData, newProof)
+	knownData, knownProof := makeVote(4, 0)
+	s.KnownPayloads.Push([32]byte{0xbb}, knownData, knownProof)
+	_, otherProof := makeVote(4, 2)
+	s.KnownPayloads.Push([32]byte{0xbb}, knownData, otherProof)
+	staleData, staleProof := makeVote(1, 3)
+	s.KnownPayloads.Push([32]byte{0xcc}, staleData, staleProof)
+
+	if n, err := s.SaveVotes(); err != nil || n != 4 {
+		t.Fatalf("SaveVotes = %d, %v; want 4, nil", n, err)
+	}
+
+	restarted := store.NewConsensusStore(backend)
+	if got := restarted.Time(); got != 77 {
+		t.Fatalf("time after restart=%d, want 77", got)
+	}
+	if n, err := restarted.RestoreVotes(); err != nil || n != 4 {
+		t.Fatalf("RestoreVotes = %d, %v; want 4, nil", n, err)
+	}
+	if restarted.NewPayloads.TotalProofs() != 1 {
+		t.Fatalf("new proofs=%d, want 1", restarted.NewPayloads.TotalProofs())
+	}
+	known := restarted.KnownPayloads.Entries()
+	if len(known) != 1 || len(known[[32]byte{0xbb}].Proofs) != 2 {
+		t.Fatalf("known entries=%v, want two proofs for 0xbb and the finalized vote pruned", known)
+	}
+	if got := backend.CountEntries(storage.TableForkChoiceVotes); got != 0 {
+		t.Fatalf("%d votes left after restore, want 0", got)
+	}
+}
+
+func TestSaveVotesReplacesEarlierSnapshot(t *testing.T) {
+	backend := storage.NewInMemoryBackend()
+	s := store.NewConsensusStore(backend)
+	data, proof := makeVote(5, 1)
+	s.NewPayloads.Push([32]byte{0xaa}, data, proof)
+	if _, err := s.SaveVotes(); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+
+	s.NewPayloads.Drain()
+	if n, err := s.SaveVotes(); err != nil || n != 0 {
+		t.Fatalf("second save = %d, %v; want 0, nil", n, err)
+	}
+	if got := backend.CountEntries(storage.TableForkChoiceVotes); got != 0 {
+		t.Fatalf("%d votes stored, want 0", got)
+	}
+}
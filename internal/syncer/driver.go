@@ -3,10 +3,10 @@ package syncer
 import (
 	"context"
 	"sync"
-	"time"
 
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 
+	"github.com/geanlabs/gean/internal/clock"
 	"github.com/geanlabs/gean/internal/logger"
 	"github.com/geanlabs/gean/internal/store"
 )
@@ -16,17 +16,23 @@ type SyncDriver struct {
 	store *store.ConsensusStore
 	p2p   SyncDriverP2P
 	ctx   context.Context
+	clock clock.Clock
 
 	mu       sync.Mutex
 	inFlight map[libp2ppeer.ID]bool
 }
 
 func NewSyncDriver(ctx context.Context, node LocalNode, store *store.ConsensusStore, p2pHost SyncDriverP2P) *SyncDriver {
+	return NewSyncDriverWithClock(ctx, node, store, p2pHost, clock.System)
+}
+
+func NewSyncDriverWithClock(ctx context.Context, node LocalNode, store *store.ConsensusStore, p2pHost SyncDriverP2P, clk clock.Clock) *SyncDriver {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	return &SyncDriver{
 		ctx:      ctx,
+		clock:    clock.Or(clk),
 		node:     node,
 		store:    store,
 		p2p:      p2pHost,
@@ -39,7 +45,7 @@ func (sd *SyncDriver) Run() {
 		return
 	}
 
-	ticker := time.NewTicker(pollInterval)
+	ticker := clock.Or(sd.clock).NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	logger.Info(logger.Sync, "sync driver started: poll_interval=%s threshold=%d slots",
@@ -49,7 +55,7 @@ func (sd *SyncDriver) Run() {
 		select {
 		case <-sd.ctx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			if sd.node.GetSyncStatus() == SyncSyncing {
 				sd.refreshSyncFromPeers(sd.ctx)
 			}
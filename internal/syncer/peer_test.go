@@ -3,10 +3,13 @@ package syncer
 import (
 	"context"
 	"testing"
+	"time"
 
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 
+	"github.com/geanlabs/gean/internal/clock"
 	"github.com/geanlabs/gean/internal/p2p"
+	"github.com/geanlabs/gean/internal/types"
 )
 
 func TestSyncDriver_PollPeerIgnoresNilStatus(t *testing.T) {
@@ -51,3 +54,31 @@ func TestSyncDriverNilDependenciesReturnDefaults(t *testing.T) {
 		t.Fatal("nil driver should not backfill")
 	}
 }
+
+func TestSyncDriver_RunPollsOnClockTicks(t *testing.T) {
+	n, store := makeTestSyncHarness()
+	mock := &mockSyncP2P{
+		peers:        []libp2ppeer.ID{"p1"},
+		statusResp:   &p2p.StatusMessage{HeadSlot: 100},
+		rangeBatches: [][]*types.SignedBlock{makeSyncRange(store.Head(), 1)},
+	}
+	clk := clock.NewVirtual(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sd := NewSyncDriverWithClock(ctx, n, store, mock, clk)
+	go sd.Run()
+
+	time.Sleep(20 * time.Millisecond)
+	if got := mock.rangeCalls.Load(); got != 0 {
+		t.Fatalf("range calls before the first tick = %d, want 0", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for mock.rangeCalls.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("sync driver did not poll after the virtual clock passed the poll interval")
+		}
+		clk.Advance(pollInterval)
+		time.Sleep(5 * time.Millisecond)
+	}
+}
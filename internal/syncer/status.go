@@ -22,6 +22,20 @@ func (s SyncStatus) String() string {
 	return "unknown"
 }
 
+// State is a point-in-time view of how far the node's head trails the wall
+// clock, for readiness checks.
+type State struct {
+	Status       SyncStatus
+	HeadSlot     uint64
+	WallSlot     uint64
+	Distance     uint64
+	Peers        int
+	HasHeadState bool
+	// Stalled is set when no stored block is close to the wall clock, i.e.
+	// the network is not producing blocks rather than this node lagging.
+	Stalled bool
+}
+
 func (sd *SyncDriver) makeStatusMessage() *p2p.StatusMessage {
 	if sd == nil || sd.store == nil {
 		return nil
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/geanlabs/gean/internal/logger"
+)
+
+const (
+	exportQueueSize     = 2048
+	exportBatchSize     = 256
+	exportFlushInterval = 2 * time.Second
+	exportTimeout       = 5 * time.Second
+)
+
+// Exporter batches spans and posts them to an OTLP/HTTP collector. Export
+// never blocks; spans are dropped when the queue is full.
+type Exporter struct {
+	url      string
+	resource []Attr
+	client   *http.Client
+	queue    chan Span
+	dropped  atomic.Uint64
+	done     chan struct{}
+}
+
+// NewExporter targets an OTLP/HTTP endpoint such as http://127.0.0.1:4318;
+// spans are posted to its /v1/traces path. resource describes this process,
+// e.g. service.name.
+func NewExporter(endpoint string, resource ...Attr) *Exporter {
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return &Exporter{
+		url:      url,
+		resource: resource,
+		client:   &http.Client{Timeout: exportTimeout},
+		queue:    make(chan Span, exportQueueSize),
+		done:     make(chan struct{}),
+	}
+}
+
+// Export is a no-op on a nil exporter so callers need not check whether
+// tracing is enabled.
+func (e *Exporter) Export(spans ...Span) {
+	if e == nil {
+		return
+	}
+	for _, s := range spans {
+		select {
+		case e.queue <- s:
+		default:
+			e.dropped.Add(1)
+		}
+	}
+}
+
+// Run sends batches until ctx is cancelled, then flushes what is queued.
+func (e *Exporter) Run(ctx context.Context) {
+	defer close(e.done)
+	ticker := time.NewTicker(exportFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Span, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			logger.Warn(logger.Node, "otlp export of %d spans failed: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case s := <-e.queue:
+					batch = append(batch, s)
+					if len(batch) == exportBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) == exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			if n := e.dropped.Swap(0); n > 0 {
+				logger.Warn(logger.Node, "otlp export queue full, dropped %d spans", n)
+			}
+			flush()
+		}
+	}
+}
+
+// Wait blocks until Run has flushed and returned.
+func (e *Exporter) Wait() {
+	if e != nil {
+		<-e.done
+	}
+}
+
+func (e *Exporter) send(spans []Span) error {
+	body, err := encodeOTLP(e.resource, spans)
+	if err != nil {
+		return fmt.Errorf("encode spans: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
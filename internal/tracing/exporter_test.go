@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExporterPostsOTLPJSON(t *testing.T) {
+	bodies := make(chan []byte, 4)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer collector.Close()
+
+	exp := NewExporter(collector.URL, String("service.name", "gean"))
+	ctx, cancel := context.WithCancel(context.Background())
+	go exp.Run(ctx)
+
+	start := time.Unix(100, 0)
+	parent := Span{Trace: TraceID{0xab}, ID: SpanID{1}, Name: "block", Start: start, End: start.Add(time.Second),
+		Attrs: []Attr{Uint("block.slot", 7), Bool("block.local", true)}}
+	child := Span{Trace: parent.Trace, ID: SpanID{2}, Parent: parent.ID, Name: "block.persist", Start: start, End: start, Error: "disk full"}
+	exp.Export(parent, child)
+	cancel()
+	exp.Wait()
+
+	var req otlpRequest
+	select {
+	case body := <-bodies:
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	default:
+		t.Fatal("collector received nothing")
+	}
+
+	rs := req.ResourceSpans[0]
+	if got := *rs.Resource.Attributes[0].Value.StringValue; got != "gean" {
+		t.Fatalf("service.name=%q", got)
+	}
+	spans := rs.ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("spans=%d, want 2", len(spans))
+	}
+	if spans[0].TraceID != "ab000000000000000000000000000000" || spans[0].ParentSpanID != "" || spans[0].StartTimeUnixNano != "100000000000" {
+		t.Fatalf("root span=%+v", spans[0])
+	}
+	if *spans[0].Attributes[0].Value.IntValue != "7" || !*spans[0].Attributes[1].Value.BoolValue {
+		t.Fatalf("attributes=%+v", spans[0].Attributes)
+	}
+	if spans[1].ParentSpanID != "0100000000000000" || spans[1].Status == nil || spans[1].Status.Message != "disk full" {
+		t.Fatalf("child span=%+v", spans[1])
+	}
+}
+
+func TestNilExporterIsNoop(t *testing.T) {
+	var exp *Exporter
+	exp.Export(Span{Name: "ignored"})
+	exp.Wait()
+}
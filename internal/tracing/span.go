@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"time"
+)
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+type Attr struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attr      { return Attr{Key: key, Value: value} }
+func Int(key string, value int64) Attr   { return Attr{Key: key, Value: value} }
+func Uint(key string, value uint64) Attr { return Attr{Key: key, Value: int64(value)} }
+func Bool(key string, value bool) Attr   { return Attr{Key: key, Value: value} }
+
+// Span is a finished unit of work. A zero Parent marks a root span; a
+// non-empty Error sets an error status on export.
+type Span struct {
+	Trace  TraceID
+	ID     SpanID
+	Parent SpanID
+	Name   string
+	Start  time.Time
+	End    time.Time
+	Attrs  []Attr
+	Error  string
+}
+
+func NewTraceID() TraceID {
+	var id TraceID
+	rand.Read(id[:])
+	return id
+}
+
+func NewSpanID() SpanID {
+	var id SpanID
+	rand.Read(id[:])
+	return id
+}
+
+// TraceIDFromRoot derives a trace ID from a block or attestation data root
+// so spans about the same object land in one trace on every node.
+func TraceIDFromRoot(root [32]byte) TraceID {
+	var id TraceID
+	copy(id[:], root[:16])
+	return id
+}
+
+// Rooted is anything with an SSZ hash tree root, such as attestation data.
+type Rooted interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// TraceFor derives obj's trace ID from its root. It skips the hashing and
+// reports false when tracing is disabled.
+func TraceFor(obj Rooted) (TraceID, bool) {
+	if !Enabled() {
+		return TraceID{}, false
+	}
+	root, err := obj.HashTreeRoot()
+	if err != nil {
+		return TraceID{}, false
+	}
+	return TraceIDFromRoot(root), true
+}
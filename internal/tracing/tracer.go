@@ -0,0 +1,170 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type tracer struct {
+	exporter *Exporter
+	// bound keeps traces whose first eight ID bytes are below it, so every
+	// node samples the same deterministic traces.
+	bound  uint64
+	always bool
+}
+
+var current atomic.Pointer[tracer]
+
+// Enable routes spans to exp, keeping the given fraction of traces. A nil
+// exporter or a ratio <= 0 disables tracing.
+func Enable(exp *Exporter, sampleRatio float64) {
+	if exp == nil || sampleRatio <= 0 {
+		current.Store(nil)
+		return
+	}
+	t := &tracer{exporter: exp}
+	if bound := sampleRatio * math.MaxUint64; bound >= math.MaxUint64 {
+		t.always = true
+	} else {
+		t.bound = uint64(bound)
+	}
+	current.Store(t)
+}
+
+func Disable() { current.Store(nil) }
+
+// Enabled reports whether any spans are exported, letting callers skip
+// computing trace IDs when tracing is off.
+func Enabled() bool { return current.Load() != nil }
+
+func Sampled(trace TraceID) bool {
+	t := current.Load()
+	return t != nil && t.sampled(trace)
+}
+
+func (t *tracer) sampled(trace TraceID) bool {
+	return t.always || binary.BigEndian.Uint64(trace[:8]) < t.bound
+}
+
+// Emit exports finished spans whose trace is sampled.
+func Emit(spans ...Span) {
+	t := current.Load()
+	if t == nil {
+		return
+	}
+	for _, s := range spans {
+		if t.sampled(s.Trace) {
+			t.exporter.Export(s)
+		}
+	}
+}
+
+// Active is an in-flight span. A nil *Active is valid and records nothing,
+// which is what Start returns when tracing is off or the trace is not
+// sampled.
+type Active struct {
+	tracer *tracer
+	mu     sync.Mutex
+	span   Span
+	ended  bool
+}
+
+func Start(trace TraceID, name string, attrs ...Attr) *Active {
+	t := current.Load()
+	if t == nil || !t.sampled(trace) {
+		return nil
+	}
+	return &Active{tracer: t, span: Span{
+		Trace: trace,
+		ID:    NewSpanID(),
+		Name:  name,
+		Start: time.Now(),
+		Attrs: attrs,
+	}}
+}
+
+// Child starts a span under a. On a nil parent it returns nil.
+func (a *Active) Child(name string, attrs ...Attr) *Active {
+	if a == nil {
+		return nil
+	}
+	return &Active{tracer: a.tracer, span: Span{
+		Trace:  a.span.Trace,
+		ID:     NewSpanID(),
+		Parent: a.span.ID,
+		Name:   name,
+		Start:  time.Now(),
+		Attrs:  attrs,
+	}}
+}
+
+func (a *Active) SetAttrs(attrs ...Attr) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.span.Attrs = append(a.span.Attrs, attrs...)
+}
+
+// End finishes and exports the span, marking it failed when err is non-nil.
+// Only the first call has an effect.
+func (a *Active) End(err error) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	if a.ended {
+		a.mu.Unlock()
+		return
+	}
+	a.ended = true
+	a.span.End = time.Now()
+	if err != nil {
+		a.span.Error = err.Error()
+	}
+	span := a.span
+	a.mu.Unlock()
+	a.tracer.exporter.Export(span)
+}
+
+// LogFields returns " trace_id=... span_id=..." for appending to a log line,
+// or "" for a nil span.
+func (a *Active) LogFields() string {
+	if a == nil {
+		return ""
+	}
+	return " trace_id=" + hex.EncodeToString(a.span.Trace[:]) + " span_id=" + hex.EncodeToString(a.span.ID[:])
+}
+
+type activeKey struct{}
+
+// ContextWith carries a to callees such as the p2p publisher.
+func ContextWith(ctx context.Context, a *Active) context.Context {
+	if a == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, activeKey{}, a)
+}
+
+func FromContext(ctx context.Context) *Active {
+	if ctx == nil {
+		return nil
+	}
+	a, _ := ctx.Value(activeKey{}).(*Active)
+	return a
+}
+
+// StartFromContext starts a child of the span carried by ctx, or a new root
+// span in trace when ctx carries none.
+func StartFromContext(ctx context.Context, trace TraceID, name string, attrs ...Attr) *Active {
+	if parent := FromContext(ctx); parent != nil {
+		return parent.Child(name, attrs...)
+	}
+	return Start(trace, name, attrs...)
+}
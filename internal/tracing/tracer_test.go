@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func enableForTest(t *testing.T, ratio float64) *Exporter {
+	t.Helper()
+	exp := NewExporter("http://127.0.0.1:0")
+	Enable(exp, ratio)
+	t.Cleanup(Disable)
+	return exp
+}
+
+func drain(exp *Exporter) []Span {
+	var out []Span
+	for {
+		select {
+		case s := <-exp.queue:
+			out = append(out, s)
+		default:
+			return out
+		}
+	}
+}
+
+func TestActiveSpansFollowContext(t *testing.T) {
+	exp := enableForTest(t, 1)
+	trace := TraceIDFromRoot([32]byte{0x42})
+
+	root := Start(trace, "attestation.produce", Uint("validator.index", 3))
+	ctx := ContextWith(context.Background(), root)
+	child := StartFromContext(ctx, TraceID{0x99}, "p2p.publish")
+	child.End(errors.New("not subscribed"))
+	root.SetAttrs(Bool("published", false))
+	root.End(nil)
+	root.End(nil)
+
+	spans := drain(exp)
+	if len(spans) != 2 {
+		t.Fatalf("spans=%d, want 2", len(spans))
+	}
+	pub, prod := spans[0], spans[1]
+	if pub.Trace != trace || pub.Parent != prod.ID || pub.Error != "not subscribed" {
+		t.Fatalf("publish span=%+v", pub)
+	}
+	if prod.Parent != (SpanID{}) || len(prod.Attrs) != 2 || prod.End.Before(prod.Start) {
+		t.Fatalf("produce span=%+v", prod)
+	}
+	if fields := root.LogFields(); !strings.HasPrefix(fields, " trace_id=42000000") {
+		t.Fatalf("log fields=%q", fields)
+	}
+}
+
+func TestSamplingIsDeterministicByTraceID(t *testing.T) {
+	exp := enableForTest(t, 0.5)
+	low, high := TraceID{0x10}, TraceID{0xf0}
+
+	if !Sampled(low) || Sampled(high) {
+		t.Fatalf("sampled low=%v high=%v", Sampled(low), Sampled(high))
+	}
+	if Start(high, "dropped") != nil {
+		t.Fatal("unsampled trace should not start a span")
+	}
+	Emit(Span{Trace: low, Name: "kept"}, Span{Trace: high, Name: "dropped"})
+	spans := drain(exp)
+	if len(spans) != 1 || spans[0].Name != "kept" {
+		t.Fatalf("spans=%+v", spans)
+	}
+}
+
+type rootFunc func() ([32]byte, error)
+
+func (f rootFunc) HashTreeRoot() ([32]byte, error) { return f() }
+
+func TestDisabledTracingIsNoop(t *testing.T) {
+	Disable()
+	hashed := false
+	obj := rootFunc(func() ([32]byte, error) { hashed = true; return [32]byte{1}, nil })
+
+	if _, ok := TraceFor(obj); ok || hashed {
+		t.Fatalf("TraceFor ok=%v hashed=%v with tracing off", ok, hashed)
+	}
+	span := Start(TraceID{1}, "off")
+	if span != nil {
+		t.Fatal("expected nil span with tracing off")
+	}
+	span.Child("child").End(nil)
+	span.SetAttrs(String("k", "v"))
+	if span.LogFields() != "" || FromContext(ContextWith(context.Background(), span)) != nil {
+		t.Fatal("nil span should leave logs and context untouched")
+	}
+}
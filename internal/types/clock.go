@@ -1,6 +1,26 @@
 package types
 
 func CurrentSlot(genesisTime, currentTimeMs uint64) uint64 {
+	return Spec().CurrentSlot(genesisTime, currentTimeMs)
+}
+
+func CurrentInterval(genesisTime, currentTimeMs uint64) uint64 {
+	return Spec().CurrentInterval(genesisTime, currentTimeMs)
+}
+
+func TotalIntervals(genesisTime, currentTimeMs uint64) uint64 {
+	return Spec().TotalIntervals(genesisTime, currentTimeMs)
+}
+
+func IntervalsFromSlot(slot uint64) uint64 {
+	return Spec().IntervalsFromSlot(slot)
+}
+
+func IntervalsFromUnixTime(unixSeconds, genesisTime uint64) uint64 {
+	return Spec().IntervalsFromUnixTime(unixSeconds, genesisTime)
+}
+
+func (s ChainSpec) CurrentSlot(genesisTime, currentTimeMs uint64) uint64 {
 	genesisMs, ok := unixMillis(genesisTime)
 	if !ok {
 		return 0
@@ -8,10 +28,10 @@ func CurrentSlot(genesisTime, currentTimeMs uint64) uint64 {
 	if currentTimeMs < genesisMs {
 		return 0
 	}
-	return (currentTimeMs - genesisMs) / MillisecondsPerSlot
+	return (currentTimeMs - genesisMs) / s.MillisecondsPerSlot()
 }
 
-func CurrentInterval(genesisTime, currentTimeMs uint64) uint64 {
+func (s ChainSpec) CurrentInterval(genesisTime, currentTimeMs uint64) uint64 {
 	genesisMs, ok := unixMillis(genesisTime)
 	if !ok {
 		return 0
@@ -19,11 +39,11 @@ func CurrentInterval(genesisTime, currentTimeMs uint64) uint64 {
 	if currentTimeMs < genesisMs {
 		return 0
 	}
-	msIntoSlot := (currentTimeMs - genesisMs) % MillisecondsPerSlot
-	return msIntoSlot / MillisecondsPerInterval
+	msIntoSlot := (currentTimeMs - genesisMs) % s.MillisecondsPerSlot()
+	return msIntoSlot / s.MillisecondsPerInterval()
 }
 
-func TotalIntervals(genesisTime, currentTimeMs uint64) uint64 {
+func (s ChainSpec) TotalIntervals(genesisTime, currentTimeMs uint64) uint64 {
 	genesisMs, ok := unixMillis(genesisTime)
 	if !ok {
 		return 0
@@ -31,21 +51,21 @@ func TotalIntervals(genesisTime, currentTimeMs uint64) uint64 {
 	if currentTimeMs < genesisMs {
 		return 0
 	}
-	return (currentTimeMs - genesisMs) / MillisecondsPerInterval
+	return (currentTimeMs - genesisMs) / s.MillisecondsPerInterval()
 }
 
-func IntervalsFromSlot(slot uint64) uint64 {
-	if slot > ^uint64(0)/IntervalsPerSlot {
+func (s ChainSpec) IntervalsFromSlot(slot uint64) uint64 {
+	if slot > ^uint64(0)/s.IntervalsPerSlot {
 		return ^uint64(0)
 	}
-	return slot * IntervalsPerSlot
+	return slot * s.IntervalsPerSlot
 }
 
-func IntervalsFromUnixTime(unixSeconds, genesisTime uint64) uint64 {
+func (s ChainSpec) IntervalsFromUnixTime(unixSeconds, genesisTime uint64) uint64 {
 	if unixSeconds < genesisTime {
 		return 0
 	}
-	return elapsedSecondsToIntervals(unixSeconds - genesisTime)
+	return s.elapsedSecondsToIntervals(unixSeconds - genesisTime)
 }
 
 func unixMillis(seconds uint64) (uint64, bool) {
@@ -55,14 +75,14 @@ func unixMillis(seconds uint64) (uint64, bool) {
 	return seconds * 1000, true
 }
 
-func elapsedSecondsToIntervals(seconds uint64) uint64 {
-	wholeSlots := seconds / SecondsPerSlot
-	remainder := seconds % SecondsPerSlot
-	if wholeSlots > ^uint64(0)/IntervalsPerSlot {
+func (s ChainSpec) elapsedSecondsToIntervals(seconds uint64) uint64 {
+	wholeSlots := seconds / s.SecondsPerSlot
+	remainder := seconds % s.SecondsPerSlot
+	if wholeSlots > ^uint64(0)/s.IntervalsPerSlot {
 		return ^uint64(0)
 	}
-	base := wholeSlots * IntervalsPerSlot
-	extra := remainder * 1000 / MillisecondsPerInterval
+	base := wholeSlots * s.IntervalsPerSlot
+	extra := remainder * 1000 / s.MillisecondsPerInterval()
 	if base > ^uint64(0)-extra {
 		return ^uint64(0)
 	}
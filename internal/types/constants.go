@@ -1,5 +1,8 @@
 package types
 
+// The slot timing and chain limits below are the devnet preset; the running
+// values come from Spec(). The list limits are also the compile-time SSZ
+// maxima that a runtime spec may not exceed.
 const (
 	SecondsPerSlot          = 4
 	IntervalsPerSlot        = 5
@@ -11,6 +14,7 @@ const (
 	AttestationCommitteeCount  = 1
 	JustificationLookbackSlots = 3
 	MaxAttestationsData        = 16
+	MaxBlockAttestations       = 1 << 12
 
 	JustificationValidatorsLimit = HistoricalRootsLimit * ValidatorRegistryLimit
 
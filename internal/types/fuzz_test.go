@@ -0,0 +1,214 @@
+package types_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/specfixtures"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type sszCodec interface {
+	UnmarshalSSZ(buf []byte) error
+	MarshalSSZ() ([]byte, error)
+	HashTreeRoot() ([32]byte, error)
+}
+
+// maxDecodeAlloc bounds what decoding n bytes may allocate. Every element of
+// a list costs at least one encoded byte, so allocation must stay linear.
+func maxDecodeAlloc(n int) uint64 {
+	return 64<<10 + 32*uint64(n)
+}
+
+func allocatedBytes(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+// fuzzSSZ seeds f with the encoded samples and every spec fixture of
+// typeName, then checks that any input the decoder accepts survives
+// decode→encode→decode unchanged and hashes without error.
+//
+// Re-encoding may be shorter than the input: fastssz accepts a 4-byte body
+// for an empty list of variable-size elements, which encodes back to nothing.
+func fuzzSSZ[T any, P interface {
+	*T
+	sszCodec
+}](f *testing.F, typeName string, samples ...P) {
+	for _, s := range samples {
+		b, err := s.MarshalSSZ()
+		if err != nil {
+			f.Fatalf("encode seed: %v", err)
+		}
+		f.Add(b)
+	}
+	var roots []string
+	for _, root := range specfixtures.SSZFixtureRoots {
+		roots = append(roots, "../../"+root)
+	}
+	for _, b := range specfixtures.SSZSeeds(typeName, roots...) {
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		first := P(new(T))
+		var err error
+		if n := allocatedBytes(func() { err = first.UnmarshalSSZ(data) }); n > maxDecodeAlloc(len(data)) {
+			t.Fatalf("decoding %d bytes allocated %d bytes", len(data), n)
+		}
+		if err != nil {
+			return
+		}
+		enc, err := first.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("encode decoded value: %v", err)
+		}
+		if len(enc) > len(data) {
+			t.Fatalf("%d input bytes re-encoded to %d", len(data), len(enc))
+		}
+
+		second := P(new(T))
+		if err := second.UnmarshalSSZ(enc); err != nil {
+			t.Fatalf("decode re-encoded value: %v", err)
+		}
+		enc2, err := second.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("encode second value: %v", err)
+		}
+		if !bytes.Equal(enc2, enc) {
+			t.Fatal("decode→encode→decode is not stable")
+		}
+		root1, err := first.HashTreeRoot()
+		if err != nil {
+			t.Fatalf("hash decoded value: %v", err)
+		}
+		if root2, _ := second.HashTreeRoot(); root2 != root1 {
+			t.Fatal("hash tree root changed across round-trip")
+		}
+	})
+}
+
+func sampleCheckpoint(slot uint64) *types.Checkpoint {
+	return &types.Checkpoint{Root: [32]byte{byte(slot), 0xaa}, Slot: slot}
+}
+
+func sampleAttestationData() *types.AttestationData {
+	return &types.AttestationData{
+		Slot:   7,
+		Head:   sampleCheckpoint(7),
+		Target: sampleCheckpoint(6),
+		Source: sampleCheckpoint(3),
+	}
+}
+
+func sampleProof() *types.AggregatedSignatureProof {
+	bits := types.NewBitlistSSZ(5)
+	types.BitlistSet(bits, 1)
+	types.BitlistSet(bits, 4)
+	return &types.AggregatedSignatureProof{Participants: bits, ProofData: []byte{1, 2, 3}}
+}
+
+func sampleSignedBlock() *types.SignedBlock {
+	bits := types.NewBitlistSSZ(9)
+	types.BitlistSet(bits, 8)
+	return &types.SignedBlock{
+		Block: &types.Block{
+			Slot:          8,
+			ProposerIndex: 2,
+			ParentRoot:    [32]byte{1},
+			StateRoot:     [32]byte{2},
+			Body: &types.BlockBody{Attestations: []*types.AggregatedAttestation{
+				{AggregationBits: bits, Data: sampleAttestationData()},
+			}},
+		},
+		Signature: &types.BlockSignatures{
+			AttestationSignatures: []*types.AggregatedSignatureProof{sampleProof()},
+			ProposerSignature:     [types.SignatureSize]byte{0xfe},
+		},
+	}
+}
+
+func sampleState() *types.State {
+	justified := types.NewBitlistSSZ(3)
+	types.BitlistSet(justified, 0)
+	return &types.State{
+		Config:                   &types.ChainConfig{GenesisTime: 1700000000},
+		Slot:                     3,
+		LatestBlockHeader:        &types.BlockHeader{Slot: 3, ProposerIndex: 1, BodyRoot: [32]byte{9}},
+		LatestJustified:          sampleCheckpoint(1),
+		LatestFinalized:          sampleCheckpoint(0),
+		HistoricalBlockHashes:    [][]byte{make([]byte, 32), bytes.Repeat([]byte{1}, 32)},
+		JustifiedSlots:           justified,
+		Validators:               []*types.Validator{{Index: 0}, {AttestationPubkey: [types.PubkeySize]byte{1}, Index: 1}},
+		JustificationsRoots:      [][]byte{bytes.Repeat([]byte{2}, 32)},
+		JustificationsValidators: types.NewBitlistSSZ(2),
+	}
+}
+
+func FuzzCheckpoint(f *testing.F) {
+	fuzzSSZ(f, "Checkpoint", sampleCheckpoint(0), sampleCheckpoint(1<<40))
+}
+
+func FuzzChainConfig(f *testing.F) {
+	fuzzSSZ(f, "Config", &types.ChainConfig{GenesisTime: 1700000000})
+}
+
+func FuzzValidator(f *testing.F) {
+	fuzzSSZ(f, "Validator", &types.Validator{ProposalPubkey: [types.PubkeySize]byte{3}, Index: 9})
+}
+
+func FuzzAttestationData(f *testing.F) {
+	fuzzSSZ(f, "AttestationData", sampleAttestationData())
+}
+
+func FuzzAttestation(f *testing.F) {
+	fuzzSSZ(f, "Attestation", &types.Attestation{ValidatorID: 4, Data: sampleAttestationData()})
+}
+
+func FuzzSignedAttestation(f *testing.F) {
+	fuzzSSZ(f, "SignedAttestation", &types.SignedAttestation{ValidatorID: 4, Data: sampleAttestationData()})
+}
+
+func FuzzAggregatedAttestation(f *testing.F) {
+	fuzzSSZ(f, "AggregatedAttestation", sampleSignedBlock().Block.Body.Attestations[0])
+}
+
+func FuzzSignedAggregatedAttestation(f *testing.F) {
+	fuzzSSZ(f, "SignedAggregatedAttestation", &types.SignedAggregatedAttestation{Data: sampleAttestationData(), Proof: sampleProof()})
+}
+
+func FuzzAggregatedSignatureProof(f *testing.F) {
+	fuzzSSZ(f, "AggregatedSignatureProof", sampleProof())
+}
+
+func FuzzBlockHeader(f *testing.F) {
+	fuzzSSZ(f, "BlockHeader", sampleState().LatestBlockHeader)
+}
+
+func FuzzBlockBody(f *testing.F) {
+	fuzzSSZ(f, "BlockBody", &types.BlockBody{}, sampleSignedBlock().Block.Body)
+}
+
+func FuzzBlock(f *testing.F) {
+	fuzzSSZ(f, "Block", sampleSignedBlock().Block)
+}
+
+func FuzzBlockSignatures(f *testing.F) {
+	fuzzSSZ(f, "BlockSignatures", sampleSignedBlock().Signature)
+}
+
+func FuzzSignedBlock(f *testing.F) {
+	fuzzSSZ(f, "SignedBlock", sampleSignedBlock())
+}
+
+func FuzzState(f *testing.F) {
+	fuzzSSZ(f, "State", sampleState())
+}
+
+func FuzzBlocksByRangeRequest(f *testing.F) {
+	fuzzSSZ(f, "BlocksByRangeRequest", &types.BlocksByRangeRequest{StartSlot: 5, Count: 64})
+}
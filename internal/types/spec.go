@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// ChainSpec holds the protocol parameters that can differ between networks.
+// The SSZ list limits of the generated encoders are fixed at compile time, so
+// HistoricalRootsLimit and ValidatorRegistryLimit may only tighten them.
+// Intervals 0-3 carry fixed duties and the last interval accepts new votes,
+// so a slot needs at least five intervals.
+type ChainSpec struct {
+	Preset                     string `json:"preset"`
+	SecondsPerSlot             uint64 `json:"seconds_per_slot"`
+	IntervalsPerSlot           uint64 `json:"intervals_per_slot"`
+	HistoricalRootsLimit       uint64 `json:"historical_roots_limit"`
+	ValidatorRegistryLimit     uint64 `json:"validator_registry_limit"`
+	AttestationCommitteeCount  uint64 `json:"attestation_committee_count"`
+	JustificationLookbackSlots uint64 `json:"justification_lookback_slots"`
+	MaxAttestationsData        uint64 `json:"max_attestations_data"`
+}
+
+const (
+	PresetDevnet  = "devnet"
+	PresetMinimal = "minimal"
+
+	minIntervalsPerSlot = 5
+)
+
+var presets = map[string]ChainSpec{
+	PresetDevnet: {
+		Preset:                     PresetDevnet,
+		SecondsPerSlot:             SecondsPerSlot,
+		IntervalsPerSlot:           IntervalsPerSlot,
+		HistoricalRootsLimit:       HistoricalRootsLimit,
+		ValidatorRegistryLimit:     ValidatorRegistryLimit,
+		AttestationCommitteeCount:  AttestationCommitteeCount,
+		JustificationLookbackSlots: JustificationLookbackSlots,
+		MaxAttestationsData:        MaxAttestationsData,
+	},
+	PresetMinimal: {
+		Preset:                     PresetMinimal,
+		SecondsPerSlot:             2,
+		IntervalsPerSlot:           5,
+		HistoricalRootsLimit:       1 << 12,
+		ValidatorRegistryLimit:     1 << 8,
+		AttestationCommitteeCount:  1,
+		JustificationLookbackSlots: 3,
+		MaxAttestationsData:        8,
+	},
+}
+
+func PresetSpec(name string) (ChainSpec, error) {
+	spec, ok := presets[name]
+	if !ok {
+		return ChainSpec{}, fmt.Errorf("unknown preset %q (known: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+	return spec, nil
+}
+
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func DevnetSpec() ChainSpec {
+	return presets[PresetDevnet]
+}
+
+func (s ChainSpec) MillisecondsPerSlot() uint64 {
+	return s.SecondsPerSlot * 1000
+}
+
+func (s ChainSpec) MillisecondsPerInterval() uint64 {
+	return s.MillisecondsPerSlot() / s.IntervalsPerSlot
+}
+
+// LastInterval is the interval that promotes new votes to known.
+func (s ChainSpec) LastInterval() uint64 {
+	return s.IntervalsPerSlot - 1
+}
+
+func (s ChainSpec) Validate() error {
+	switch {
+	case s.SecondsPerSlot == 0:
+		return fmt.Errorf("SECONDS_PER_SLOT must be > 0")
+	case s.IntervalsPerSlot < minIntervalsPerSlot:
+		return fmt.Errorf("INTERVALS_PER_SLOT=%d, want >= %d", s.IntervalsPerSlot, minIntervalsPerSlot)
+	case s.MillisecondsPerSlot()%s.IntervalsPerSlot != 0:
+		return fmt.Errorf("SECONDS_PER_SLOT=%d does not split into %d whole-millisecond intervals",
+			s.SecondsPerSlot, s.IntervalsPerSlot)
+	case s.HistoricalRootsLimit == 0 || s.HistoricalRootsLimit > HistoricalRootsLimit:
+		return fmt.Errorf("HISTORICAL_ROOTS_LIMIT=%d, want 1..%d", s.HistoricalRootsLimit, HistoricalRootsLimit)
+	case s.ValidatorRegistryLimit == 0 || s.ValidatorRegistryLimit > ValidatorRegistryLimit:
+		return fmt.Errorf("VALIDATOR_REGISTRY_LIMIT=%d, want 1..%d", s.ValidatorRegistryLimit, ValidatorRegistryLimit)
+	case s.AttestationCommitteeCount == 0:
+		return fmt.Errorf("ATTESTATION_COMMITTEE_COUNT must be > 0")
+	case s.JustificationLookbackSlots == 0:
+		return fmt.Errorf("JUSTIFICATION_LOOKBACK_SLOTS must be > 0")
+	case s.MaxAttestationsData == 0 || s.MaxAttestationsData > MaxBlockAttestations:
+		return fmt.Errorf("MAX_ATTESTATIONS_DATA=%d, want 1..%d", s.MaxAttestationsData, MaxBlockAttestations)
+	}
+	return nil
+}
+
+var activeSpec atomic.Pointer[ChainSpec]
+
+// Spec is the chain spec this process runs with, the devnet preset unless
+// SetSpec was called at startup.
+func Spec() ChainSpec {
+	if s := activeSpec.Load(); s != nil {
+		return *s
+	}
+	return DevnetSpec()
+}
+
+func SetSpec(s ChainSpec) {
+	activeSpec.Store(&s)
+}
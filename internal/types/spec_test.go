@@ -0,0 +1,60 @@
+package types
+
+import "testing"
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, name := range PresetNames() {
+		spec, err := PresetSpec(name)
+		if err != nil {
+			t.Fatalf("preset %s: %v", name, err)
+		}
+		if err := spec.Validate(); err != nil {
+			t.Fatalf("preset %s invalid: %v", name, err)
+		}
+	}
+	if _, err := PresetSpec("mainnet"); err == nil {
+		t.Fatal("expected unknown preset to fail")
+	}
+}
+
+func TestSpecValidateRejectsOutOfRange(t *testing.T) {
+	tests := map[string]func(*ChainSpec){
+		"zero seconds":          func(s *ChainSpec) { s.SecondsPerSlot = 0 },
+		"too few intervals":     func(s *ChainSpec) { s.IntervalsPerSlot = 4 },
+		"uneven intervals":      func(s *ChainSpec) { s.SecondsPerSlot, s.IntervalsPerSlot = 1, 7 },
+		"history above ssz":     func(s *ChainSpec) { s.HistoricalRootsLimit = HistoricalRootsLimit + 1 },
+		"registry above ssz":    func(s *ChainSpec) { s.ValidatorRegistryLimit = ValidatorRegistryLimit + 1 },
+		"zero committees":       func(s *ChainSpec) { s.AttestationCommitteeCount = 0 },
+		"zero lookback":         func(s *ChainSpec) { s.JustificationLookbackSlots = 0 },
+		"attestations over ssz": func(s *ChainSpec) { s.MaxAttestationsData = MaxBlockAttestations + 1 },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := DevnetSpec()
+			mutate(&spec)
+			if err := spec.Validate(); err == nil {
+				t.Fatalf("expected %+v to be rejected", spec)
+			}
+		})
+	}
+}
+
+func TestSetSpecDrivesClock(t *testing.T) {
+	t.Cleanup(func() { SetSpec(DevnetSpec()) })
+	spec := DevnetSpec()
+	spec.SecondsPerSlot, spec.IntervalsPerSlot = 3, 6
+	SetSpec(spec)
+
+	if got := CurrentSlot(10, 10_000+7_000); got != 2 {
+		t.Fatalf("slot=%d, want 2", got)
+	}
+	if got := CurrentInterval(10, 10_000+7_000); got != 2 {
+		t.Fatalf("interval=%d, want 2", got)
+	}
+	if got := IntervalsFromSlot(4); got != 24 {
+		t.Fatalf("intervals from slot=%d, want 24", got)
+	}
+	if got := Spec().LastInterval(); got != 5 {
+		t.Fatalf("last interval=%d, want 5", got)
+	}
+}
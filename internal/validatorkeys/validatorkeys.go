@@ -0,0 +1,98 @@
+// Package validatorkeys loads the signing keys used by gean and
+// gean-validator and checks how many signing epochs they have left.
+package validatorkeys
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/geanlabs/gean/internal/keystore"
+	"github.com/geanlabs/gean/internal/logger"
+	"github.com/geanlabs/gean/internal/remotesigner"
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss"
+)
+
+// WarnIntervalSlots spaces out repeated exhaustion warnings (~1h).
+const WarnIntervalSlots = 900
+
+// Config selects where validator keys come from and when to warn about them.
+type Config struct {
+	RemoteSigner         remotesigner.ClientConfig
+	SigningHistoryFile   string
+	KeystorePasswordFile string
+	KeyExpiryWarnEpochs  uint64
+}
+
+// RegisterFlags registers the remote signer, signing history, keystore
+// password and key expiry flags into cfg.
+func RegisterFlags(fs *flag.FlagSet, cfg *Config) {
+	remotesigner.RegisterFlags(fs, &cfg.RemoteSigner)
+	fs.StringVar(&cfg.SigningHistoryFile, "signing-history-file", "", "Persist the slot signing history to this file (optional)")
+	fs.Uint64Var(&cfg.KeyExpiryWarnEpochs, "key-expiry-warn-epochs", 21600, "Warn when a validator key has this many signing epochs left (0 disables)")
+	fs.StringVar(&cfg.KeystorePasswordFile, "keystore-password-file", "", "Password for encrypted validator keystores; prompts on the terminal when unset")
+}
+
+func (cfg Config) Validate() error {
+	return cfg.RemoteSigner.Validate()
+}
+
+// Load connects to the remote signer when one is configured, and otherwise
+// reads nodeID's keys from validatorsFile and keysDir. Messages are logged
+// under component.
+func Load(ctx context.Context, cfg Config, validatorsFile, keysDir, nodeID, component string) (*xmss.KeyManager, error) {
+	var (
+		keyManager *xmss.KeyManager
+		err        error
+	)
+	if cfg.RemoteSigner.URL != "" {
+		keyManager, err = remotesigner.NewKeyManager(ctx, cfg.RemoteSigner)
+		if err != nil {
+			return nil, fmt.Errorf("connect remote signer: %w", err)
+		}
+		logger.Info(component, "using remote signer %s", cfg.RemoteSigner.URL)
+	} else {
+		keyManager, err = xmss.LoadValidatorKeysWithPasswords(validatorsFile, keysDir, nodeID,
+			keystore.PasswordFlag(cfg.KeystorePasswordFile, os.Stderr))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.SigningHistoryFile != "" {
+		history, err := xmss.LoadSigningHistory(cfg.SigningHistoryFile)
+		if err != nil {
+			keyManager.Close()
+			return nil, err
+		}
+		keyManager.UseSigningHistory(history)
+	}
+	return keyManager, nil
+}
+
+// CheckLifetimes refuses keys that are already exhausted and warns about
+// keys close to exhaustion.
+func CheckLifetimes(keyManager *xmss.KeyManager, spec types.ChainSpec, genesisTime, warnEpochs uint64, component string) error {
+	slot := spec.CurrentSlot(genesisTime, uint64(time.Now().UnixMilli()))
+	if _, err := keyManager.Lifetimes(); err != nil {
+		logger.Warn(component, "cannot read key lifetimes: %v", err)
+	}
+	if err := keyManager.CheckExpiry(slot); err != nil {
+		return err
+	}
+	WarnExpiring(keyManager, slot, warnEpochs, component)
+	return nil
+}
+
+func WarnExpiring(keyManager *xmss.KeyManager, slot, warnEpochs uint64, component string) {
+	if warnEpochs == 0 {
+		return
+	}
+	for _, lt := range keyManager.ExpiringKeys(slot, warnEpochs) {
+		logger.Warn(component, "validator key nearly exhausted validator=%d role=%s remaining_epochs=%d end_slot=%d - rotate keys",
+			lt.ValidatorIndex, lt.Role, lt.Remaining(slot), lt.End)
+	}
+}
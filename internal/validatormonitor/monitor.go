@@ -0,0 +1,342 @@
+// Package validatormonitor follows the attestations and blocks of a chosen
+// set of validators and reports whether they are aggregated, included and
+// voting for the canonical chain.
+package validatormonitor
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/geanlabs/gean/internal/metrics"
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// EvaluationDelaySlots is how long a slot's votes wait for inclusion before
+// they are checked against the canonical chain.
+const EvaluationDelaySlots = 8
+
+const (
+	OutcomeSeen       = "seen"
+	OutcomeAggregated = "aggregated"
+	OutcomeIncluded   = "included"
+	OutcomeMissed     = "missed"
+)
+
+const (
+	VoteHead   = "head"
+	VoteTarget = "target"
+	VoteSource = "source"
+)
+
+// Chain resolves the canonical block at or before a slot.
+type Chain interface {
+	BlockRootAt(slot uint64) ([32]byte, bool)
+}
+
+type Summary struct {
+	ValidatorIndex         uint64  `json:"validator_index"`
+	Local                  bool    `json:"local"`
+	AttestationsSeen       uint64  `json:"attestations_seen"`
+	AttestationsAggregated uint64  `json:"attestations_aggregated"`
+	AttestationsIncluded   uint64  `json:"attestations_included"`
+	AttestationsMissed     uint64  `json:"attestations_missed"`
+	VotesEvaluated         uint64  `json:"votes_evaluated"`
+	CorrectHead            uint64  `json:"correct_head"`
+	CorrectTarget          uint64  `json:"correct_target"`
+	CorrectSource          uint64  `json:"correct_source"`
+	AverageInclusionDelay  float64 `json:"average_inclusion_delay"`
+	BlocksProposed         uint64  `json:"blocks_proposed"`
+	LastAttestationSlot    uint64  `json:"last_attestation_slot"`
+}
+
+type vote struct {
+	data       *types.AttestationData
+	seen       bool
+	aggregated bool
+	included   bool
+}
+
+type validatorState struct {
+	summary           Summary
+	inclusionDelaySum uint64
+	votes             map[uint64]*vote
+}
+
+type Monitor struct {
+	mu         sync.Mutex
+	local      func() []uint64
+	localSet   map[uint64]bool
+	extra      map[uint64]bool
+	validators map[uint64]*validatorState
+
+	started  bool
+	nextEval uint64
+}
+
+// New monitors the validators returned by local, re-read every slot so keys
+// added or removed at runtime are followed.
+func New(local func() []uint64) *Monitor {
+	m := &Monitor{
+		local:      local,
+		localSet:   make(map[uint64]bool),
+		extra:      make(map[uint64]bool),
+		validators: make(map[uint64]*validatorState),
+	}
+	m.refreshLocked()
+	return m
+}
+
+// Track adds validators that are not run by this node.
+func (m *Monitor) Track(validatorIDs ...uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, vid := range validatorIDs {
+		m.extra[vid] = true
+	}
+}
+
+func (m *Monitor) refreshLocked() {
+	if m.local == nil {
+		return
+	}
+	clear(m.localSet)
+	for _, vid := range m.local() {
+		m.localSet[vid] = true
+	}
+}
+
+func (m *Monitor) tracked(vid uint64) bool {
+	return m.localSet[vid] || m.extra[vid]
+}
+
+// settled reports whether slot was already evaluated; late events for it
+// are ignored so a vote is never both missed and included.
+func (m *Monitor) settled(slot uint64) bool {
+	return m.started && slot < m.nextEval
+}
+
+func (m *Monitor) stateLocked(vid uint64) *validatorState {
+	st, ok := m.validators[vid]
+	if !ok {
+		st = &validatorState{summary: Summary{ValidatorIndex: vid}, votes: make(map[uint64]*vote)}
+		m.validators[vid] = st
+	}
+	return st
+}
+
+func (m *Monitor) voteLocked(vid uint64, data *types.AttestationData) *vote {
+	st := m.stateLocked(vid)
+	v, ok := st.votes[data.Slot]
+	if !ok {
+		v = &vote{data: data}
+		st.votes[data.Slot] = v
+	}
+	return v
+}
+
+// OnAttestation records an individual attestation, produced locally or
+// received over gossip.
+func (m *Monitor) OnAttestation(vid uint64, data *types.AttestationData) {
+	if m == nil || data == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.tracked(vid) || m.settled(data.Slot) {
+		return
+	}
+	v := m.voteLocked(vid, data)
+	if v.seen {
+		return
+	}
+	v.seen = true
+	st := m.validators[vid]
+	st.summary.AttestationsSeen++
+	st.summary.LastAttestationSlot = max(st.summary.LastAttestationSlot, data.Slot)
+	metrics.IncValidatorMonitorAttestation(vid, OutcomeSeen)
+}
+
+// OnAggregate records the participants of a verified aggregated attestation.
+func (m *Monitor) OnAggregate(data *types.AttestationData, participants []byte) {
+	if m == nil || data == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.settled(data.Slot) {
+		return
+	}
+	for _, vid := range types.BitlistIndices(participants) {
+		if !m.tracked(vid) {
+			continue
+		}
+		v := m.voteLocked(vid, data)
+		if v.aggregated {
+			continue
+		}
+		v.aggregated = true
+		m.validators[vid].summary.AttestationsAggregated++
+		metrics.IncValidatorMonitorAttestation(vid, OutcomeAggregated)
+	}
+}
+
+// OnBlock records an imported block's proposer and the first inclusion of
+// each monitored vote in its attestations.
+func (m *Monitor) OnBlock(block *types.Block) {
+	if m == nil || block == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tracked(block.ProposerIndex) {
+		m.stateLocked(block.ProposerIndex).summary.BlocksProposed++
+		metrics.IncValidatorMonitorBlock(block.ProposerIndex)
+	}
+	if block.Body == nil {
+		return
+	}
+	for _, att := range block.Body.Attestations {
+		if att == nil || att.Data == nil || m.settled(att.Data.Slot) {
+			continue
+		}
+		for _, vid := range types.BitlistIndices(att.AggregationBits) {
+			if !m.tracked(vid) {
+				continue
+			}
+			v := m.voteLocked(vid, att.Data)
+			if v.included {
+				continue
+			}
+			v.included = true
+			var delay uint64
+			if block.Slot > att.Data.Slot {
+				delay = block.Slot - att.Data.Slot
+			}
+			st := m.validators[vid]
+			st.summary.AttestationsIncluded++
+			st.inclusionDelaySum += delay
+			metrics.IncValidatorMonitorAttestation(vid, OutcomeIncluded)
+			metrics.ObserveValidatorMonitorInclusionDelay(vid, delay)
+		}
+	}
+}
+
+// OnSlot evaluates every slot that is now EvaluationDelaySlots old: votes
+// never included count as missed, and known votes are checked against chain.
+func (m *Monitor) OnSlot(slot uint64, chain Chain) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshLocked()
+	if !m.started {
+		m.started = true
+		m.nextEval = slot
+		return
+	}
+	for ; m.nextEval+EvaluationDelaySlots <= slot; m.nextEval++ {
+		m.evaluateLocked(m.nextEval, chain)
+	}
+}
+
+func (m *Monitor) evaluateLocked(slot uint64, chain Chain) {
+	for vid, st := range m.validators {
+		if !m.tracked(vid) {
+			clear(st.votes)
+		}
+	}
+	for vid := range m.localSet {
+		m.evaluateVoteLocked(vid, slot, chain)
+	}
+	for vid := range m.extra {
+		if !m.localSet[vid] {
+			m.evaluateVoteLocked(vid, slot, chain)
+		}
+	}
+}
+
+func (m *Monitor) evaluateVoteLocked(vid, slot uint64, chain Chain) {
+	st := m.stateLocked(vid)
+	v := st.votes[slot]
+	for s := range st.votes {
+		if s <= slot {
+			delete(st.votes, s)
+		}
+	}
+	if v == nil || !v.included {
+		st.summary.AttestationsMissed++
+		metrics.IncValidatorMonitorAttestation(vid, OutcomeMissed)
+	}
+	if v == nil || chain == nil || !validData(v.data) {
+		return
+	}
+
+	head, okHead := chain.BlockRootAt(v.data.Slot)
+	target, okTarget := chain.BlockRootAt(v.data.Target.Slot)
+	source, okSource := chain.BlockRootAt(v.data.Source.Slot)
+	if !okHead || !okTarget || !okSource {
+		return
+	}
+	st.summary.VotesEvaluated++
+	m.countVoteLocked(st, vid, VoteHead, head == v.data.Head.Root)
+	m.countVoteLocked(st, vid, VoteTarget, target == v.data.Target.Root)
+	m.countVoteLocked(st, vid, VoteSource, source == v.data.Source.Root)
+}
+
+func (m *Monitor) countVoteLocked(st *validatorState, vid uint64, kind string, correct bool) {
+	if correct {
+		switch kind {
+		case VoteHead:
+			st.summary.CorrectHead++
+		case VoteTarget:
+			st.summary.CorrectTarget++
+		case VoteSource:
+			st.summary.CorrectSource++
+		}
+	}
+	metrics.IncValidatorMonitorVote(vid, kind, correct)
+}
+
+func validData(data *types.AttestationData) bool {
+	return data != nil && data.Head != nil && data.Target != nil && data.Source != nil
+}
+
+// Summaries returns the running totals of every currently monitored
+// validator, ordered by index.
+func (m *Monitor) Summaries() []Summary {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Summary, 0, len(m.localSet)+len(m.extra))
+	for vid, st := range m.validators {
+		if !m.tracked(vid) {
+			continue
+		}
+		out = append(out, m.summaryLocked(vid, st))
+	}
+	for vid := range m.localSet {
+		if _, ok := m.validators[vid]; !ok {
+			out = append(out, Summary{ValidatorIndex: vid, Local: true})
+		}
+	}
+	for vid := range m.extra {
+		if _, ok := m.validators[vid]; !ok && !m.localSet[vid] {
+			out = append(out, Summary{ValidatorIndex: vid})
+		}
+	}
+	slices.SortFunc(out, func(a, b Summary) int { return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex) })
+	return out
+}
+
+func (m *Monitor) summaryLocked(vid uint64, st *validatorState) Summary {
+	s := st.summary
+	s.Local = m.localSet[vid]
+	if s.AttestationsIncluded > 0 {
+		s.AverageInclusionDelay = float64(st.inclusionDelaySum) / float64(s.AttestationsIncluded)
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package validatormonitor
+
+import (
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type fakeChain map[uint64][32]byte
+
+func (c fakeChain) BlockRootAt(slot uint64) ([32]byte, bool) {
+	for s := slot; ; s-- {
+		if root, ok := c[s]; ok {
+			return root, true
+		}
+		if s == 0 {
+			return [32]byte{}, false
+		}
+	}
+}
+
+func attData(slot uint64, head, target, source [32]byte) *types.AttestationData {
+	return &types.AttestationData{
+		Slot:   slot,
+		Head:   &types.Checkpoint{Root: head, Slot: slot},
+		Target: &types.Checkpoint{Root: target, Slot: 8},
+		Source: &types.Checkpoint{Root: source, Slot: 0},
+	}
+}
+
+func TestMonitorTracksInclusionAndCorrectness(t *testing.T) {
+	chain := fakeChain{0: {0x01}, 8: {0x08}, 10: {0x0a}, 11: {0x0b}}
+	m := New(func() []uint64 { return []uint64{1, 2} })
+	m.Track(7)
+	m.OnSlot(10, chain)
+
+	good := attData(10, [32]byte{0x0a}, [32]byte{0x08}, [32]byte{0x01})
+	wrongHead := attData(10, [32]byte{0xff}, [32]byte{0x08}, [32]byte{0x01})
+	m.OnAttestation(1, good)
+	m.OnAttestation(2, wrongHead)
+	m.OnAttestation(9, good)
+	m.OnAggregate(good, types.BitlistFromIndices([]uint64{1, 7}))
+	m.OnBlock(&types.Block{
+		Slot:          12,
+		ProposerIndex: 2,
+		Body: &types.BlockBody{Attestations: []*types.AggregatedAttestation{
+			{AggregationBits: types.BitlistFromIndices([]uint64{1, 7}), Data: good},
+		}},
+	})
+
+	m.OnSlot(10+EvaluationDelaySlots, chain)
+
+	summaries := m.Summaries()
+	if len(summaries) != 3 {
+		t.Fatalf("summaries=%+v, want validators 1, 2 and 7", summaries)
+	}
+	v1, v2, v7 := summaries[0], summaries[1], summaries[2]
+	if !v1.Local || v1.AttestationsSeen != 1 || v1.AttestationsAggregated != 1 || v1.AttestationsIncluded != 1 || v1.AttestationsMissed != 0 {
+		t.Fatalf("validator 1=%+v", v1)
+	}
+	if v1.AverageInclusionDelay != 2 || v1.CorrectHead != 1 || v1.CorrectTarget != 1 || v1.CorrectSource != 1 {
+		t.Fatalf("validator 1 correctness=%+v", v1)
+	}
+	if v2.AttestationsMissed != 1 || v2.VotesEvaluated != 1 || v2.CorrectHead != 0 || v2.CorrectTarget != 1 || v2.BlocksProposed != 1 {
+		t.Fatalf("validator 2=%+v", v2)
+	}
+	if v7.Local || v7.AttestationsIncluded != 1 || v7.VotesEvaluated != 1 || v7.CorrectHead != 1 {
+		t.Fatalf("validator 7=%+v", v7)
+	}
+}
+
+func TestMonitorCountsSilentValidatorsAsMissed(t *testing.T) {
+	ids := []uint64{3}
+	m := New(func() []uint64 { return ids })
+	m.OnSlot(5, nil)
+	m.OnSlot(5+EvaluationDelaySlots+1, nil)
+	if s := m.Summaries(); len(s) != 1 || s[0].AttestationsMissed != 2 {
+		t.Fatalf("summaries=%+v, want two missed slots", s)
+	}
+
+	m.OnBlock(&types.Block{Slot: 20, Body: &types.BlockBody{Attestations: []*types.AggregatedAttestation{
+		{AggregationBits: types.BitlistFromIndices([]uint64{3}), Data: attData(5, [32]byte{}, [32]byte{}, [32]byte{})},
+	}}})
+	if s := m.Summaries(); s[0].AttestationsIncluded != 0 {
+		t.Fatal("inclusion of an already evaluated slot must be ignored")
+	}
+
+	ids = nil
+	m.OnSlot(5+EvaluationDelaySlots+2, nil)
+	if s := m.Summaries(); len(s) != 0 {
+		t.Fatalf("removed validator still reported: %+v", s)
+	}
+}
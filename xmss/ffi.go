@@ -1,3 +1,5 @@
+//go:build !xmss_insecure
+
 package xmss
 
 // #cgo CFLAGS: -I.
@@ -74,7 +76,6 @@ package xmss
 import "C"
 
 import (
-	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -83,30 +84,9 @@ import (
 	"github.com/geanlabs/gean/internal/types"
 )
 
-const (
-	MessageLength    = 32
-	MaxProofSize     = 1 << 20
-	SignatureBuffer  = 4000
-	PubkeyBuffer     = 256
-	PrivateKeyBuffer = 10 << 20
-)
-
-var (
-	ErrEmptyInput          = errors.New("empty input")
-	ErrCountMismatch       = errors.New("public key count does not match signature count")
-	ErrAggregationFailed   = errors.New("signature aggregation failed")
-	ErrSerializationFailed = errors.New("proof serialization failed")
-	ErrProofTooBig         = errors.New("aggregated proof exceeds 1 MiB")
-	ErrVerificationFailed  = errors.New("aggregated signature verification failed")
-	ErrDeserializeFailed   = errors.New("proof deserialization failed")
-	ErrSigningFailed       = errors.New("signing failed")
-	ErrInvalidSignature    = errors.New("signature verification returned invalid")
-	ErrSignatureError      = errors.New("signature verification error (malformed data)")
-	ErrPubkeyParseFailed   = errors.New("public key parsing failed")
-	ErrKeypairParseFailed  = errors.New("keypair parsing failed")
-	ErrMalformedChildProof = errors.New("malformed child proof")
-	ErrMalformedRawInput   = errors.New("malformed raw signature input")
-)
+// Insecure reports whether this binary was built with the xmss_insecure
+// stand-in instead of the real signature scheme.
+const Insecure = false
 
 var (
 	proverOnce   sync.Once
@@ -143,22 +123,6 @@ func VerifySignatureSSZ(pubkey [types.PubkeySize]byte, slot uint32, message [32]
 	}
 }
 
-type ChildProof struct {
-	Pubkeys   []CPubKey
-	ProofData []byte
-}
-
-const LogInvRate = 2
-
-func AggregateSignatures(
-	pubkeys []CPubKey,
-	sigs []CSig,
-	message [32]byte,
-	slot uint32,
-) ([]byte, error) {
-	return AggregateWithChildren(pubkeys, sigs, nil, message, slot)
-}
-
 func AggregateWithChildren(
 	pubkeys []CPubKey,
 	sigs []CSig,
@@ -280,44 +244,6 @@ func AggregateWithChildren(
 	return result, nil
 }
 
-func validateRawInputs(pubkeys []CPubKey, sigs []CSig) error {
-	if err := validatePublicKeys(pubkeys); err != nil {
-		return err
-	}
-	for i, sig := range sigs {
-		if sig == nil {
-			return fmt.Errorf("%w: signature %d is nil", ErrMalformedRawInput, i)
-		}
-	}
-	return nil
-}
-
-func validatePublicKeys(pubkeys []CPubKey) error {
-	for i, pk := range pubkeys {
-		if pk == nil {
-			return fmt.Errorf("%w: pubkey %d is nil", ErrMalformedRawInput, i)
-		}
-	}
-	return nil
-}
-
-func validateChildProofs(children []ChildProof) error {
-	for i, child := range children {
-		if len(child.Pubkeys) == 0 {
-			return fmt.Errorf("%w: child %d has no pubkeys", ErrMalformedChildProof, i)
-		}
-		if len(child.ProofData) == 0 {
-			return fmt.Errorf("%w: child %d proof data is empty", ErrMalformedChildProof, i)
-		}
-		for j, pk := range child.Pubkeys {
-			if pk == nil {
-				return fmt.Errorf("%w: child %d pubkey %d is nil", ErrMalformedChildProof, i, j)
-			}
-		}
-	}
-	return nil
-}
-
 func VerifyAggregatedSignature(
 	proofData []byte,
 	pubkeys []CPubKey,
@@ -357,10 +283,6 @@ func VerifyAggregatedSignature(
 	return nil
 }
 
-type CPubKey = unsafe.Pointer
-
-type CSig = unsafe.Pointer
-
 func ParsePublicKey(pubkeyBytes [types.PubkeySize]byte) (CPubKey, error) {
 	pk := C.hashsig_public_key_from_ssz(
 		(*C.uint8_t)(unsafe.Pointer(&pubkeyBytes[0])),
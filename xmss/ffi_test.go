@@ -1,3 +1,5 @@
+//go:build !xmss_insecure
+
 package xmss
 
 import (
@@ -167,6 +169,33 @@ func TestKeyGenerateSignVerifyRoundtrip(t *testing.T) {
 	}
 }
 
+func TestActiveRangeMatchesGeneratedInterval(t *testing.T) {
+	// Both bounds sit on bottom-tree boundaries (2^16 epochs) and span two
+	// bottom trees, so key generation keeps the requested interval as is.
+	const start, count = 1 << 16, 1 << 17
+	kp, err := GenerateKeyPair("gean-activation-interval", start, count)
+	if err != nil {
+		t.Fatalf("key generation failed: %v", err)
+	}
+	defer kp.Close()
+
+	got, err := kp.ActiveRange()
+	if err != nil {
+		t.Fatalf("active range: %v", err)
+	}
+	if want := (ActiveRange{Start: start, End: start + count}); got != want {
+		t.Fatalf("active range=%+v, want %+v", got, want)
+	}
+	if _, err := kp.Sign(uint32(got.Start), [32]byte{0x01}); err != nil {
+		t.Fatalf("sign at first active slot: %v", err)
+	}
+
+	kp.Close()
+	if _, err := kp.ActiveRange(); err == nil {
+		t.Fatal("closed keypair should not report an active range")
+	}
+}
+
 func TestVerifySignatureSSZMalformedPubkey(t *testing.T) {
 	var pubkey [52]byte
 	var sig [2536]byte
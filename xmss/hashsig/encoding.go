@@ -0,0 +1,69 @@
+package hashsig
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+)
+
+// Aborting target-sum encoding: the message hash is read as base-8 chunks,
+// one per chain, and only hashes whose chunks sum to targetSum are valid.
+const (
+	chainLength = 8
+	targetSum   = 200
+
+	msgLen          = 9
+	chunksPerElem   = 8
+	messageHashLen  = (Dimension + chunksPerElem - 1) / chunksPerElem
+	chunkDomainSize = 1 << (3 * chunksPerElem)
+	// Elements at or above abortBound would bias the chunks.
+	abortBound = (Modulus - 1) / chunkDomainSize * chunkDomainSize
+)
+
+var errEncodingAborted = errors.New("hashsig: message hash is not a valid codeword")
+
+func messageElements(message [32]byte) []FieldElement {
+	le := slices.Clone(message[:])
+	slices.Reverse(le)
+	acc := new(big.Int).SetBytes(le)
+	mod := big.NewInt(Modulus)
+	out := make([]FieldElement, msgLen)
+	r := new(big.Int)
+	for i := range out {
+		acc.QuoRem(acc, mod, r)
+		out[i] = FieldElement(r.Uint64())
+	}
+	return out
+}
+
+// encode returns the chain positions the signature's hashes start at.
+func encode(param *[ParameterLen]FieldElement, epoch uint32, message [32]byte, rho *[RandLen]FieldElement) ([]uint8, error) {
+	t := messageTweak(epoch)
+	input := make([]FieldElement, 0, RandLen+ParameterLen+tweakLen+msgLen)
+	input = append(input, rho[:]...)
+	input = append(input, param[:]...)
+	input = append(input, t[:]...)
+	input = append(input, messageElements(message)...)
+
+	chunks := make([]uint8, 0, messageHashLen*chunksPerElem)
+	for _, fe := range compress(poseidon24(), input, messageHashLen) {
+		if fe >= abortBound {
+			return nil, errEncodingAborted
+		}
+		v := uint32(fe) % chunkDomainSize
+		for range chunksPerElem {
+			chunks = append(chunks, uint8(v%chainLength))
+			v /= chainLength
+		}
+	}
+	chunks = chunks[:Dimension]
+
+	sum := 0
+	for _, c := range chunks {
+		sum += int(c)
+	}
+	if sum != targetSum {
+		return nil, errEncodingAborted
+	}
+	return chunks, nil
+}
@@ -0,0 +1,47 @@
+package hashsig
+
+func add(a, b FieldElement) FieldElement {
+	s := uint64(a) + uint64(b)
+	if s >= Modulus {
+		s -= Modulus
+	}
+	return FieldElement(s)
+}
+
+func mul(a, b FieldElement) FieldElement {
+	return FieldElement(uint64(a) * uint64(b) % Modulus)
+}
+
+func neg(a FieldElement) FieldElement {
+	if a == 0 {
+		return 0
+	}
+	return Modulus - a
+}
+
+func cube(a FieldElement) FieldElement {
+	return mul(mul(a, a), a)
+}
+
+func pow(a FieldElement, e uint64) FieldElement {
+	result := FieldElement(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mul(result, a)
+		}
+		a = mul(a, a)
+	}
+	return result
+}
+
+func inverse(a FieldElement) FieldElement {
+	return pow(a, Modulus-2)
+}
+
+// decompose writes acc in base Modulus, least significant digit first.
+func decompose(dst []FieldElement, acc uint64) {
+	for i := range dst {
+		dst[i] = FieldElement(acc % Modulus)
+		acc /= Modulus
+	}
+}
@@ -0,0 +1,98 @@
+// Package hashsig decodes and verifies hash-sig (leanSig) public keys and
+// signatures in pure Go, without cgo, so tools built with CGO_ENABLED=0 can
+// check them.
+//
+// Keys and signatures are SSZ containers of KoalaBear field elements, each a
+// little-endian uint32 in canonical form. Verification recomputes the
+// Poseidon2 chain ends and the Merkle path; signing and aggregation stay on
+// the FFI in package xmss.
+package hashsig
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Modulus is the KoalaBear prime 2^31 - 2^24 + 1.
+const Modulus = 0x7f000001
+
+// Production (devnet4) instantiation parameters.
+const (
+	HashLen      = 8
+	RandLen      = 7
+	ParameterLen = 5
+	Dimension    = 46
+	LogLifetime  = 32
+)
+
+const (
+	elementSize = 4
+	digestSize  = HashLen * elementSize
+	offsetSize  = 4
+
+	// signatureFixedSize is the path offset, rho and the hashes offset.
+	signatureFixedSize = offsetSize + RandLen*elementSize + offsetSize
+
+	PublicKeySize = (HashLen + ParameterLen) * elementSize
+	SignatureSize = signatureFixedSize + offsetSize + LogLifetime*digestSize + Dimension*digestSize
+)
+
+var (
+	ErrInvalidLength  = errors.New("hashsig: invalid length")
+	ErrInvalidOffset  = errors.New("hashsig: invalid offset")
+	ErrNonCanonical   = errors.New("hashsig: field element not canonical")
+	ErrUnexpectedSize = errors.New("hashsig: unexpected dimension")
+)
+
+// FieldElement is a canonical KoalaBear element.
+type FieldElement uint32
+
+// Digest is one tweakable-hash output.
+type Digest [HashLen]FieldElement
+
+func readElement(b []byte) (FieldElement, error) {
+	v := binary.LittleEndian.Uint32(b)
+	if v >= Modulus {
+		return 0, fmt.Errorf("%w: %#x", ErrNonCanonical, v)
+	}
+	return FieldElement(v), nil
+}
+
+func readElements(dst []FieldElement, b []byte) error {
+	for i := range dst {
+		v, err := readElement(b[i*elementSize:])
+		if err != nil {
+			return err
+		}
+		dst[i] = v
+	}
+	return nil
+}
+
+func appendElements(b []byte, elems []FieldElement) []byte {
+	for _, e := range elems {
+		b = binary.LittleEndian.AppendUint32(b, uint32(e))
+	}
+	return b
+}
+
+func readDigests(b []byte) ([]Digest, error) {
+	if len(b)%digestSize != 0 {
+		return nil, fmt.Errorf("%w: digest list of %d bytes", ErrInvalidLength, len(b))
+	}
+	digests := make([]Digest, len(b)/digestSize)
+	for i := range digests {
+		if err := readElements(digests[i][:], b[i*digestSize:]); err != nil {
+			return nil, err
+		}
+	}
+	return digests, nil
+}
+
+func appendDigests(b []byte, digests []Digest) []byte {
+	for i := range digests {
+		b = appendElements(b, digests[i][:])
+	}
+	return b
+}
@@ -0,0 +1,191 @@
+package hashsig
+
+import "sync"
+
+// Poseidon2 over KoalaBear with the x^3 S-box, as instantiated by Plonky3:
+// 8 external rounds split around the internal ones, round constants from the
+// Grain LFSR of the Poseidon2 reference parameters script.
+const (
+	externalRounds = 8
+	fieldBits      = 31
+)
+
+type poseidon2 struct {
+	width    int
+	initial  [][]FieldElement
+	internal []FieldElement
+	final    [][]FieldElement
+	diag     []FieldElement
+}
+
+// Internal layer diagonals. The internal matrix is 1 + diag(V), with V given
+// as small powers of two and their inverses.
+var (
+	diag16 = []diagEntry{
+		{-2, 0}, {1, 0}, {2, 0}, {1, 1}, {3, 0}, {4, 0}, {-1, 1}, {-3, 0},
+		{-4, 0}, {1, 8}, {1, 3}, {1, 24}, {-1, 8}, {-1, 3}, {-1, 4}, {-1, 24},
+	}
+	diag24 = []diagEntry{
+		{-2, 0}, {1, 0}, {2, 0}, {1, 1}, {3, 0}, {4, 0}, {-1, 1}, {-3, 0},
+		{-4, 0}, {1, 8}, {1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}, {1, 24},
+		{-1, 8}, {-1, 3}, {-1, 4}, {-1, 5}, {-1, 6}, {-1, 7}, {-1, 9}, {-1, 24},
+	}
+)
+
+// diagEntry is num / 2^log2Den.
+type diagEntry struct {
+	num     int64
+	log2Den uint
+}
+
+func (d diagEntry) element() FieldElement {
+	v := FieldElement(uint64(max(d.num, -d.num)) % Modulus)
+	if d.num < 0 {
+		v = neg(v)
+	}
+	return mul(v, inverse(pow(2, uint64(d.log2Den))))
+}
+
+var (
+	poseidon16 = sync.OnceValue(func() *poseidon2 { return newPoseidon2(16, 20, diag16) })
+	poseidon24 = sync.OnceValue(func() *poseidon2 { return newPoseidon2(24, 23, diag24) })
+)
+
+func newPoseidon2(width, internalRounds int, diag []diagEntry) *poseidon2 {
+	p := &poseidon2{width: width, diag: make([]FieldElement, width)}
+	for i, d := range diag {
+		p.diag[i] = d.element()
+	}
+
+	g := newGrain(width, externalRounds, internalRounds)
+	round := func() []FieldElement {
+		rc := make([]FieldElement, width)
+		for i := range rc {
+			rc[i] = g.element()
+		}
+		return rc
+	}
+	for range externalRounds / 2 {
+		p.initial = append(p.initial, round())
+	}
+	for range internalRounds {
+		p.internal = append(p.internal, round()[0])
+	}
+	for range externalRounds / 2 {
+		p.final = append(p.final, round())
+	}
+	return p
+}
+
+func (p *poseidon2) permute(state []FieldElement) {
+	p.externalLayer(state)
+	for _, rc := range p.initial {
+		p.externalRound(state, rc)
+	}
+	for _, rc := range p.internal {
+		state[0] = cube(add(state[0], rc))
+		var sum FieldElement
+		for _, s := range state {
+			sum = add(sum, s)
+		}
+		for i := range state {
+			state[i] = add(sum, mul(state[i], p.diag[i]))
+		}
+	}
+	for _, rc := range p.final {
+		p.externalRound(state, rc)
+	}
+}
+
+func (p *poseidon2) externalRound(state, rc []FieldElement) {
+	for i := range state {
+		state[i] = cube(add(state[i], rc[i]))
+	}
+	p.externalLayer(state)
+}
+
+// externalLayer applies circ(2M4, M4, ..., M4): M4 to every 4-element block,
+// then adds the sum of each block position across all blocks.
+func (p *poseidon2) externalLayer(state []FieldElement) {
+	for i := 0; i < len(state); i += 4 {
+		mat4(state[i : i+4])
+	}
+	var sums [4]FieldElement
+	for i, s := range state {
+		sums[i%4] = add(sums[i%4], s)
+	}
+	for i := range state {
+		state[i] = add(state[i], sums[i%4])
+	}
+}
+
+// mat4 multiplies by [[2,3,1,1],[1,2,3,1],[1,1,2,3],[3,1,1,2]].
+func mat4(x []FieldElement) {
+	t01 := add(x[0], x[1])
+	t23 := add(x[2], x[3])
+	t0123 := add(t01, t23)
+	t01123 := add(t0123, x[1])
+	t01233 := add(t0123, x[3])
+	x[3] = add(t01233, add(x[0], x[0]))
+	x[1] = add(t01123, add(x[2], x[2]))
+	x[0] = add(t01123, t01)
+	x[2] = add(t01233, t23)
+}
+
+// grain is the self-shrinking Grain LFSR that generates Poseidon round
+// constants, seeded with the field and permutation shape.
+type grain struct {
+	bits [80]byte
+}
+
+func newGrain(width, fullRounds, partialRounds int) *grain {
+	g := &grain{}
+	i := 0
+	put := func(v, n int) {
+		for b := n - 1; b >= 0; b-- {
+			g.bits[i] = byte(v>>b) & 1
+			i++
+		}
+	}
+	put(1, 2) // prime field
+	put(0, 4) // x^alpha S-box
+	put(fieldBits, 12)
+	put(width, 12)
+	put(fullRounds, 10)
+	put(partialRounds, 10)
+	put(1<<30-1, 30)
+	for range 160 {
+		g.clock()
+	}
+	return g
+}
+
+func (g *grain) clock() byte {
+	b := g.bits[62] ^ g.bits[51] ^ g.bits[38] ^ g.bits[23] ^ g.bits[13] ^ g.bits[0]
+	copy(g.bits[:], g.bits[1:])
+	g.bits[79] = b
+	return b
+}
+
+func (g *grain) bit() byte {
+	for {
+		if g.clock() == 1 {
+			return g.clock()
+		}
+		g.clock()
+	}
+}
+
+// element samples fieldBits bits, most significant first, rejecting values
+// outside the field.
+func (g *grain) element() FieldElement {
+	for {
+		var v uint64
+		for range fieldBits {
+			v = v<<1 | uint64(g.bit())
+		}
+		if v < Modulus {
+			return FieldElement(v)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package hashsig
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PublicKey is the Merkle root and the public hash parameter.
+type PublicKey struct {
+	Root      Digest
+	Parameter [ParameterLen]FieldElement
+}
+
+func ParsePublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, fmt.Errorf("%w: public key has %d bytes, expected %d", ErrInvalidLength, len(b), PublicKeySize)
+	}
+	pk := &PublicKey{}
+	if err := readElements(pk.Root[:], b); err != nil {
+		return nil, err
+	}
+	if err := readElements(pk.Parameter[:], b[digestSize:]); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+func (pk *PublicKey) Bytes() []byte {
+	b := make([]byte, 0, PublicKeySize)
+	b = appendElements(b, pk.Root[:])
+	return appendElements(b, pk.Parameter[:])
+}
+
+// Signature is a Merkle authentication path for the epoch's leaf, the
+// encoding randomness rho and one chain value per encoded chunk.
+type Signature struct {
+	Path   []Digest
+	Rho    [RandLen]FieldElement
+	Hashes []Digest
+}
+
+// ParseSignature decodes the SSZ container
+//
+//	Signature { path: { siblings: List[Digest] }, rho: [F; RandLen], hashes: List[Digest] }
+//
+// It accepts any path depth and chain count. CheckShape enforces the
+// production ones.
+func ParseSignature(b []byte) (*Signature, error) {
+	if len(b) < signatureFixedSize {
+		return nil, fmt.Errorf("%w: signature has %d bytes", ErrInvalidLength, len(b))
+	}
+	pathOffset := binary.LittleEndian.Uint32(b[0:])
+	hashesOffset := binary.LittleEndian.Uint32(b[signatureFixedSize-offsetSize:])
+	if pathOffset != signatureFixedSize {
+		return nil, fmt.Errorf("%w: path offset %d", ErrInvalidOffset, pathOffset)
+	}
+	if hashesOffset < pathOffset || uint64(hashesOffset) > uint64(len(b)) {
+		return nil, fmt.Errorf("%w: hashes offset %d", ErrInvalidOffset, hashesOffset)
+	}
+
+	sig := &Signature{}
+	if err := readElements(sig.Rho[:], b[offsetSize:]); err != nil {
+		return nil, err
+	}
+
+	path := b[pathOffset:hashesOffset]
+	if len(path) < offsetSize {
+		return nil, fmt.Errorf("%w: path has %d bytes", ErrInvalidLength, len(path))
+	}
+	if off := binary.LittleEndian.Uint32(path); off != offsetSize {
+		return nil, fmt.Errorf("%w: siblings offset %d", ErrInvalidOffset, off)
+	}
+	var err error
+	if sig.Path, err = readDigests(path[offsetSize:]); err != nil {
+		return nil, err
+	}
+	if sig.Hashes, err = readDigests(b[hashesOffset:]); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (sig *Signature) Bytes() []byte {
+	pathSize := offsetSize + len(sig.Path)*digestSize
+	b := make([]byte, 0, signatureFixedSize+pathSize+len(sig.Hashes)*digestSize)
+	b = binary.LittleEndian.AppendUint32(b, signatureFixedSize)
+	b = appendElements(b, sig.Rho[:])
+	b = binary.LittleEndian.AppendUint32(b, uint32(signatureFixedSize+pathSize))
+	b = binary.LittleEndian.AppendUint32(b, offsetSize)
+	b = appendDigests(b, sig.Path)
+	return appendDigests(b, sig.Hashes)
+}
+
+// CheckShape reports whether the signature has the production path depth
+// and chain count.
+func (sig *Signature) CheckShape() error {
+	if len(sig.Path) != LogLifetime {
+		return fmt.Errorf("%w: path depth %d, expected %d", ErrUnexpectedSize, len(sig.Path), LogLifetime)
+	}
+	if len(sig.Hashes) != Dimension {
+		return fmt.Errorf("%w: %d chains, expected %d", ErrUnexpectedSize, len(sig.Hashes), Dimension)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package hashsig
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+func readHexFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	b, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	return b
+}
+
+func productionSignature() *Signature {
+	sig := &Signature{Path: make([]Digest, LogLifetime), Hashes: make([]Digest, Dimension)}
+	for i := range sig.Rho {
+		sig.Rho[i] = FieldElement(i + 1)
+	}
+	for i := range sig.Path {
+		sig.Path[i][0] = FieldElement(i)
+	}
+	for i := range sig.Hashes {
+		sig.Hashes[i][HashLen-1] = Modulus - 1 - FieldElement(i)
+	}
+	return sig
+}
+
+func TestSizesMatchConsensusTypes(t *testing.T) {
+	if PublicKeySize != types.PubkeySize {
+		t.Fatalf("PublicKeySize=%d, types.PubkeySize=%d", PublicKeySize, types.PubkeySize)
+	}
+	if SignatureSize != types.SignatureSize {
+		t.Fatalf("SignatureSize=%d, types.SignatureSize=%d", SignatureSize, types.SignatureSize)
+	}
+}
+
+func TestParseReamVectors(t *testing.T) {
+	// The ream vector predates devnet4 and has 64 chains instead of 46.
+	sigBytes := readHexFixture(t, "ream_signature.hex")
+	sig, err := ParseSignature(sigBytes)
+	if err != nil {
+		t.Fatalf("parse signature: %v", err)
+	}
+	if len(sig.Path) != LogLifetime || len(sig.Hashes) != 64 {
+		t.Fatalf("path depth %d, chains %d", len(sig.Path), len(sig.Hashes))
+	}
+	if !bytes.Equal(sig.Bytes(), sigBytes) {
+		t.Fatal("signature does not re-encode to the same bytes")
+	}
+	if err := sig.CheckShape(); !errors.Is(err, ErrUnexpectedSize) {
+		t.Fatalf("CheckShape err=%v, want ErrUnexpectedSize", err)
+	}
+
+	pkBytes := readHexFixture(t, "ream_pubkey.hex")
+	pk, err := ParsePublicKey(pkBytes)
+	if err != nil {
+		t.Fatalf("parse public key: %v", err)
+	}
+	if !bytes.Equal(pk.Bytes(), pkBytes) {
+		t.Fatal("public key does not re-encode to the same bytes")
+	}
+}
+
+func TestProductionSignatureRoundtrip(t *testing.T) {
+	want := productionSignature()
+	b := want.Bytes()
+	if len(b) != SignatureSize {
+		t.Fatalf("encoded %d bytes, want %d", len(b), SignatureSize)
+	}
+	got, err := ParseSignature(b)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := got.CheckShape(); err != nil {
+		t.Fatalf("CheckShape: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), b) {
+		t.Fatal("roundtrip changed the encoding")
+	}
+}
+
+func TestParseSignatureRejectsMalformed(t *testing.T) {
+	valid := productionSignature().Bytes()
+	mutate := func(f func(b []byte) []byte) []byte {
+		return f(bytes.Clone(valid))
+	}
+	cases := map[string]struct {
+		b    []byte
+		want error
+	}{
+		"truncated": {valid[:signatureFixedSize-1], ErrInvalidLength},
+		"path offset": {mutate(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b, 0)
+			return b
+		}), ErrInvalidOffset},
+		"hashes offset past end": {mutate(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[signatureFixedSize-offsetSize:], uint32(len(b)+1))
+			return b
+		}), ErrInvalidOffset},
+		"hashes offset before path": {mutate(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[signatureFixedSize-offsetSize:], signatureFixedSize-1)
+			return b
+		}), ErrInvalidOffset},
+		"siblings offset": {mutate(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[signatureFixedSize:], 8)
+			return b
+		}), ErrInvalidOffset},
+		"partial digest": {valid[:len(valid)-1], ErrInvalidLength},
+		"non-canonical rho": {mutate(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[offsetSize:], Modulus)
+			return b
+		}), ErrNonCanonical},
+	}
+	for name, tc := range cases {
+		if _, err := ParseSignature(tc.b); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err=%v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestParsePublicKeyRejectsMalformed(t *testing.T) {
+	if _, err := ParsePublicKey(make([]byte, PublicKeySize-1)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatalf("short key err=%v, want ErrInvalidLength", err)
+	}
+	b := make([]byte, PublicKeySize)
+	binary.LittleEndian.PutUint32(b[PublicKeySize-elementSize:], 0xffffffff)
+	if _, err := ParsePublicKey(b); !errors.Is(err, ErrNonCanonical) {
+		t.Fatalf("non-canonical parameter err=%v, want ErrNonCanonical", err)
+	}
+}
@@ -0,0 +1,132 @@
+package hashsig
+
+import "sync"
+
+// Tweakable hash: Poseidon2 in compression mode for chain steps and inner
+// tree nodes, and a sponge for leaves. Every call is keyed by the public
+// parameter and a tweak that binds it to its position in the key.
+const (
+	tweakLen    = 2
+	capacityLen = 9
+
+	chainSeparator   = 0x00
+	treeSeparator    = 0x01
+	messageSeparator = 0x02
+)
+
+type tweak [tweakLen]FieldElement
+
+func newTweak(v uint64) tweak {
+	var t tweak
+	decompose(t[:], v)
+	return t
+}
+
+func chainTweak(epoch uint32, chain, pos uint8) tweak {
+	return newTweak(uint64(epoch)<<24 | uint64(chain)<<16 | uint64(pos)<<8 | chainSeparator)
+}
+
+func treeTweak(level uint8, pos uint32) tweak {
+	return newTweak(uint64(level)<<40 | uint64(pos)<<8 | treeSeparator)
+}
+
+func messageTweak(epoch uint32) tweak {
+	return newTweak(uint64(epoch)<<8 | messageSeparator)
+}
+
+// compress permutes the zero-padded input, adds the input back and keeps the
+// first n elements.
+func compress(p *poseidon2, input []FieldElement, n int) []FieldElement {
+	state := make([]FieldElement, p.width)
+	copy(state, input)
+	p.permute(state)
+	for i, v := range input {
+		state[i] = add(state[i], v)
+	}
+	return state[:n]
+}
+
+func sponge(p *poseidon2, capacity, input []FieldElement, n int) []FieldElement {
+	rate := p.width - len(capacity)
+	state := make([]FieldElement, p.width)
+	copy(state[rate:], capacity)
+	for len(input) > 0 {
+		chunk := input[:min(rate, len(input))]
+		for i, v := range chunk {
+			state[i] = add(state[i], v)
+		}
+		p.permute(state)
+		input = input[len(chunk):]
+	}
+	out := make([]FieldElement, 0, n)
+	for {
+		out = append(out, state[:rate]...)
+		if len(out) >= n {
+			return out[:n]
+		}
+		p.permute(state)
+	}
+}
+
+// leafCapacity separates the leaf sponge by the lengths it absorbs.
+var leafCapacity = sync.OnceValue(func() []FieldElement {
+	lengths := []uint32{ParameterLen, tweakLen, Dimension, HashLen}
+	input := make([]FieldElement, 24)
+	// The lengths form a 128-bit integer, first length most significant.
+	var hi, lo uint64
+	for _, l := range lengths {
+		hi = hi<<32 | lo>>32
+		lo = lo<<32 | uint64(l)
+	}
+	decompose128(input, hi, lo)
+	return compress(poseidon24(), input, capacityLen)
+})
+
+// decompose128 is decompose for the 128-bit integer hi·2^64 + lo.
+func decompose128(dst []FieldElement, hi, lo uint64) {
+	for i := range dst {
+		// Long division by Modulus in 32-bit limbs.
+		var rem uint64
+		limbs := [4]uint64{hi >> 32, hi & 0xffffffff, lo >> 32, lo & 0xffffffff}
+		for j, l := range limbs {
+			cur := rem<<32 | l
+			limbs[j] = cur / Modulus
+			rem = cur % Modulus
+		}
+		dst[i] = FieldElement(rem)
+		hi = limbs[0]<<32 | limbs[1]
+		lo = limbs[2]<<32 | limbs[3]
+	}
+}
+
+func keyedInput(param *[ParameterLen]FieldElement, t tweak, n int) []FieldElement {
+	input := make([]FieldElement, 0, ParameterLen+tweakLen+n)
+	input = append(input, param[:]...)
+	return append(input, t[:]...)
+}
+
+func hashChainStep(param *[ParameterLen]FieldElement, t tweak, d *Digest) Digest {
+	input := append(keyedInput(param, t, HashLen), d[:]...)
+	var out Digest
+	copy(out[:], compress(poseidon16(), input, HashLen))
+	return out
+}
+
+func hashTreeNode(param *[ParameterLen]FieldElement, t tweak, left, right *Digest) Digest {
+	input := keyedInput(param, t, 2*HashLen)
+	input = append(input, left[:]...)
+	input = append(input, right[:]...)
+	var out Digest
+	copy(out[:], compress(poseidon24(), input, HashLen))
+	return out
+}
+
+func hashLeaf(param *[ParameterLen]FieldElement, t tweak, ends []Digest) Digest {
+	input := keyedInput(param, t, len(ends)*HashLen)
+	for i := range ends {
+		input = append(input, ends[i][:]...)
+	}
+	var out Digest
+	copy(out[:], sponge(poseidon24(), leafCapacity(), input, HashLen))
+	return out
+}
@@ -0,0 +1,68 @@
+package hashsig
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+// ffiVector is a public key, epoch, message and signature labelled with the
+// FFI's verdict. make hashsig-vectors writes them from the Rust signer.
+type ffiVector struct {
+	Name      string `json:"name"`
+	Pubkey    string `json:"pubkey"`
+	Epoch     uint32 `json:"epoch"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+	Valid     bool   `json:"valid"`
+}
+
+func decodeHex(t *testing.T, name, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("%s: decode hex: %v", name, err)
+	}
+	return b
+}
+
+// TestVerifyMatchesFFIVectors checks pure-Go verification against vectors
+// produced by the FFI. HASHSIG_VECTORS selects a freshly generated set, as
+// make test-ffi does; otherwise the committed testdata set is used.
+func TestVerifyMatchesFFIVectors(t *testing.T) {
+	path := os.Getenv("HASHSIG_VECTORS")
+	if path == "" {
+		path = "testdata/ffi_vectors.json"
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			t.Skip("no committed FFI vectors; run make hashsig-vectors")
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read vectors: %v", err)
+	}
+	var vectors []ffiVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("decode vectors: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("vector file is empty")
+	}
+
+	for _, v := range vectors {
+		var message [32]byte
+		if n := copy(message[:], decodeHex(t, v.Name, v.Message)); n != len(message) {
+			t.Fatalf("%s: message has %d bytes", v.Name, n)
+		}
+		ok, err := VerifyBytes(decodeHex(t, v.Name, v.Pubkey), v.Epoch, message, decodeHex(t, v.Name, v.Signature))
+		if err != nil {
+			t.Fatalf("%s: verify: %v", v.Name, err)
+		}
+		if ok != v.Valid {
+			t.Errorf("%s: verify=%v, FFI=%v", v.Name, ok, v.Valid)
+		}
+	}
+}
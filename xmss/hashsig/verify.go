@@ -0,0 +1,56 @@
+package hashsig
+
+// Verify checks sig on message at epoch against pk. It returns false for a
+// well-formed signature that does not verify, and an error only when the
+// signature does not have the production shape.
+func Verify(pk *PublicKey, epoch uint32, message [32]byte, sig *Signature) (bool, error) {
+	if err := sig.CheckShape(); err != nil {
+		return false, err
+	}
+	chunks, err := encode(&pk.Parameter, epoch, message, &sig.Rho)
+	if err != nil {
+		return false, nil
+	}
+
+	ends := make([]Digest, Dimension)
+	for i, start := range chunks {
+		ends[i] = walkChain(&pk.Parameter, epoch, uint8(i), start, chainLength-1-start, sig.Hashes[i])
+	}
+	root := hashLeaf(&pk.Parameter, treeTweak(0, epoch), ends)
+	return merkleRoot(&pk.Parameter, epoch, root, sig.Path) == pk.Root, nil
+}
+
+// VerifyBytes is Verify on SSZ-encoded inputs.
+func VerifyBytes(pubkey []byte, epoch uint32, message [32]byte, signature []byte) (bool, error) {
+	pk, err := ParsePublicKey(pubkey)
+	if err != nil {
+		return false, err
+	}
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return false, err
+	}
+	return Verify(pk, epoch, message, sig)
+}
+
+// walkChain advances d, found at position start of the chain, by steps.
+func walkChain(param *[ParameterLen]FieldElement, epoch uint32, chain, start, steps uint8, d Digest) Digest {
+	for j := range steps {
+		d = hashChainStep(param, chainTweak(epoch, chain, start+j+1), &d)
+	}
+	return d
+}
+
+// merkleRoot hashes leaf up the authentication path for position epoch.
+func merkleRoot(param *[ParameterLen]FieldElement, epoch uint32, leaf Digest, path []Digest) Digest {
+	node, pos := leaf, epoch
+	for level := range path {
+		if pos%2 == 0 {
+			node = hashTreeNode(param, treeTweak(uint8(level+1), pos>>1), &node, &path[level])
+		} else {
+			node = hashTreeNode(param, treeTweak(uint8(level+1), pos>>1), &path[level], &node)
+		}
+		pos >>= 1
+	}
+	return node
+}
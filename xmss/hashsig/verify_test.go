@@ -0,0 +1,126 @@
+package hashsig
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"testing"
+)
+
+func randomDigest(r *rand.Rand) Digest {
+	var d Digest
+	for i := range d {
+		d[i] = FieldElement(r.Uint32N(Modulus))
+	}
+	return d
+}
+
+// signedFixture builds a key and signature the way a signer would: it
+// searches rho for a valid encoding, takes random chain values at the
+// encoded positions and a random authentication path, and derives the root.
+// It shares the hash code under test, so it only checks that verification is
+// consistent and rejects tampering; agreement with the real scheme is
+// checked by TestVerifyMatchesFFIVectors.
+func signedFixture(t *testing.T, epoch uint32, message [32]byte) (*PublicKey, *Signature) {
+	t.Helper()
+	r := rand.New(rand.NewPCG(uint64(epoch), 7))
+	pk := &PublicKey{}
+	for i := range pk.Parameter {
+		pk.Parameter[i] = FieldElement(r.Uint32N(Modulus))
+	}
+	sig := &Signature{Path: make([]Digest, LogLifetime), Hashes: make([]Digest, Dimension)}
+
+	var chunks []uint8
+	for range 1 << 20 {
+		for i := range sig.Rho {
+			sig.Rho[i] = FieldElement(r.Uint32N(Modulus))
+		}
+		var err error
+		if chunks, err = encode(&pk.Parameter, epoch, message, &sig.Rho); err == nil {
+			break
+		}
+	}
+	if chunks == nil {
+		t.Fatal("no rho gives a valid encoding")
+	}
+
+	ends := make([]Digest, Dimension)
+	for i, start := range chunks {
+		sig.Hashes[i] = randomDigest(r)
+		ends[i] = walkChain(&pk.Parameter, epoch, uint8(i), start, chainLength-1-start, sig.Hashes[i])
+	}
+	for i := range sig.Path {
+		sig.Path[i] = randomDigest(r)
+	}
+	pk.Root = merkleRoot(&pk.Parameter, epoch, hashLeaf(&pk.Parameter, treeTweak(0, epoch), ends), sig.Path)
+	return pk, sig
+}
+
+func TestVerifyAcceptsConsistentSignature(t *testing.T) {
+	for _, epoch := range []uint32{0, 5, 1<<32 - 1} {
+		message := [32]byte{0xab, byte(epoch)}
+		pk, sig := signedFixture(t, epoch, message)
+		ok, err := VerifyBytes(pk.Bytes(), epoch, message, sig.Bytes())
+		if err != nil || !ok {
+			t.Fatalf("epoch %d: verify=%v err=%v, want true", epoch, ok, err)
+		}
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	const epoch = 9
+	message := [32]byte{0x42}
+	pk, sig := signedFixture(t, epoch, message)
+
+	cases := []struct {
+		name   string
+		epoch  uint32
+		msg    [32]byte
+		mutate func(*PublicKey, *Signature)
+	}{
+		{"message", epoch, [32]byte{0x43}, nil},
+		{"epoch", epoch + 1, message, nil},
+		{"chain hash", epoch, message, func(_ *PublicKey, s *Signature) { s.Hashes[3][0] = add(s.Hashes[3][0], 1) }},
+		{"path", epoch, message, func(_ *PublicKey, s *Signature) { s.Path[LogLifetime-1][7] = add(s.Path[LogLifetime-1][7], 1) }},
+		{"rho", epoch, message, func(_ *PublicKey, s *Signature) { s.Rho[0] = add(s.Rho[0], 1) }},
+		{"parameter", epoch, message, func(p *PublicKey, _ *Signature) { p.Parameter[4] = add(p.Parameter[4], 1) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pk, sig := *pk, *sig
+			sig.Path = append([]Digest(nil), sig.Path...)
+			sig.Hashes = append([]Digest(nil), sig.Hashes...)
+			if tc.mutate != nil {
+				tc.mutate(&pk, &sig)
+			}
+			ok, err := Verify(&pk, tc.epoch, tc.msg, &sig)
+			if err != nil || ok {
+				t.Fatalf("verify=%v err=%v, want false", ok, err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsWrongShape(t *testing.T) {
+	pk, sig := signedFixture(t, 0, [32]byte{})
+	sig.Path = sig.Path[:LogLifetime-1]
+	if _, err := Verify(pk, 0, [32]byte{}, sig); err == nil {
+		t.Fatal("verify accepted a short authentication path")
+	}
+}
+
+func TestDecompose128(t *testing.T) {
+	dst := make([]FieldElement, 5)
+	decompose128(dst, 0x5, 0x0000000200000003)
+	var hi, lo uint64
+	for i := len(dst) - 1; i >= 0; i-- {
+		loHi, loLo := bits.Mul64(lo, Modulus)
+		hi = hi*Modulus + loHi
+		lo = loLo + uint64(dst[i])
+		if lo < loLo {
+			hi++
+		}
+	}
+	if hi != 0x5 || lo != 0x0000000200000003 {
+		t.Fatalf("recomposed %#x %#x", hi, lo)
+	}
+}
@@ -0,0 +1,169 @@
+//go:build !xmss_insecure
+
+package xmss
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+	"github.com/geanlabs/gean/xmss/hashsig"
+)
+
+// TestPureGoDecodingMatchesFFI checks that keys and signatures produced by
+// the FFI decode in pure Go and re-encode to identical bytes.
+func TestPureGoDecodingMatchesFFI(t *testing.T) {
+	kp, err := GenerateKeyPair("hashsig-decode-test", 0, 1<<18)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+	defer kp.Close()
+
+	pkBytes, err := kp.PublicKeyBytes()
+	if err != nil {
+		t.Fatalf("pubkey: %v", err)
+	}
+	pk, err := hashsig.ParsePublicKey(pkBytes[:])
+	if err != nil {
+		t.Fatalf("parse pubkey: %v", err)
+	}
+	if !bytes.Equal(pk.Bytes(), pkBytes[:]) {
+		t.Fatal("public key re-encoding differs from FFI bytes")
+	}
+
+	for _, slot := range []uint32{0, 1, 1 << 10} {
+		var message [32]byte
+		message[0] = byte(slot)
+		sigBytes, err := kp.Sign(slot, message)
+		if err != nil {
+			t.Fatalf("sign slot %d: %v", slot, err)
+		}
+		sig, err := hashsig.ParseSignature(sigBytes[:])
+		if err != nil {
+			t.Fatalf("parse signature slot %d: %v", slot, err)
+		}
+		if err := sig.CheckShape(); err != nil {
+			t.Fatalf("signature slot %d: %v", slot, err)
+		}
+		if !bytes.Equal(sig.Bytes(), sigBytes[:]) {
+			t.Fatalf("signature slot %d re-encoding differs from FFI bytes", slot)
+		}
+	}
+}
+
+var hashsigVectorsOut = flag.String("hashsig-vectors", "", "write the FFI verification vectors to this file")
+
+// hashsigVector is one entry of xmss/hashsig/testdata/ffi_vectors.json.
+type hashsigVector struct {
+	Name      string `json:"name"`
+	Pubkey    string `json:"pubkey"`
+	Epoch     uint32 `json:"epoch"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+	Valid     bool   `json:"valid"`
+}
+
+// ffiHashsigVectors signs the roundtrip_test.go message with the
+// roundtrip_test.go key at a few slots, derives tampered variants, and labels
+// each with the FFI's verdict.
+func ffiHashsigVectors(t *testing.T) []hashsigVector {
+	t.Helper()
+	kp, err := GenerateKeyPair("roundtrip-test-0", 0, 1<<18)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+	defer kp.Close()
+	pkBytes, err := kp.PublicKeyBytes()
+	if err != nil {
+		t.Fatalf("pubkey: %v", err)
+	}
+
+	bump := func(e *hashsig.FieldElement) { *e = (*e + 1) % hashsig.Modulus }
+	var vectors []hashsigVector
+	for _, slot := range []uint32{0, 1, 1 << 10} {
+		var message [32]byte
+		message[0] = 0xab
+		sigBytes, err := kp.Sign(slot, message)
+		if err != nil {
+			t.Fatalf("sign slot %d: %v", slot, err)
+		}
+		tampered := func(mutate func(*hashsig.Signature)) []byte {
+			sig, err := hashsig.ParseSignature(sigBytes[:])
+			if err != nil {
+				t.Fatalf("parse signature slot %d: %v", slot, err)
+			}
+			mutate(sig)
+			return sig.Bytes()
+		}
+
+		cases := []struct {
+			name string
+			slot uint32
+			msg  [32]byte
+			sig  []byte
+		}{
+			{"valid", slot, message, sigBytes[:]},
+			{"wrong slot", slot + 1, message, sigBytes[:]},
+			{"wrong message", slot, [32]byte{0xcd}, sigBytes[:]},
+			{"tampered chain hash", slot, message, tampered(func(s *hashsig.Signature) { bump(&s.Hashes[hashsig.Dimension-1][0]) })},
+			{"tampered path", slot, message, tampered(func(s *hashsig.Signature) { bump(&s.Path[0][hashsig.HashLen-1]) })},
+			{"tampered rho", slot, message, tampered(func(s *hashsig.Signature) { bump(&s.Rho[0]) })},
+		}
+		for _, tc := range cases {
+			var sig [types.SignatureSize]byte
+			copy(sig[:], tc.sig)
+			ok, err := VerifySignatureSSZ(pkBytes, tc.slot, tc.msg, sig)
+			if tc.name == "valid" && (err != nil || !ok) {
+				t.Fatalf("FFI rejected its own signature at slot %d: %v", slot, err)
+			}
+			vectors = append(vectors, hashsigVector{
+				Name:      fmt.Sprintf("slot %d %s", slot, tc.name),
+				Pubkey:    hex.EncodeToString(pkBytes[:]),
+				Epoch:     tc.slot,
+				Message:   hex.EncodeToString(tc.msg[:]),
+				Signature: hex.EncodeToString(tc.sig),
+				Valid:     err == nil && ok,
+			})
+		}
+	}
+	return vectors
+}
+
+// TestPureGoVerificationMatchesFFI checks that pure-Go verification agrees
+// with the FFI on the same keys, messages and signatures, including ones that
+// must be rejected.
+func TestPureGoVerificationMatchesFFI(t *testing.T) {
+	for _, v := range ffiHashsigVectors(t) {
+		pk, _ := hex.DecodeString(v.Pubkey)
+		sig, _ := hex.DecodeString(v.Signature)
+		var message [32]byte
+		hex.Decode(message[:], []byte(v.Message))
+		ok, err := hashsig.VerifyBytes(pk, v.Epoch, message, sig)
+		if err != nil {
+			t.Fatalf("%s: pure-Go verify: %v", v.Name, err)
+		}
+		if ok != v.Valid {
+			t.Fatalf("%s: pure Go=%v FFI=%v", v.Name, ok, v.Valid)
+		}
+	}
+}
+
+// TestWriteHashsigVectors writes the vectors the cgo-free hashsig tests check
+// against. It runs only with -hashsig-vectors; see make hashsig-vectors.
+func TestWriteHashsigVectors(t *testing.T) {
+	if *hashsigVectorsOut == "" {
+		t.Skip("set -hashsig-vectors to write FFI vectors")
+	}
+	b, err := json.MarshalIndent(ffiHashsigVectors(t), "", "  ")
+	if err != nil {
+		t.Fatalf("encode vectors: %v", err)
+	}
+	if err := os.WriteFile(*hashsigVectorsOut, append(b, '\n'), 0o644); err != nil {
+		t.Fatalf("write vectors: %v", err)
+	}
+}
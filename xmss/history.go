@@ -0,0 +1,218 @@
+package xmss
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type SigningRole string
+
+const (
+	RoleAttestation SigningRole = "attestation"
+	RoleProposal    SigningRole = "proposal"
+)
+
+// ErrSigningHistoryConflict is returned when a request would reuse an XMSS
+// one-time key: signing a different message at an already used slot, or
+// signing below the highest slot already signed.
+var ErrSigningHistoryConflict = errors.New("signing history conflict")
+
+type SigningRecord struct {
+	ValidatorIndex uint64      `json:"validator_index"`
+	Role           SigningRole `json:"role"`
+	Slot           uint64      `json:"slot"`
+	MessageRoot    string      `json:"message_root"`
+}
+
+type historyKey struct {
+	validatorIndex uint64
+	role           SigningRole
+}
+
+type historyEntry struct {
+	slot uint64
+	root [32]byte
+}
+
+type SigningHistory struct {
+	mu      sync.Mutex
+	path    string
+	entries map[historyKey]historyEntry
+}
+
+func NewSigningHistory() *SigningHistory {
+	return &SigningHistory{entries: make(map[historyKey]historyEntry)}
+}
+
+// LoadSigningHistory reads the history at path, starting empty if the file
+// does not exist yet. Every accepted signature is written back to path.
+func LoadSigningHistory(path string) (*SigningHistory, error) {
+	h := NewSigningHistory()
+	h.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read signing history: %w", err)
+	}
+	var records []SigningRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parse signing history: %w", err)
+	}
+	for _, rec := range records {
+		root, err := decodeRoot(rec.MessageRoot)
+		if err != nil {
+			return nil, fmt.Errorf("signing history validator %d: %w", rec.ValidatorIndex, err)
+		}
+		h.entries[historyKey{rec.ValidatorIndex, rec.Role}] = historyEntry{slot: rec.Slot, root: root}
+	}
+	return h, nil
+}
+
+// CheckAndRecord accepts a signing request and records it before the caller
+// signs. Re-signing the same message at the last slot is allowed.
+func (h *SigningHistory) CheckAndRecord(validatorIndex uint64, role SigningRole, slot uint64, root [32]byte) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := historyKey{validatorIndex, role}
+	last, ok := h.entries[key]
+	if ok {
+		if slot < last.slot {
+			return fmt.Errorf("%w: validator %d %s slot %d is below last signed slot %d",
+				ErrSigningHistoryConflict, validatorIndex, role, slot, last.slot)
+		}
+		if slot == last.slot {
+			if root != last.root {
+				return fmt.Errorf("%w: validator %d %s already signed a different message at slot %d",
+					ErrSigningHistoryConflict, validatorIndex, role, slot)
+			}
+			return nil
+		}
+	}
+
+	h.entries[key] = historyEntry{slot: slot, root: root}
+	if err := h.persistLocked(); err != nil {
+		if ok {
+			h.entries[key] = last
+		} else {
+			delete(h.entries, key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *SigningHistory) Records() []SigningRecord {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.recordsLocked()
+}
+
+func (h *SigningHistory) recordsLocked() []SigningRecord {
+	records := make([]SigningRecord, 0, len(h.entries))
+	for key, entry := range h.entries {
+		records = append(records, SigningRecord{
+			ValidatorIndex: key.validatorIndex,
+			Role:           key.role,
+			Slot:           entry.slot,
+			MessageRoot:    fmt.Sprintf("0x%x", entry.root),
+		})
+	}
+	sortRecords(records)
+	return records
+}
+
+func (h *SigningHistory) persistLocked() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(h.recordsLocked(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode signing history: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), ".signing-history-*")
+	if err != nil {
+		return fmt.Errorf("write signing history: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write signing history: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync signing history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write signing history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), h.path); err != nil {
+		return fmt.Errorf("replace signing history: %w", err)
+	}
+	return nil
+}
+
+// Import merges records exported by another signer. For each validator and
+// role the higher slot wins; on an equal slot the local entry is kept.
+func (h *SigningHistory) Import(records []SigningRecord) error {
+	if h == nil || len(records) == 0 {
+		return nil
+	}
+	incoming := make(map[historyKey]historyEntry, len(records))
+	for _, rec := range records {
+		if rec.Role != RoleAttestation && rec.Role != RoleProposal {
+			return fmt.Errorf("signing history validator %d: unknown role %q", rec.ValidatorIndex, rec.Role)
+		}
+		root, err := decodeRoot(rec.MessageRoot)
+		if err != nil {
+			return fmt.Errorf("signing history validator %d: %w", rec.ValidatorIndex, err)
+		}
+		key := historyKey{rec.ValidatorIndex, rec.Role}
+		if prev, ok := incoming[key]; ok && prev.slot >= rec.Slot {
+			continue
+		}
+		incoming[key] = historyEntry{slot: rec.Slot, root: root}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	previous := make(map[historyKey]historyEntry, len(h.entries))
+	for key, entry := range h.entries {
+		previous[key] = entry
+	}
+	for key, entry := range incoming {
+		if last, ok := h.entries[key]; ok && last.slot >= entry.slot {
+			continue
+		}
+		h.entries[key] = entry
+	}
+	if err := h.persistLocked(); err != nil {
+		h.entries = previous
+		return err
+	}
+	return nil
+}
+
+// ExportValidator returns the records held for one validator.
+func (h *SigningHistory) ExportValidator(validatorIndex uint64) []SigningRecord {
+	var out []SigningRecord
+	for _, rec := range h.Records() {
+		if rec.ValidatorIndex == validatorIndex {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package xmss
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSigningHistoryRejectsReuse(t *testing.T) {
+	h := NewSigningHistory()
+	if err := h.CheckAndRecord(1, RoleAttestation, 5, [32]byte{0x01}); err != nil {
+		t.Fatalf("first record: %v", err)
+	}
+	if err := h.CheckAndRecord(1, RoleAttestation, 5, [32]byte{0x01}); err != nil {
+		t.Fatalf("same message at same slot should be allowed: %v", err)
+	}
+	if err := h.CheckAndRecord(1, RoleAttestation, 5, [32]byte{0x02}); !errors.Is(err, ErrSigningHistoryConflict) {
+		t.Fatalf("different message err=%v, want conflict", err)
+	}
+	if err := h.CheckAndRecord(1, RoleAttestation, 4, [32]byte{0x01}); !errors.Is(err, ErrSigningHistoryConflict) {
+		t.Fatalf("lower slot err=%v, want conflict", err)
+	}
+	if err := h.CheckAndRecord(1, RoleProposal, 4, [32]byte{0x03}); err != nil {
+		t.Fatalf("roles are tracked separately: %v", err)
+	}
+	if err := h.CheckAndRecord(2, RoleAttestation, 1, [32]byte{0x04}); err != nil {
+		t.Fatalf("validators are tracked separately: %v", err)
+	}
+}
+
+func TestSigningHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h, err := LoadSigningHistory(path)
+	if err != nil {
+		t.Fatalf("load missing history: %v", err)
+	}
+	if err := h.CheckAndRecord(3, RoleProposal, 10, [32]byte{0xaa}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	reloaded, err := LoadSigningHistory(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	records := reloaded.Records()
+	if len(records) != 1 || records[0].ValidatorIndex != 3 || records[0].Slot != 10 || records[0].Role != RoleProposal {
+		t.Fatalf("records=%+v", records)
+	}
+	if err := reloaded.CheckAndRecord(3, RoleProposal, 10, [32]byte{0xbb}); !errors.Is(err, ErrSigningHistoryConflict) {
+		t.Fatalf("err=%v, want conflict after reload", err)
+	}
+}
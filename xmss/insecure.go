@@ -0,0 +1,279 @@
+//go:build xmss_insecure
+
+package xmss
+
+// This file replaces the Rust FFI with a pure-Go stand-in for local testing.
+// Keys, signatures and aggregates have the production sizes and are
+// deterministic, and any tampering makes verification fail. They are NOT
+// signatures: anyone who knows a public key can produce a valid signature for
+// it. Release builds refuse to compile with this tag, see insecure_release.go.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"unsafe"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// Insecure reports whether this binary was built with the xmss_insecure
+// stand-in instead of the real signature scheme.
+const Insecure = true
+
+var (
+	insecureKeyMagic   = []byte("GEAN-INSECURE-XMSS-KEY")
+	insecureProofMagic = []byte("GEAN-INSECURE-XMSS-AGG")
+)
+
+const insecureSeedSize = 32
+
+type insecurePrivateKey struct {
+	seed  [insecureSeedSize]byte
+	start uint64
+	end   uint64
+}
+
+type insecurePublicKey struct {
+	bytes [types.PubkeySize]byte
+}
+
+type insecureSignature struct {
+	bytes [types.SignatureSize]byte
+}
+
+type ValidatorKeyPair struct {
+	private *insecurePrivateKey
+	public  *insecurePublicKey
+	Index   uint64
+}
+
+// insecureHash expands a domain-separated SHA-256 digest of parts to n bytes.
+func insecureHash(n int, domain string, parts ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	for _, p := range parts {
+		var l [8]byte
+		binary.LittleEndian.PutUint64(l[:], uint64(len(p)))
+		h.Write(l[:])
+		h.Write(p)
+	}
+	digest := h.Sum(nil)
+
+	out := make([]byte, 0, n+sha256.Size)
+	for counter := uint32(0); len(out) < n; counter++ {
+		var c [4]byte
+		binary.LittleEndian.PutUint32(c[:], counter)
+		block := sha256.Sum256(append(c[:], digest...))
+		out = append(out, block[:]...)
+	}
+	return out[:n]
+}
+
+func insecurePublicKeyFor(seed [insecureSeedSize]byte) [types.PubkeySize]byte {
+	var pk [types.PubkeySize]byte
+	copy(pk[:], insecureHash(types.PubkeySize, "pubkey", seed[:]))
+	return pk
+}
+
+func insecureSignatureFor(pubkey [types.PubkeySize]byte, slot uint32, message [32]byte) [types.SignatureSize]byte {
+	var slotBytes [4]byte
+	binary.LittleEndian.PutUint32(slotBytes[:], slot)
+	var sig [types.SignatureSize]byte
+	copy(sig[:], insecureHash(types.SignatureSize, "signature", pubkey[:], slotBytes[:], message[:]))
+	return sig
+}
+
+func EnsureProverReady() {}
+
+func EnsureVerifierReady() {}
+
+func GenerateKeyPair(seedPhrase string, activationEpoch, numActiveEpochs uint64) (*ValidatorKeyPair, error) {
+	sk := &insecurePrivateKey{start: activationEpoch, end: activationEpoch + numActiveEpochs}
+	copy(sk.seed[:], insecureHash(insecureSeedSize, "seed", []byte(seedPhrase)))
+	return &ValidatorKeyPair{
+		private: sk,
+		public:  &insecurePublicKey{bytes: insecurePublicKeyFor(sk.seed)},
+	}, nil
+}
+
+func (kp *ValidatorKeyPair) PublicKeyPtr() *insecurePublicKey {
+	if kp == nil {
+		return nil
+	}
+	return kp.public
+}
+
+func (kp *ValidatorKeyPair) PrivateKeyPtr() *insecurePrivateKey {
+	if kp == nil {
+		return nil
+	}
+	return kp.private
+}
+
+func (kp *ValidatorKeyPair) Sign(slot uint32, message [32]byte) ([types.SignatureSize]byte, error) {
+	sk, pk := kp.PrivateKeyPtr(), kp.PublicKeyPtr()
+	if sk == nil || pk == nil {
+		return [types.SignatureSize]byte{}, fmt.Errorf("%w: keypair is nil or closed", ErrSigningFailed)
+	}
+	if uint64(slot) < sk.start || uint64(slot) >= sk.end {
+		return [types.SignatureSize]byte{}, fmt.Errorf("%w: validator %d slot %d", ErrSigningFailed, kp.Index, slot)
+	}
+	return insecureSignatureFor(pk.bytes, slot, message), nil
+}
+
+func (kp *ValidatorKeyPair) ActiveRange() (ActiveRange, error) {
+	sk := kp.PrivateKeyPtr()
+	if sk == nil {
+		return ActiveRange{}, fmt.Errorf("keypair is nil or closed")
+	}
+	return ActiveRange{Start: sk.start, End: sk.end}, nil
+}
+
+func (kp *ValidatorKeyPair) Close() {
+	if kp != nil {
+		kp.private = nil
+		kp.public = nil
+	}
+}
+
+func (kp *ValidatorKeyPair) PublicKeyBytes() ([types.PubkeySize]byte, error) {
+	pk := kp.PublicKeyPtr()
+	if pk == nil {
+		return [types.PubkeySize]byte{}, fmt.Errorf("keypair is nil or closed")
+	}
+	return pk.bytes, nil
+}
+
+// PrivateKeyBytes encodes the key as magic || start || end || seed. The real
+// scheme's parser rejects it, so stand-in keys cannot be loaded by a
+// production build.
+func (kp *ValidatorKeyPair) PrivateKeyBytes() ([]byte, error) {
+	sk := kp.PrivateKeyPtr()
+	if sk == nil {
+		return nil, fmt.Errorf("keypair is nil or closed")
+	}
+	out := slices.Clone(insecureKeyMagic)
+	out = binary.LittleEndian.AppendUint64(out, sk.start)
+	out = binary.LittleEndian.AppendUint64(out, sk.end)
+	return append(out, sk.seed[:]...), nil
+}
+
+func keypairFromSSZ(skBytes, pkBytes []byte, index uint64) (*ValidatorKeyPair, error) {
+	if len(skBytes) == 0 || len(pkBytes) == 0 {
+		return nil, fmt.Errorf("%w: validator %d: empty key bytes", ErrKeypairParseFailed, index)
+	}
+	if len(skBytes) != len(insecureKeyMagic)+16+insecureSeedSize || !bytes.HasPrefix(skBytes, insecureKeyMagic) {
+		return nil, fmt.Errorf("%w: validator %d", ErrKeypairParseFailed, index)
+	}
+	rest := skBytes[len(insecureKeyMagic):]
+	sk := &insecurePrivateKey{
+		start: binary.LittleEndian.Uint64(rest[0:8]),
+		end:   binary.LittleEndian.Uint64(rest[8:16]),
+	}
+	copy(sk.seed[:], rest[16:])
+	pk := insecurePublicKeyFor(sk.seed)
+	if !bytes.Equal(pk[:], pkBytes) {
+		return nil, fmt.Errorf("%w: validator %d", ErrKeypairParseFailed, index)
+	}
+	return &ValidatorKeyPair{private: sk, public: &insecurePublicKey{bytes: pk}, Index: index}, nil
+}
+
+func VerifySignatureSSZ(pubkey [types.PubkeySize]byte, slot uint32, message [32]byte, signature [types.SignatureSize]byte) (bool, error) {
+	return insecureSignatureFor(pubkey, slot, message) == signature, nil
+}
+
+func ParsePublicKey(pubkeyBytes [types.PubkeySize]byte) (CPubKey, error) {
+	return unsafe.Pointer(&insecurePublicKey{bytes: pubkeyBytes}), nil
+}
+
+func FreePublicKey(CPubKey) {}
+
+func ParseSignature(sigBytes []byte) (CSig, error) {
+	if len(sigBytes) != types.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	sig := &insecureSignature{}
+	copy(sig.bytes[:], sigBytes)
+	return unsafe.Pointer(sig), nil
+}
+
+func FreeSignature(CSig) {}
+
+// insecureProof commits to the message, slot and the set of participating
+// public keys, so the order keys are supplied in does not matter.
+func insecureProof(pubkeys []CPubKey, message [32]byte, slot uint32) []byte {
+	keys := make([][]byte, 0, len(pubkeys))
+	for _, pk := range pubkeys {
+		keys = append(keys, (*insecurePublicKey)(pk).bytes[:])
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	keys = slices.CompactFunc(keys, bytes.Equal)
+
+	var slotBytes [4]byte
+	binary.LittleEndian.PutUint32(slotBytes[:], slot)
+	parts := append([][]byte{message[:], slotBytes[:]}, keys...)
+	return append(slices.Clone(insecureProofMagic), insecureHash(sha256.Size, "aggregate", parts...)...)
+}
+
+func AggregateWithChildren(
+	pubkeys []CPubKey,
+	sigs []CSig,
+	children []ChildProof,
+	message [32]byte,
+	slot uint32,
+) ([]byte, error) {
+	numRaw := len(pubkeys)
+	numChildren := len(children)
+
+	if numRaw == 0 && numChildren == 0 {
+		return nil, ErrEmptyInput
+	}
+	if numRaw > 0 && len(sigs) != numRaw {
+		return nil, fmt.Errorf("%w: %d pubkeys, %d sigs", ErrCountMismatch, numRaw, len(sigs))
+	}
+	if err := validateRawInputs(pubkeys, sigs); err != nil {
+		return nil, err
+	}
+	if numRaw == 0 && numChildren < 2 {
+		return nil, fmt.Errorf("at least 2 children required when no raw sigs provided")
+	}
+	if err := validateChildProofs(children); err != nil {
+		return nil, err
+	}
+
+	all := slices.Clone(pubkeys)
+	for i, pk := range pubkeys {
+		sig := (*insecureSignature)(sigs[i])
+		if insecureSignatureFor((*insecurePublicKey)(pk).bytes, slot, message) != sig.bytes {
+			return nil, ErrAggregationFailed
+		}
+	}
+	for _, child := range children {
+		if !bytes.Equal(child.ProofData, insecureProof(child.Pubkeys, message, slot)) {
+			return nil, ErrAggregationFailed
+		}
+		all = append(all, child.Pubkeys...)
+	}
+	return insecureProof(all, message, slot), nil
+}
+
+func VerifyAggregatedSignature(
+	proofData []byte,
+	pubkeys []CPubKey,
+	message [32]byte,
+	slot uint32,
+) error {
+	if len(proofData) == 0 || len(pubkeys) == 0 {
+		return ErrEmptyInput
+	}
+	if err := validatePublicKeys(pubkeys); err != nil {
+		return err
+	}
+	if !bytes.Equal(proofData, insecureProof(pubkeys, message, slot)) {
+		return ErrVerificationFailed
+	}
+	return nil
+}
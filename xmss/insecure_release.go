@@ -0,0 +1,7 @@
+//go:build xmss_insecure && release
+
+package xmss
+
+// Release builds must use the real signature scheme. The undefined name below
+// stops the build if both tags are set.
+var _ = xmssInsecureCannotBeUsedInReleaseBuilds
@@ -0,0 +1,117 @@
+//go:build xmss_insecure
+
+package xmss
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInsecureSignatureRejectsTampering(t *testing.T) {
+	kp, err := GenerateKeyPair("insecure-tamper", 0, 8)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+	defer kp.Close()
+	pk, err := kp.PublicKeyBytes()
+	if err != nil {
+		t.Fatalf("pubkey: %v", err)
+	}
+	msg := [32]byte{0xab}
+	sig, err := kp.Sign(3, msg)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	if ok, _ := VerifySignatureSSZ(pk, 3, msg, sig); !ok {
+		t.Fatal("valid signature rejected")
+	}
+	tampered := sig
+	tampered[len(tampered)-1] ^= 1
+	if ok, _ := VerifySignatureSSZ(pk, 3, msg, tampered); ok {
+		t.Fatal("tampered signature accepted")
+	}
+	otherPk := pk
+	otherPk[0] ^= 1
+	if ok, _ := VerifySignatureSSZ(otherPk, 3, msg, sig); ok {
+		t.Fatal("signature accepted under another key")
+	}
+	if _, err := kp.Sign(8, msg); !errors.Is(err, ErrSigningFailed) {
+		t.Fatalf("sign past active range err=%v, want ErrSigningFailed", err)
+	}
+}
+
+func TestInsecureKeypairBytesRoundtrip(t *testing.T) {
+	kp, err := GenerateKeyPair("insecure-roundtrip", 4, 16)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+	defer kp.Close()
+	sk, err := kp.PrivateKeyBytes()
+	if err != nil {
+		t.Fatalf("private key bytes: %v", err)
+	}
+	pk, err := kp.PublicKeyBytes()
+	if err != nil {
+		t.Fatalf("pubkey: %v", err)
+	}
+
+	loaded, err := keypairFromSSZ(sk, pk[:], 7)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if r, _ := loaded.ActiveRange(); r != (ActiveRange{Start: 4, End: 20}) {
+		t.Fatalf("active range %+v, want 4..20", r)
+	}
+	other := pk
+	other[0] ^= 1
+	if _, err := keypairFromSSZ(sk, other[:], 7); !errors.Is(err, ErrKeypairParseFailed) {
+		t.Fatalf("mismatched pubkey err=%v, want ErrKeypairParseFailed", err)
+	}
+}
+
+func TestInsecureAggregateIsOrderIndependentAndBound(t *testing.T) {
+	msg := [32]byte{0x01}
+	var pks []CPubKey
+	var sigs []CSig
+	for _, seed := range []string{"agg-a", "agg-b", "agg-c"} {
+		kp, err := GenerateKeyPair(seed, 0, 8)
+		if err != nil {
+			t.Fatalf("keygen: %v", err)
+		}
+		pkBytes, _ := kp.PublicKeyBytes()
+		sigBytes, err := kp.Sign(2, msg)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		kp.Close()
+		pk, _ := ParsePublicKey(pkBytes)
+		sig, err := ParseSignature(sigBytes[:])
+		if err != nil {
+			t.Fatalf("parse sig: %v", err)
+		}
+		pks = append(pks, pk)
+		sigs = append(sigs, sig)
+	}
+
+	child, err := AggregateSignatures(pks[:2], sigs[:2], msg, 2)
+	if err != nil {
+		t.Fatalf("aggregate child: %v", err)
+	}
+	proof, err := AggregateWithChildren(pks[2:], sigs[2:], []ChildProof{{Pubkeys: pks[:2], ProofData: child}}, msg, 2)
+	if err != nil {
+		t.Fatalf("aggregate with child: %v", err)
+	}
+	if err := VerifyAggregatedSignature(proof, []CPubKey{pks[2], pks[0], pks[1]}, msg, 2); err != nil {
+		t.Fatalf("verify reordered keys: %v", err)
+	}
+	if err := VerifyAggregatedSignature(proof, pks[:2], msg, 2); !errors.Is(err, ErrVerificationFailed) {
+		t.Fatalf("verify with missing key err=%v, want ErrVerificationFailed", err)
+	}
+	if err := VerifyAggregatedSignature(proof, pks, msg, 3); !errors.Is(err, ErrVerificationFailed) {
+		t.Fatalf("verify at wrong slot err=%v, want ErrVerificationFailed", err)
+	}
+	if _, err := AggregateSignatures(pks[:1], sigs[1:2], msg, 2); !errors.Is(err, ErrAggregationFailed) {
+		t.Fatalf("aggregate mismatched signature err=%v, want ErrAggregationFailed", err)
+	}
+}
@@ -0,0 +1,114 @@
+//go:build !xmss_insecure
+
+package xmss
+
+// #include <stdint.h>
+// #include <stdlib.h>
+// #include <stdbool.h>
+// typedef struct KeyPair KeyPair;
+// typedef struct PublicKey PublicKey;
+// typedef struct PrivateKey PrivateKey;
+// typedef struct Signature Signature;
+//
+// KeyPair* hashsig_keypair_from_ssz(
+//     const uint8_t* private_key_ptr, size_t private_key_len,
+//     const uint8_t* public_key_ptr, size_t public_key_len);
+// void hashsig_keypair_free(KeyPair* keypair);
+// const PublicKey* hashsig_keypair_get_public_key(const KeyPair* keypair);
+// const PrivateKey* hashsig_keypair_get_private_key(const KeyPair* keypair);
+// Signature* hashsig_sign(const PrivateKey* private_key, const uint8_t* message_ptr, uint32_t epoch);
+// void hashsig_signature_free(Signature* signature);
+// size_t hashsig_signature_to_bytes(const Signature* signature, uint8_t* buffer, size_t buffer_len);
+// bool hashsig_private_key_activation_interval(const PrivateKey* private_key, uint64_t* start, uint64_t* end);
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type ValidatorKeyPair struct {
+	handle *C.KeyPair
+	Index  uint64
+}
+
+func (kp *ValidatorKeyPair) PublicKeyPtr() *C.PublicKey {
+	if kp == nil || kp.handle == nil {
+		return nil
+	}
+	return C.hashsig_keypair_get_public_key(kp.handle)
+}
+
+func (kp *ValidatorKeyPair) PrivateKeyPtr() *C.PrivateKey {
+	if kp == nil || kp.handle == nil {
+		return nil
+	}
+	return C.hashsig_keypair_get_private_key(kp.handle)
+}
+
+func (kp *ValidatorKeyPair) Sign(slot uint32, message [32]byte) ([types.SignatureSize]byte, error) {
+	var result [types.SignatureSize]byte
+	privateKey := kp.PrivateKeyPtr()
+	if privateKey == nil {
+		return result, fmt.Errorf("%w: keypair is nil or closed", ErrSigningFailed)
+	}
+
+	sigPtr := C.hashsig_sign(
+		privateKey,
+		(*C.uint8_t)(unsafe.Pointer(&message[0])),
+		C.uint32_t(slot),
+	)
+	if sigPtr == nil {
+		return result, fmt.Errorf("%w: validator %d slot %d", ErrSigningFailed, kp.Index, slot)
+	}
+	defer C.hashsig_signature_free(sigPtr)
+
+	buf := make([]byte, SignatureBuffer)
+	n := C.hashsig_signature_to_bytes(
+		sigPtr,
+		(*C.uint8_t)(unsafe.Pointer(&buf[0])),
+		C.size_t(len(buf)),
+	)
+	if n == 0 || int(n) != types.SignatureSize {
+		return result, fmt.Errorf("signature serialization failed: wrote %d bytes, expected %d", n, types.SignatureSize)
+	}
+
+	copy(result[:], buf[:n])
+	return result, nil
+}
+
+func (kp *ValidatorKeyPair) ActiveRange() (ActiveRange, error) {
+	privateKey := kp.PrivateKeyPtr()
+	if privateKey == nil {
+		return ActiveRange{}, fmt.Errorf("keypair is nil or closed")
+	}
+	var start, end C.uint64_t
+	if !C.hashsig_private_key_activation_interval(privateKey, &start, &end) {
+		return ActiveRange{}, fmt.Errorf("read activation interval for validator %d", kp.Index)
+	}
+	return ActiveRange{Start: uint64(start), End: uint64(end)}, nil
+}
+
+func (kp *ValidatorKeyPair) Close() {
+	if kp != nil && kp.handle != nil {
+		C.hashsig_keypair_free(kp.handle)
+		kp.handle = nil
+	}
+}
+
+func keypairFromSSZ(skBytes, pkBytes []byte, index uint64) (*ValidatorKeyPair, error) {
+	if len(skBytes) == 0 || len(pkBytes) == 0 {
+		return nil, fmt.Errorf("%w: validator %d: empty key bytes", ErrKeypairParseFailed, index)
+	}
+	handle := C.hashsig_keypair_from_ssz(
+		(*C.uint8_t)(unsafe.Pointer(&skBytes[0])), C.size_t(len(skBytes)),
+		(*C.uint8_t)(unsafe.Pointer(&pkBytes[0])), C.size_t(len(pkBytes)),
+	)
+	if handle == nil {
+		return nil, fmt.Errorf("%w: validator %d", ErrKeypairParseFailed, index)
+	}
+
+	return &ValidatorKeyPair{handle: handle, Index: index}, nil
+}
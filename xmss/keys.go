@@ -1,139 +1,98 @@
 package xmss
 
-// #include <stdint.h>
-// #include <stdlib.h>
-// typedef struct KeyPair KeyPair;
-// typedef struct PublicKey PublicKey;
-// typedef struct PrivateKey PrivateKey;
-// typedef struct Signature Signature;
-//
-// KeyPair* hashsig_keypair_from_ssz(
-//     const uint8_t* private_key_ptr, size_t private_key_len,
-//     const uint8_t* public_key_ptr, size_t public_key_len);
-// void hashsig_keypair_free(KeyPair* keypair);
-// const PublicKey* hashsig_keypair_get_public_key(const KeyPair* keypair);
-// const PrivateKey* hashsig_keypair_get_private_key(const KeyPair* keypair);
-// Signature* hashsig_sign(const PrivateKey* private_key, const uint8_t* message_ptr, uint32_t epoch);
-// void hashsig_signature_free(Signature* signature);
-// size_t hashsig_signature_to_bytes(const Signature* signature, uint8_t* buffer, size_t buffer_len);
-import "C"
-
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
-	"unsafe"
+	"sync"
 
+	"github.com/geanlabs/gean/internal/keystore"
 	"github.com/geanlabs/gean/internal/types"
 	"gopkg.in/yaml.v3"
 )
 
-type ValidatorKeyPair struct {
-	handle *C.KeyPair
-	Index  uint64
+type KeyManager struct {
+	mu              sync.RWMutex
+	attestationKeys map[uint64]Signer
+	proposalKeys    map[uint64]Signer
+	history         *SigningHistory
 }
 
-func (kp *ValidatorKeyPair) PublicKeyPtr() *C.PublicKey {
-	if kp == nil || kp.handle == nil {
-		return nil
-	}
-	return C.hashsig_keypair_get_public_key(kp.handle)
+func NewKeyManager(attestationKeys, proposalKeys map[uint64]*ValidatorKeyPair) *KeyManager {
+	return NewSignerKeyManager(localSigners(attestationKeys), localSigners(proposalKeys))
 }
 
-func (kp *ValidatorKeyPair) PrivateKeyPtr() *C.PrivateKey {
-	if kp == nil || kp.handle == nil {
-		return nil
+func NewSignerKeyManager(attestationKeys, proposalKeys map[uint64]Signer) *KeyManager {
+	return &KeyManager{
+		attestationKeys: attestationKeys,
+		proposalKeys:    proposalKeys,
+		history:         NewSigningHistory(),
 	}
-	return C.hashsig_keypair_get_private_key(kp.handle)
 }
 
-func (kp *ValidatorKeyPair) Sign(slot uint32, message [32]byte) ([types.SignatureSize]byte, error) {
-	var result [types.SignatureSize]byte
-	privateKey := kp.PrivateKeyPtr()
-	if privateKey == nil {
-		return result, fmt.Errorf("%w: keypair is nil or closed", ErrSigningFailed)
-	}
-
-	sigPtr := C.hashsig_sign(
-		privateKey,
-		(*C.uint8_t)(unsafe.Pointer(&message[0])),
-		C.uint32_t(slot),
-	)
-	if sigPtr == nil {
-		return result, fmt.Errorf("%w: validator %d slot %d", ErrSigningFailed, kp.Index, slot)
+func localSigners(keys map[uint64]*ValidatorKeyPair) map[uint64]Signer {
+	signers := make(map[uint64]Signer, len(keys))
+	for id, kp := range keys {
+		signers[id] = kp
 	}
-	defer C.hashsig_signature_free(sigPtr)
-
-	buf := make([]byte, SignatureBuffer)
-	n := C.hashsig_signature_to_bytes(
-		sigPtr,
-		(*C.uint8_t)(unsafe.Pointer(&buf[0])),
-		C.size_t(len(buf)),
-	)
-	if n == 0 || int(n) != types.SignatureSize {
-		return result, fmt.Errorf("signature serialization failed: wrote %d bytes, expected %d", n, types.SignatureSize)
-	}
-
-	copy(result[:], buf[:n])
-	return result, nil
+	return signers
 }
 
-func (kp *ValidatorKeyPair) Close() {
-	if kp != nil && kp.handle != nil {
-		C.hashsig_keypair_free(kp.handle)
-		kp.handle = nil
+func (km *KeyManager) ValidatorIDs() []uint64 {
+	if km == nil {
+		return nil
 	}
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return sortedIDs(km.attestationKeys)
 }
 
-type KeyManager struct {
-	attestationKeys map[uint64]*ValidatorKeyPair
-	proposalKeys    map[uint64]*ValidatorKeyPair
-}
-
-func NewKeyManager(attestationKeys, proposalKeys map[uint64]*ValidatorKeyPair) *KeyManager {
-	return &KeyManager{
-		attestationKeys: attestationKeys,
-		proposalKeys:    proposalKeys,
+func (km *KeyManager) GetAttestationKey(validatorID uint64) Signer {
+	if km == nil {
+		return nil
 	}
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return km.attestationKeys[validatorID]
 }
 
-func (km *KeyManager) ValidatorIDs() []uint64 {
+func (km *KeyManager) GetProposalKey(validatorID uint64) Signer {
 	if km == nil {
 		return nil
 	}
-	ids := make([]uint64, 0, len(km.attestationKeys))
-	for id := range km.attestationKeys {
-		ids = append(ids, id)
-	}
-	sort.Slice(ids, func(i, j int) bool {
-		return ids[i] < ids[j]
-	})
-	return ids
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return km.proposalKeys[validatorID]
 }
 
-func (km *KeyManager) GetAttestationKey(validatorID uint64) *ValidatorKeyPair {
+func (km *KeyManager) SigningHistory() *SigningHistory {
 	if km == nil {
 		return nil
 	}
-	return km.attestationKeys[validatorID]
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return km.history
 }
 
-func (km *KeyManager) GetProposalKey(validatorID uint64) *ValidatorKeyPair {
-	if km == nil {
-		return nil
+// UseSigningHistory replaces the in-memory history, typically with one loaded
+// from disk so protection survives restarts.
+func (km *KeyManager) UseSigningHistory(h *SigningHistory) {
+	if km != nil && h != nil {
+		km.mu.Lock()
+		km.history = h
+		km.mu.Unlock()
 	}
-	return km.proposalKeys[validatorID]
 }
 
 func (km *KeyManager) SignAttestation(validatorID uint64, data *types.AttestationData) ([types.SignatureSize]byte, error) {
 	if km == nil {
 		return [types.SignatureSize]byte{}, fmt.Errorf("key manager is nil")
 	}
-	kp := km.GetAttestationKey(validatorID)
-	if kp == nil || kp.handle == nil {
+	signer := km.GetAttestationKey(validatorID)
+	if signer == nil {
 		return [types.SignatureSize]byte{}, fmt.Errorf("attestation key for validator %d not found", validatorID)
 	}
 	if data == nil {
@@ -149,16 +108,22 @@ func (km *KeyManager) SignAttestation(validatorID uint64, data *types.Attestatio
 	if uint64(slot) != data.Slot {
 		return [types.SignatureSize]byte{}, fmt.Errorf("slot %d overflows uint32", data.Slot)
 	}
+	if err := checkActive(signer, validatorID, RoleAttestation, data.Slot); err != nil {
+		return [types.SignatureSize]byte{}, err
+	}
+	if err := km.SigningHistory().CheckAndRecord(validatorID, RoleAttestation, data.Slot, msgRoot); err != nil {
+		return [types.SignatureSize]byte{}, err
+	}
 
-	return kp.Sign(slot, msgRoot)
+	return signer.Sign(slot, msgRoot)
 }
 
 func (km *KeyManager) SignBlock(validatorID uint64, slot uint64, blockRoot [32]byte) ([types.SignatureSize]byte, error) {
 	if km == nil {
 		return [types.SignatureSize]byte{}, fmt.Errorf("key manager is nil")
 	}
-	kp := km.GetProposalKey(validatorID)
-	if kp == nil || kp.handle == nil {
+	signer := km.GetProposalKey(validatorID)
+	if signer == nil {
 		return [types.SignatureSize]byte{}, fmt.Errorf("proposal key for validator %d not found", validatorID)
 	}
 
@@ -166,19 +131,27 @@ func (km *KeyManager) SignBlock(validatorID uint64, slot uint64, blockRoot [32]b
 	if uint64(s) != slot {
 		return [types.SignatureSize]byte{}, fmt.Errorf("slot %d overflows uint32", slot)
 	}
+	if err := checkActive(signer, validatorID, RoleProposal, slot); err != nil {
+		return [types.SignatureSize]byte{}, err
+	}
+	if err := km.SigningHistory().CheckAndRecord(validatorID, RoleProposal, slot, blockRoot); err != nil {
+		return [types.SignatureSize]byte{}, err
+	}
 
-	return kp.Sign(s, blockRoot)
+	return signer.Sign(s, blockRoot)
 }
 
 func (km *KeyManager) Close() {
 	if km == nil {
 		return
 	}
-	for _, kp := range km.attestationKeys {
-		kp.Close()
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	for _, signer := range km.attestationKeys {
+		signer.Close()
 	}
-	for _, kp := range km.proposalKeys {
-		kp.Close()
+	for _, signer := range km.proposalKeys {
+		signer.Close()
 	}
 }
 
@@ -193,6 +166,12 @@ type annotatedValidator struct {
 }
 
 func LoadValidatorKeys(annotatedPath, keysDir, nodeID string) (*KeyManager, error) {
+	return LoadValidatorKeysWithPasswords(annotatedPath, keysDir, nodeID, nil)
+}
+
+// LoadValidatorKeysWithPasswords is LoadValidatorKeys for key directories that
+// may hold encrypted keystores; passwords is consulted for each one.
+func LoadValidatorKeysWithPasswords(annotatedPath, keysDir, nodeID string, passwords keystore.PasswordSource) (*KeyManager, error) {
 	data, err := os.ReadFile(annotatedPath)
 	if err != nil {
 		return nil, fmt.Errorf("read annotated validators: %w", err)
@@ -213,7 +192,7 @@ func LoadValidatorKeys(annotatedPath, keysDir, nodeID string) (*KeyManager, erro
 
 	for _, v := range validators {
 		if v.PrivkeyFile != "" {
-			kp, err := loadKeypair(keysDir, v.PrivkeyFile, v.PubkeyHex, v.Index)
+			kp, err := loadKeypair(keysDir, v.PrivkeyFile, v.PubkeyHex, v.Index, passwords)
 			if err != nil {
 				return nil, fmt.Errorf("load key for validator %d (%s): %w", v.Index, v.PrivkeyFile, err)
 			}
@@ -236,13 +215,13 @@ func LoadValidatorKeys(annotatedPath, keysDir, nodeID string) (*KeyManager, erro
 			if v.ProposalSkFile == "" {
 				return nil, fmt.Errorf("proposal key file missing for validator %d", v.Index)
 			}
-			attKp, err := loadKeypair(keysDir, v.AttestationSkFile, v.AttestationPubkey, v.Index)
+			attKp, err := loadKeypair(keysDir, v.AttestationSkFile, v.AttestationPubkey, v.Index, passwords)
 			if err != nil {
 				return nil, fmt.Errorf("load attestation key for validator %d: %w", v.Index, err)
 			}
 			attestationKeys[v.Index] = attKp
 
-			propKp, err := loadKeypair(keysDir, v.ProposalSkFile, v.ProposalPubkey, v.Index)
+			propKp, err := loadKeypair(keysDir, v.ProposalSkFile, v.ProposalPubkey, v.Index, passwords)
 			if err != nil {
 				return nil, fmt.Errorf("load proposal key for validator %d: %w", v.Index, err)
 			}
@@ -253,7 +232,7 @@ func LoadValidatorKeys(annotatedPath, keysDir, nodeID string) (*KeyManager, erro
 	return NewKeyManager(attestationKeys, proposalKeys), nil
 }
 
-func loadKeypair(keysDir, skFile, pubkeyHex string, index uint64) (*ValidatorKeyPair, error) {
+func loadKeypair(keysDir, skFile, pubkeyHex string, index uint64, passwords keystore.PasswordSource) (*ValidatorKeyPair, error) {
 	skPath := skFile
 	if !filepath.IsAbs(skPath) {
 		skPath = filepath.Join(keysDir, skFile)
@@ -267,6 +246,22 @@ func loadKeypair(keysDir, skFile, pubkeyHex string, index uint64) (*ValidatorKey
 		return nil, fmt.Errorf("secret key is empty")
 	}
 
+	pkBytes, err := decodePubkeyHex(pubkeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	if keystore.IsKeystore(skBytes) {
+		skBytes, err = decryptKeystore(skPath, skBytes, pkBytes, passwords)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keypairFromSSZ(skBytes, pkBytes, index)
+}
+
+func decodePubkeyHex(pubkeyHex string) ([]byte, error) {
 	pkHex := strings.TrimSpace(pubkeyHex)
 	if len(pkHex) >= 2 && pkHex[0] == '0' && (pkHex[1] == 'x' || pkHex[1] == 'X') {
 		pkHex = pkHex[2:]
@@ -278,14 +273,27 @@ func loadKeypair(keysDir, skFile, pubkeyHex string, index uint64) (*ValidatorKey
 	if len(pkBytes) != types.PubkeySize {
 		return nil, fmt.Errorf("pubkey has %d bytes, expected %d", len(pkBytes), types.PubkeySize)
 	}
+	return pkBytes, nil
+}
 
-	handle := C.hashsig_keypair_from_ssz(
-		(*C.uint8_t)(unsafe.Pointer(&skBytes[0])), C.size_t(len(skBytes)),
-		(*C.uint8_t)(unsafe.Pointer(&pkBytes[0])), C.size_t(len(pkBytes)),
-	)
-	if handle == nil {
-		return nil, fmt.Errorf("%w: validator %d", ErrKeypairParseFailed, index)
+func decryptKeystore(path string, data, pubkey []byte, passwords keystore.PasswordSource) ([]byte, error) {
+	if passwords == nil {
+		return nil, fmt.Errorf("%s is an encrypted keystore: %w", filepath.Base(path), keystore.ErrNoPassword)
 	}
-
-	return &ValidatorKeyPair{handle: handle, Index: index}, nil
+	ks, err := keystore.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	ksPubkey, err := ks.PubkeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("keystore pubkey: %w", err)
+	}
+	if !bytes.Equal(ksPubkey, pubkey) {
+		return nil, fmt.Errorf("keystore pubkey does not match annotated pubkey")
+	}
+	password, err := passwords(path)
+	if err != nil {
+		return nil, err
+	}
+	return ks.Decrypt(password)
 }
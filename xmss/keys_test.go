@@ -1,11 +1,14 @@
 package xmss
 
 import (
+	"encoding/hex"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/geanlabs/gean/internal/keystore"
 	"github.com/geanlabs/gean/internal/types"
 )
 
@@ -76,11 +79,43 @@ func TestLoadKeypairRejectsEmptySecretKey(t *testing.T) {
 	}
 
 	pubkeyHex := "0X" + strings.Repeat("00", types.PubkeySize)
-	if _, err := loadKeypair(dir, "empty.sk", pubkeyHex, 0); err == nil || !strings.Contains(err.Error(), "secret key is empty") {
+	if _, err := loadKeypair(dir, "empty.sk", pubkeyHex, 0, nil); err == nil || !strings.Contains(err.Error(), "secret key is empty") {
 		t.Fatalf("loadKeypair error=%v, want empty secret key rejection", err)
 	}
 }
 
+func TestLoadKeypairKeystoreChecks(t *testing.T) {
+	dir := t.TempDir()
+	pubkey := make([]byte, types.PubkeySize)
+	pubkey[0] = 0x01
+	ks, err := keystore.Encrypt([]byte{0x01, 0x02}, "pw", pubkey, "", "", keystore.Params{KDF: keystore.KDFPBKDF2, Iterations: 16})
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	data, err := ks.Marshal()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "key.json"), data, 0o600); err != nil {
+		t.Fatalf("write keystore: %v", err)
+	}
+	pubkeyHex := hex.EncodeToString(pubkey)
+	password := func(pw string) keystore.PasswordSource {
+		return func(string) (string, error) { return pw, nil }
+	}
+
+	if _, err := loadKeypair(dir, "key.json", pubkeyHex, 0, nil); !errors.Is(err, keystore.ErrNoPassword) {
+		t.Fatalf("no password err=%v, want ErrNoPassword", err)
+	}
+	if _, err := loadKeypair(dir, "key.json", pubkeyHex, 0, password("nope")); !errors.Is(err, keystore.ErrInvalidPassword) {
+		t.Fatalf("wrong password err=%v, want ErrInvalidPassword", err)
+	}
+	otherPubkey := strings.Repeat("00", types.PubkeySize)
+	if _, err := loadKeypair(dir, "key.json", otherPubkey, 0, password("pw")); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("pubkey mismatch err=%v", err)
+	}
+}
+
 func TestKeyManagerDualKeyRouting(t *testing.T) {
 	attKp, err := GenerateKeyPair("test-attestation-key-0", 0, 1<<16)
 	if err != nil {
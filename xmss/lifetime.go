@@ -0,0 +1,105 @@
+package xmss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrKeyExpired = errors.New("validator key expired")
+
+// ActiveRange is the half-open slot range [Start, End) a key was generated
+// for. Every slot consumes one one-time key epoch.
+type ActiveRange struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+func (r ActiveRange) Expired(slot uint64) bool {
+	return slot >= r.End
+}
+
+// Remaining is the number of slots from slot (or activation, if later) until
+// the key is exhausted.
+func (r ActiveRange) Remaining(slot uint64) uint64 {
+	from := max(slot, r.Start)
+	if from >= r.End {
+		return 0
+	}
+	return r.End - from
+}
+
+type KeyLifetime struct {
+	ValidatorIndex uint64
+	Role           SigningRole
+	ActiveRange
+}
+
+// Lifetimes reports the active range of every key. Keys whose range cannot be
+// read are skipped and reported in the returned error.
+func (km *KeyManager) Lifetimes() ([]KeyLifetime, error) {
+	if km == nil {
+		return nil, nil
+	}
+	var (
+		lifetimes []KeyLifetime
+		errs      []error
+	)
+	collect := func(role SigningRole, keys map[uint64]Signer) {
+		for _, vid := range sortedIDs(keys) {
+			r, err := keys[vid].ActiveRange()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("validator %d %s key: %w", vid, role, err))
+				continue
+			}
+			lifetimes = append(lifetimes, KeyLifetime{ValidatorIndex: vid, Role: role, ActiveRange: r})
+		}
+	}
+	km.mu.RLock()
+	collect(RoleAttestation, km.attestationKeys)
+	collect(RoleProposal, km.proposalKeys)
+	km.mu.RUnlock()
+	return lifetimes, errors.Join(errs...)
+}
+
+// CheckExpiry fails if any key can no longer sign at slot. Keys whose range
+// cannot be read are not checked; Lifetimes reports those.
+func (km *KeyManager) CheckExpiry(slot uint64) error {
+	lifetimes, _ := km.Lifetimes()
+	var expired []string
+	for _, lt := range lifetimes {
+		if lt.Expired(slot) {
+			expired = append(expired, fmt.Sprintf("validator %d %s (end %d)", lt.ValidatorIndex, lt.Role, lt.End))
+		}
+	}
+	if len(expired) > 0 {
+		return fmt.Errorf("%w at slot %d: %s", ErrKeyExpired, slot, strings.Join(expired, ", "))
+	}
+	return nil
+}
+
+func checkActive(signer Signer, validatorID uint64, role SigningRole, slot uint64) error {
+	r, err := signer.ActiveRange()
+	if err != nil {
+		// Unknown range: let the signer itself decide.
+		return nil
+	}
+	if slot < r.Start || r.Expired(slot) {
+		return fmt.Errorf("%w: validator %d %s key active for slots [%d, %d), asked to sign slot %d",
+			ErrKeyExpired, validatorID, role, r.Start, r.End, slot)
+	}
+	return nil
+}
+
+// ExpiringKeys returns the keys that have at most within slots left at slot,
+// including keys that are already exhausted.
+func (km *KeyManager) ExpiringKeys(slot, within uint64) []KeyLifetime {
+	lifetimes, _ := km.Lifetimes()
+	var expiring []KeyLifetime
+	for _, lt := range lifetimes {
+		if lt.Remaining(slot) <= within {
+			expiring = append(expiring, lt)
+		}
+	}
+	return expiring
+}
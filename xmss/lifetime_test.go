@@ -0,0 +1,67 @@
+package xmss
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+type rangeSigner struct {
+	r   ActiveRange
+	err error
+}
+
+func (s rangeSigner) Sign(uint32, [32]byte) ([types.SignatureSize]byte, error) {
+	return [types.SignatureSize]byte{}, nil
+}
+
+func (s rangeSigner) ActiveRange() (ActiveRange, error) { return s.r, s.err }
+
+func (s rangeSigner) Close() {}
+
+func TestActiveRangeRemaining(t *testing.T) {
+	r := ActiveRange{Start: 10, End: 20}
+	cases := map[uint64]uint64{0: 10, 10: 10, 15: 5, 19: 1, 20: 0, 50: 0}
+	for slot, want := range cases {
+		if got := r.Remaining(slot); got != want {
+			t.Fatalf("Remaining(%d)=%d, want %d", slot, got, want)
+		}
+	}
+}
+
+func TestKeyManagerCheckExpiry(t *testing.T) {
+	km := NewSignerKeyManager(
+		map[uint64]Signer{0: rangeSigner{r: ActiveRange{End: 100}}, 1: rangeSigner{r: ActiveRange{End: 50}}},
+		map[uint64]Signer{0: rangeSigner{r: ActiveRange{End: 100}}},
+	)
+	if err := km.CheckExpiry(49); err != nil {
+		t.Fatalf("CheckExpiry(49): %v", err)
+	}
+	if err := km.CheckExpiry(50); !errors.Is(err, ErrKeyExpired) {
+		t.Fatalf("CheckExpiry(50) err=%v, want ErrKeyExpired", err)
+	}
+
+	expiring := km.ExpiringKeys(40, 10)
+	if len(expiring) != 1 || expiring[0].ValidatorIndex != 1 || expiring[0].Role != RoleAttestation {
+		t.Fatalf("expiring=%+v, want validator 1 attestation", expiring)
+	}
+
+	if _, err := km.SignBlock(0, 100, [32]byte{}); !errors.Is(err, ErrKeyExpired) {
+		t.Fatalf("SignBlock past end err=%v, want ErrKeyExpired", err)
+	}
+	if _, err := km.SignBlock(0, 99, [32]byte{}); err != nil {
+		t.Fatalf("SignBlock in range: %v", err)
+	}
+}
+
+func TestKeyManagerLifetimesReportsUnreadableKeys(t *testing.T) {
+	km := NewSignerKeyManager(map[uint64]Signer{
+		0: rangeSigner{r: ActiveRange{End: 10}},
+		1: rangeSigner{err: errors.New("closed")},
+	}, nil)
+	lifetimes, err := km.Lifetimes()
+	if err == nil || len(lifetimes) != 1 {
+		t.Fatalf("lifetimes=%+v err=%v, want one lifetime and an error", lifetimes, err)
+	}
+}
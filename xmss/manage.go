@@ -0,0 +1,117 @@
+package xmss
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/geanlabs/gean/internal/keystore"
+)
+
+var ErrValidatorKeysExist = errors.New("validator keys already loaded")
+
+// ValidatorKeys is a decrypted attestation/proposal key pair ready to be
+// added to a running KeyManager.
+type ValidatorKeys struct {
+	ValidatorIndex    uint64
+	AttestationPubkey []byte
+	ProposalPubkey    []byte
+	Attestation       Signer
+	Proposal          Signer
+}
+
+func (vk *ValidatorKeys) Close() {
+	if vk == nil {
+		return
+	}
+	if vk.Attestation != nil {
+		vk.Attestation.Close()
+	}
+	if vk.Proposal != nil {
+		vk.Proposal.Close()
+	}
+}
+
+// LoadKeystoreKeys decrypts an EIP-2335 attestation and proposal keystore
+// pair. The public keys are taken from the keystores themselves.
+func LoadKeystoreKeys(index uint64, attestationKeystore, proposalKeystore []byte, password string) (*ValidatorKeys, error) {
+	attPub, att, err := keypairFromKeystore(index, attestationKeystore, password)
+	if err != nil {
+		return nil, fmt.Errorf("attestation keystore: %w", err)
+	}
+	propPub, prop, err := keypairFromKeystore(index, proposalKeystore, password)
+	if err != nil {
+		att.Close()
+		return nil, fmt.Errorf("proposal keystore: %w", err)
+	}
+	return &ValidatorKeys{
+		ValidatorIndex:    index,
+		AttestationPubkey: attPub,
+		ProposalPubkey:    propPub,
+		Attestation:       att,
+		Proposal:          prop,
+	}, nil
+}
+
+func keypairFromKeystore(index uint64, data []byte, password string) ([]byte, *ValidatorKeyPair, error) {
+	ks, err := keystore.Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubkey, err := ks.PubkeyBytes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("keystore pubkey: %w", err)
+	}
+	secret, err := ks.Decrypt(password)
+	if err != nil {
+		return nil, nil, err
+	}
+	kp, err := keypairFromSSZ(secret, pubkey, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pubkey, kp, nil
+}
+
+// AddValidator starts signing for a validator without restarting the node.
+func (km *KeyManager) AddValidator(keys *ValidatorKeys) error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	vid := keys.ValidatorIndex
+	if _, ok := km.attestationKeys[vid]; ok {
+		return fmt.Errorf("%w: validator %d", ErrValidatorKeysExist, vid)
+	}
+	if _, ok := km.proposalKeys[vid]; ok {
+		return fmt.Errorf("%w: validator %d", ErrValidatorKeysExist, vid)
+	}
+	if km.attestationKeys == nil {
+		km.attestationKeys = make(map[uint64]Signer)
+	}
+	if km.proposalKeys == nil {
+		km.proposalKeys = make(map[uint64]Signer)
+	}
+	km.attestationKeys[vid] = keys.Attestation
+	km.proposalKeys[vid] = keys.Proposal
+	return nil
+}
+
+// RemoveValidator stops signing for a validator and releases its keys. The
+// validator's signing history is kept so the keys cannot be re-imported and
+// used to sign below a slot they already signed.
+func (km *KeyManager) RemoveValidator(validatorID uint64) bool {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	att, hasAtt := km.attestationKeys[validatorID]
+	prop, hasProp := km.proposalKeys[validatorID]
+	if !hasAtt && !hasProp {
+		return false
+	}
+	delete(km.attestationKeys, validatorID)
+	delete(km.proposalKeys, validatorID)
+	if att != nil {
+		att.Close()
+	}
+	if prop != nil && prop != att {
+		prop.Close()
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package xmss
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyManagerAddRemoveValidator(t *testing.T) {
+	km := NewSignerKeyManager(map[uint64]Signer{0: rangeSigner{}}, map[uint64]Signer{0: rangeSigner{}})
+
+	if err := km.AddValidator(&ValidatorKeys{ValidatorIndex: 0, Attestation: rangeSigner{}, Proposal: rangeSigner{}}); !errors.Is(err, ErrValidatorKeysExist) {
+		t.Fatalf("duplicate add err=%v, want ErrValidatorKeysExist", err)
+	}
+	if err := km.AddValidator(&ValidatorKeys{ValidatorIndex: 3, Attestation: rangeSigner{}, Proposal: rangeSigner{}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if ids := km.ValidatorIDs(); len(ids) != 2 || ids[1] != 3 {
+		t.Fatalf("ids=%v, want [0 3]", ids)
+	}
+
+	if !km.RemoveValidator(0) {
+		t.Fatal("remove 0 returned false")
+	}
+	if km.RemoveValidator(0) {
+		t.Fatal("second remove 0 returned true")
+	}
+	if km.GetAttestationKey(0) != nil || km.GetProposalKey(0) != nil {
+		t.Fatal("removed validator still has keys")
+	}
+}
+
+func TestSigningHistoryImportKeepsHigherSlot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h, err := LoadSigningHistory(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if err := h.CheckAndRecord(1, RoleAttestation, 10, [32]byte{0x01}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = h.Import([]SigningRecord{
+		{ValidatorIndex: 1, Role: RoleAttestation, Slot: 10, MessageRoot: rootHex(0x02)},
+		{ValidatorIndex: 1, Role: RoleProposal, Slot: 8, MessageRoot: rootHex(0x03)},
+		{ValidatorIndex: 2, Role: RoleAttestation, Slot: 20, MessageRoot: rootHex(0x04)},
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if err := h.CheckAndRecord(1, RoleAttestation, 10, [32]byte{0x01}); err != nil {
+		t.Fatalf("local entry at equal slot was replaced: %v", err)
+	}
+	if err := h.CheckAndRecord(2, RoleAttestation, 19, [32]byte{}); !errors.Is(err, ErrSigningHistoryConflict) {
+		t.Fatalf("imported slot not enforced: %v", err)
+	}
+	if got := h.ExportValidator(1); len(got) != 2 {
+		t.Fatalf("export validator 1=%+v, want 2 records", got)
+	}
+
+	reloaded, err := LoadSigningHistory(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(reloaded.Records()) != 3 {
+		t.Fatalf("persisted records=%d, want 3", len(reloaded.Records()))
+	}
+
+	if err := h.Import([]SigningRecord{{ValidatorIndex: 1, Role: "vote", MessageRoot: rootHex(0)}}); err == nil {
+		t.Fatal("expected unknown role error")
+	}
+}
+
+func rootHex(b byte) string {
+	return fmt.Sprintf("0x%x", [32]byte{b})
+}
@@ -1,3 +1,5 @@
+//go:build !xmss_insecure
+
 package xmss
 
 import "sync"
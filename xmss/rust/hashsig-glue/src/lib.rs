@@ -1,4 +1,7 @@
-use leansig::{signature::SignatureScheme, MESSAGE_LENGTH};
+use leansig::{
+    signature::{SignatureScheme, SignatureSchemeSecretKey},
+    MESSAGE_LENGTH,
+};
 use rand::rngs::StdRng;
 use rand::Rng;
 use rand::SeedableRng;
@@ -360,6 +363,25 @@ pub unsafe extern "C" fn hashsig_private_key_to_bytes(
     }
 }
 
+/// Writes the half-open epoch range the secret key was generated for.
+/// Returns false if any pointer is null.
+#[no_mangle]
+pub unsafe extern "C" fn hashsig_private_key_activation_interval(
+    private_key: *const PrivateKey,
+    start: *mut u64,
+    end: *mut u64,
+) -> bool {
+    if private_key.is_null() || start.is_null() || end.is_null() {
+        return false;
+    }
+    unsafe {
+        let range = (*private_key).inner.get_activation_interval();
+        *start = range.start;
+        *end = range.end;
+    }
+    true
+}
+
 #[no_mangle]
 pub unsafe extern "C" fn hashsig_verify_ssz(
     pubkey_bytes: *const u8,
@@ -0,0 +1,90 @@
+package xmss
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+const (
+	MessageLength    = 32
+	MaxProofSize     = 1 << 20
+	SignatureBuffer  = 4000
+	PubkeyBuffer     = 256
+	PrivateKeyBuffer = 10 << 20
+)
+
+var (
+	ErrEmptyInput          = errors.New("empty input")
+	ErrCountMismatch       = errors.New("public key count does not match signature count")
+	ErrAggregationFailed   = errors.New("signature aggregation failed")
+	ErrSerializationFailed = errors.New("proof serialization failed")
+	ErrProofTooBig         = errors.New("aggregated proof exceeds 1 MiB")
+	ErrVerificationFailed  = errors.New("aggregated signature verification failed")
+	ErrDeserializeFailed   = errors.New("proof deserialization failed")
+	ErrSigningFailed       = errors.New("signing failed")
+	ErrInvalidSignature    = errors.New("signature verification returned invalid")
+	ErrSignatureError      = errors.New("signature verification error (malformed data)")
+	ErrPubkeyParseFailed   = errors.New("public key parsing failed")
+	ErrKeypairParseFailed  = errors.New("keypair parsing failed")
+	ErrMalformedChildProof = errors.New("malformed child proof")
+	ErrMalformedRawInput   = errors.New("malformed raw signature input")
+)
+
+type CPubKey = unsafe.Pointer
+
+type CSig = unsafe.Pointer
+
+type ChildProof struct {
+	Pubkeys   []CPubKey
+	ProofData []byte
+}
+
+const LogInvRate = 2
+
+func AggregateSignatures(
+	pubkeys []CPubKey,
+	sigs []CSig,
+	message [32]byte,
+	slot uint32,
+) ([]byte, error) {
+	return AggregateWithChildren(pubkeys, sigs, nil, message, slot)
+}
+
+func validateRawInputs(pubkeys []CPubKey, sigs []CSig) error {
+	if err := validatePublicKeys(pubkeys); err != nil {
+		return err
+	}
+	for i, sig := range sigs {
+		if sig == nil {
+			return fmt.Errorf("%w: signature %d is nil", ErrMalformedRawInput, i)
+		}
+	}
+	return nil
+}
+
+func validatePublicKeys(pubkeys []CPubKey) error {
+	for i, pk := range pubkeys {
+		if pk == nil {
+			return fmt.Errorf("%w: pubkey %d is nil", ErrMalformedRawInput, i)
+		}
+	}
+	return nil
+}
+
+func validateChildProofs(children []ChildProof) error {
+	for i, child := range children {
+		if len(child.Pubkeys) == 0 {
+			return fmt.Errorf("%w: child %d has no pubkeys", ErrMalformedChildProof, i)
+		}
+		if len(child.ProofData) == 0 {
+			return fmt.Errorf("%w: child %d proof data is empty", ErrMalformedChildProof, i)
+		}
+		for j, pk := range child.Pubkeys {
+			if pk == nil {
+				return fmt.Errorf("%w: child %d pubkey %d is nil", ErrMalformedChildProof, i, j)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package xmss
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/geanlabs/gean/internal/types"
+)
+
+// Signer produces an XMSS signature for a 32-byte message at a slot. The
+// local implementation is ValidatorKeyPair; remote implementations forward
+// the request to a signing service that holds the secret key.
+type Signer interface {
+	Sign(slot uint32, message [32]byte) ([types.SignatureSize]byte, error)
+	ActiveRange() (ActiveRange, error)
+	Close()
+}
+
+func sortedIDs(keys map[uint64]Signer) []uint64 {
+	ids := make([]uint64, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func sortRecords(records []SigningRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].ValidatorIndex != records[j].ValidatorIndex {
+			return records[i].ValidatorIndex < records[j].ValidatorIndex
+		}
+		return records[i].Role < records[j].Role
+	})
+}
+
+func decodeRoot(s string) ([32]byte, error) {
+	var root [32]byte
+	raw, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return root, fmt.Errorf("decode message root: %w", err)
+	}
+	if len(raw) != len(root) {
+		return root, fmt.Errorf("message root has %d bytes, expected %d", len(raw), len(root))
+	}
+	copy(root[:], raw)
+	return root, nil
+}